@@ -4,6 +4,7 @@ import (
 	"e-commerce/api/handler"
 	"e-commerce/config"
 	"e-commerce/pkg/logger"
+	"e-commerce/pkg/ratelimit"
 	"e-commerce/service"
 	"e-commerce/storage"
 
@@ -14,72 +15,166 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func NewApi(r *gin.Engine, cfg *config.Config, storage storage.StorageI, logger logger.LoggerI, service service.IServiceManager) {
-	h := handler.NewHandler(cfg, storage, logger, service)
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
+func NewApi(r *gin.Engine, cfg *config.Config, storage storage.StorageI, logger logger.LoggerI, service service.IServiceManager, limiter ratelimit.Limiter) {
+	h := handler.NewHandler(cfg, storage, logger, service, limiter)
 	r.Use(customCORSMiddleware())
 	v1 := r.Group("/e_commerce/api/v1")
 
-	v1.POST("/login", h.UserLogin)
-	v1.POST("/sendcode", h.UserRegister)
-	v1.POST("/verifycode", h.UserRegisterConfirm)
-	v1.POST("/byphoneconfirm", h.UserLoginByPhoneConfirm)
-
-	v1.POST("/admin/login", h.AdminLogin)
-
-	v1.POST("/admin", h.CreateAdmin)
-	v1.GET("/admin/:id", h.GetByIdAdmin)
-	v1.GET("/admin", h.GetListAdmin)
-	v1.PUT("/admin/:id", h.UpdateAdmin)
-	v1.DELETE("/admin/:id", h.DeleteAdmin)
-
-	v1.POST("/color", h.CreateColor)
-	v1.GET("/color", h.GetListColor)
-	v1.DELETE("/color/:id", h.DeleteColor)
-
-	v1.POST("/banner", h.CreateBanner)
-	v1.GET("/banner/:id", h.GetByIdBanner)
-	v1.GET("/banner", h.GetListBanner)
-	v1.PUT("/banner/:id", h.UpdateBanner)
-	v1.DELETE("/banner/:id", h.DeleteBanner)
-
-	v1.POST("/customer", h.CreateCustomer)
-	v1.GET("/customer/:id", h.GetByIdCustomer)
-	v1.GET("/customer", h.GetListCustomer)
-	v1.PUT("/customer/:id", h.UpdateCustomer)
-	v1.DELETE("/customer/:id", h.DeleteCustomer)
-
-	v1.POST("/brand", h.CreateBrand)
-	v1.GET("/brand/:id", h.GetByIdBrand)
-	v1.GET("/brand", h.GetListBrand)
-	v1.PUT("/brand/:id", h.UpdateBrand)
-	v1.DELETE("/brand/:id", h.DeleteBrand)
-
-	v1.POST("/category", h.CreateCategory)
-	v1.GET("/category/:id", h.GetByIdCategory)
-	v1.GET("/category", h.GetListCategory)
-	v1.PUT("/category/:id", h.UpdateCategory)
-	v1.DELETE("/category/:id", h.DeleteCategory)
-
-	v1.POST("/order", h.CreateOrder)
-	v1.GET("/order/:id", h.GetByIdOrder)
-	v1.GET("/order", h.GetAllOrders)
-	v1.PUT("/order/:id", h.UpdateOrder)
-	v1.DELETE("/order/:id", h.DeleteOrder)
-
-	v1.POST("/product", h.CreateProduct)
-	v1.GET("/product/:id", h.GetByIdProduct)
-	v1.GET("/product", h.GetListProduct)
-	v1.PUT("/product/:id", h.UpdateProduct)
-	v1.DELETE("/product/:id", h.DeleteProduct)
-
-	v1.POST("upload-files", h.UploadFiles)
-	v1.DELETE("delete-file", h.DeleteFile)
-
-	v1.POST("/location", h.CreateLocation)
-	v1.GET("/location/:id", h.GetByIdLocation)
-	v1.GET("/location", h.GetListLocation)
-	v1.PUT("/location/:id", h.UpdateLocation)
-	v1.DELETE("/location/:id", h.DeleteLocation)
+	var (
+		smsIP        = mustPolicy("sms_ip", cfg.RateLimitSmsIP)
+		smsPhone     = mustPolicy("sms_phone", cfg.RateLimitSmsPhone)
+		loginIP      = mustPolicy("login_ip", cfg.RateLimitLoginIP)
+		loginAccount = mustPolicy("login_account", cfg.RateLimitLoginAccount)
+		user         = mustPolicy("user", cfg.RateLimitUser)
+	)
+
+	// public routes, the ones sending a paid sms or checking credentials are rate limited
+	sms := v1.Group("", h.RateLimitByIP(smsIP), h.RateLimitByPhone(smsPhone))
+	sms.POST("/sendcode", h.UserRegister)
+	sms.POST("/byphone", h.UserLoginByPhone)
+
+	auth := v1.Group("", h.RateLimitByIP(loginIP))
+	auth.POST("/login", h.RateLimitByPhone(loginAccount), h.UserLogin)
+	auth.POST("/admin/login", h.RateLimitByPhone(loginAccount), h.AdminLogin)
+	auth.POST("/verifycode", h.UserRegisterConfirm)
+	auth.POST("/byphoneconfirm", h.UserLoginByPhoneConfirm)
+	auth.POST("/refresh", h.RefreshToken)
+	auth.POST("/logout", h.Logout)
+
+	v1.GET("/color", h.OptionalAuthMiddleware(), h.GetListColor)
+
+	v1.GET("/banner/:id", h.OptionalAuthMiddleware(), h.GetByIdBanner)
+	v1.GET("/banner", h.OptionalAuthMiddleware(), h.GetListBanner)
+
+	v1.GET("/brand/:id", h.OptionalAuthMiddleware(), h.GetByIdBrand)
+	v1.GET("/brand", h.OptionalAuthMiddleware(), h.GetListBrand)
+
+	v1.GET("/category/tree", h.GetTreeCategory)
+	v1.GET("/category/:id/breadcrumbs", h.GetBreadcrumbsCategory)
+	v1.GET("/category/:id", h.OptionalAuthMiddleware(), h.GetByIdCategory)
+	v1.GET("/category", h.OptionalAuthMiddleware(), h.GetListCategory)
+
+	v1.GET("/product/:id/variant", h.GetListVariant)
+	v1.GET("/product/:id/media", h.GetListMedia)
+	v1.GET("/product/:id/review", h.GetListProductReview)
+	v1.GET("/product/:id/related", h.OptionalAuthMiddleware(), h.GetRelatedProduct)
+	v1.GET("/product/:id/bought-together", h.OptionalAuthMiddleware(), h.GetBoughtTogetherProduct)
+	v1.GET("/product/:id", h.OptionalAuthMiddleware(), h.GetByIdProduct)
+	v1.GET("/product", h.OptionalAuthMiddleware(), h.GetListProduct)
+
+	v1.GET("/location/:id", h.OptionalAuthMiddleware(), h.GetByIdLocation)
+	v1.GET("/location", h.OptionalAuthMiddleware(), h.GetListLocation)
+
+	secured := v1.Group("", h.AuthMiddleware(), h.RateLimitByUser(user))
+
+	// customer-only routes
+	customer := secured.Group("", h.RoleMiddleware(config.CUSTOMER_ROLE))
+
+	customer.POST("/order", h.CreateOrder)
+	customer.POST("/product/:id/review", h.CreateReview)
+	customer.POST("/review/photos", h.UploadReviewPhotos)
+	customer.POST("/product/:id/favorite", h.CreateFavorite)
+	customer.DELETE("/product/:id/favorite", h.DeleteFavorite)
+	customer.GET("/favorite", h.GetListFavorite)
+
+	// routes shared by customers and admins, ownership is checked in handlers
+	account := secured.Group("", h.RoleMiddleware(config.CUSTOMER_ROLE, config.ADMIN_ROLE))
+
+	account.GET("/customer/:id", h.GetByIdCustomer)
+	account.PUT("/customer/:id", h.UpdateCustomer)
+
+	account.GET("/order/:id", h.GetByIdOrder)
+	account.GET("/order", h.GetAllOrders)
+
+	// admin-only routes, each guarded by the permission the admin's role must grant
+	admin := secured.Group("", h.RoleMiddleware(config.ADMIN_ROLE))
+
+	admins := admin.Group("", h.PermissionMiddleware(config.PERMISSION_ADMIN_MANAGE))
+	admins.POST("/admin", h.CreateAdmin)
+	admins.GET("/admin/:id", h.GetByIdAdmin)
+	admins.GET("/admin", h.GetListAdmin)
+	admins.PUT("/admin/:id", h.UpdateAdmin)
+	admins.DELETE("/admin/:id", h.DeleteAdmin)
+	admins.PUT("/admin/:id/role", h.UpdateAdminRole)
+
+	admins.POST("/role", h.CreateRole)
+	admins.GET("/role/:id", h.GetByIdRole)
+	admins.GET("/role", h.GetListRole)
+	admins.PUT("/role/:id", h.UpdateRole)
+
+	colors := admin.Group("", h.PermissionMiddleware(config.PERMISSION_COLOR_WRITE))
+	colors.POST("/color", h.CreateColor)
+	colors.PUT("/color/:id", h.UpdateColor)
+	colors.POST("/color/:id/stock", h.AdjustColorStock)
+	colors.DELETE("/color/:id", h.DeleteColor)
+	colors.POST("/color/:id/restore", h.RestoreColor)
+
+	banners := admin.Group("", h.PermissionMiddleware(config.PERMISSION_BANNER_WRITE))
+	banners.POST("/banner", h.CreateBanner)
+	banners.PUT("/banner/:id", h.UpdateBanner)
+	banners.DELETE("/banner/:id", h.DeleteBanner)
+	banners.POST("/banner/:id/restore", h.RestoreBanner)
+
+	admin.POST("/customer", h.PermissionMiddleware(config.PERMISSION_CUSTOMER_WRITE), h.CreateCustomer)
+	admin.GET("/customer", h.PermissionMiddleware(config.PERMISSION_CUSTOMER_READ), h.GetListCustomer)
+	admin.DELETE("/customer/:id", h.PermissionMiddleware(config.PERMISSION_CUSTOMER_WRITE), h.DeleteCustomer)
+	admin.POST("/customer/:id/restore", h.PermissionMiddleware(config.PERMISSION_CUSTOMER_WRITE), h.RestoreCustomer)
+
+	brands := admin.Group("", h.PermissionMiddleware(config.PERMISSION_BRAND_WRITE))
+	brands.POST("/brand", h.CreateBrand)
+	brands.PUT("/brand/:id", h.UpdateBrand)
+	brands.DELETE("/brand/:id", h.DeleteBrand)
+	brands.POST("/brand/:id/restore", h.RestoreBrand)
+
+	categories := admin.Group("", h.PermissionMiddleware(config.PERMISSION_CATEGORY_WRITE))
+	categories.POST("/category", h.CreateCategory)
+	categories.PUT("/category/:id", h.UpdateCategory)
+	categories.DELETE("/category/:id", h.DeleteCategory)
+	categories.POST("/category/:id/restore", h.RestoreCategory)
+
+	admin.PUT("/order/:id", h.PermissionMiddleware(config.PERMISSION_ORDER_UPDATE_STATUS), h.UpdateOrder)
+	admin.DELETE("/order/:id", h.PermissionMiddleware(config.PERMISSION_ORDER_DELETE), h.DeleteOrder)
+
+	products := admin.Group("", h.PermissionMiddleware(config.PERMISSION_PRODUCT_WRITE))
+	products.POST("/product", h.CreateProduct)
+	products.POST("/product/import", h.ImportProduct)
+	products.GET("/product/export", h.ExportProduct)
+	products.PUT("/product/:id", h.UpdateProduct)
+	products.DELETE("/product/:id", h.DeleteProduct)
+	products.POST("/product/:id/restore", h.RestoreProduct)
+	products.POST("/product/:id/variant", h.CreateVariant)
+	products.PUT("/variant/:id", h.UpdateVariant)
+	products.POST("/variant/:id/stock", h.AdjustVariantStock)
+	products.DELETE("/variant/:id", h.DeleteVariant)
+	products.POST("/product/:id/media", h.CreateMedia)
+	products.PUT("/product/:id/media/order", h.ReorderMedia)
+	products.PUT("/media/:id", h.UpdateMedia)
+	products.DELETE("/media/:id", h.DeleteMedia)
+
+	files := admin.Group("", h.PermissionMiddleware(config.PERMISSION_FILE_UPLOAD))
+	files.POST("upload-files", h.UploadFiles)
+	files.DELETE("delete-file", h.DeleteFile)
+
+	locations := admin.Group("", h.PermissionMiddleware(config.PERMISSION_LOCATION_WRITE))
+	locations.POST("/location", h.CreateLocation)
+	locations.PUT("/location/:id", h.UpdateLocation)
+	locations.DELETE("/location/:id", h.DeleteLocation)
+	locations.POST("/location/:id/restore", h.RestoreLocation)
+
+	reviews := admin.Group("", h.PermissionMiddleware(config.PERMISSION_REVIEW_MODERATE))
+	reviews.GET("/review", h.GetListReview)
+	reviews.PUT("/review/:id/status", h.ModerateReview)
+	reviews.DELETE("/review/:id", h.DeleteReview)
+
+	admin.GET("/audit", h.PermissionMiddleware(config.PERMISSION_AUDIT_READ), h.GetListAudit)
+	admin.GET("/product/:id/price-history", h.PermissionMiddleware(config.PERMISSION_PRICE_HISTORY_READ), h.GetListPriceHistory)
+
+	inventory := admin.Group("", h.PermissionMiddleware(config.PERMISSION_INVENTORY_READ))
+	inventory.GET("/inventory/movement", h.GetListInventoryMovement)
+	inventory.GET("/inventory/reconcile", h.ReconcileInventory)
 
 	url := ginSwagger.URL("swagger/doc.json")
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
@@ -102,3 +197,11 @@ func customCORSMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+func mustPolicy(name, value string) ratelimit.Policy {
+	policy, err := ratelimit.ParsePolicy(name, value)
+	if err != nil {
+		panic(err)
+	}
+	return policy
+}
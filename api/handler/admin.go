@@ -3,7 +3,6 @@ package handler
 import (
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
-	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +11,7 @@ import (
 // Create Admin godoc
 // @ID create_admins
 // @Router /e_commerce/api/v1/admin [POST]
+// @Security ApiKeyAuth
 // @Summary Create Admin
 // @Description Create Admin
 // @Tags Admin
@@ -33,6 +33,12 @@ func (h *handler) CreateAdmin(c *gin.Context) {
 		return
 	}
 
+	if adminCreate.RoleId != "" && !helper.IsValidUUID(adminCreate.RoleId) {
+		h.logger.Error("is invalid role uuid!")
+		c.JSON(http.StatusBadRequest, "invalid role_id")
+		return
+	}
+
 	resp, err := h.storage.Admin().Create(c.Request.Context(), &adminCreate)
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "error Admin.Create")
@@ -40,6 +46,8 @@ func (h *handler) CreateAdmin(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, models.AuditActionCreate, models.AuditEntityAdmin, resp.Id, nil, resp)
+
 	h.logger.Info("Create Admin Successfully!!")
 	c.JSON(http.StatusCreated, resp)
 }
@@ -47,6 +55,7 @@ func (h *handler) CreateAdmin(c *gin.Context) {
 // GetByID Admin godoc
 // @ID get_by_id_admin
 // @Router /e_commerce/api/v1/admin/{id} [GET]
+// @Security ApiKeyAuth
 // @Summary Get By ID Admin
 // @Description Get By ID Admin
 // @Tags Admin
@@ -79,6 +88,7 @@ func (h *handler) GetByIdAdmin(c *gin.Context) {
 // GetList Admin godoc
 // @ID get_list_admin
 // @Router /e_commerce/api/v1/admin [GET]
+// @Security ApiKeyAuth
 // @Summary Get List Admin
 // @Description Get List Admin
 // @Tags Admin
@@ -123,6 +133,7 @@ func (h *handler) GetListAdmin(c *gin.Context) {
 // Update Admin godoc
 // @ID update_admin
 // @Router /e_commerce/api/v1/admin/{id} [PUT]
+// @Security ApiKeyAuth
 // @Summary Update Admin
 // @Description Update Admin
 // @Tags Admin
@@ -153,6 +164,14 @@ func (h *handler) UpdateAdmin(c *gin.Context) {
 	}
 
 	adminUpdate.Id = id
+
+	before, err := h.storage.Admin().GetByID(c.Request.Context(), &models.AdminPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Admin.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
 	rowsAffected, err := h.storage.Admin().Update(c.Request.Context(), &adminUpdate)
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Admin.Update!")
@@ -173,6 +192,8 @@ func (h *handler) UpdateAdmin(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, models.AuditActionUpdate, models.AuditEntityAdmin, id, before, resp)
+
 	h.logger.Info("Update Admin Successfully!")
 	c.JSON(http.StatusAccepted, resp)
 }
@@ -180,6 +201,7 @@ func (h *handler) UpdateAdmin(c *gin.Context) {
 // Delete Admin godoc
 // @ID delete_admin
 // @Router /e_commerce/api/v1/admin/{id} [DELETE]
+// @Security ApiKeyAuth
 // @Summary Delete Admin
 // @Description Delete Admin
 // @Tags Admin
@@ -198,13 +220,22 @@ func (h *handler) DeleteAdmin(c *gin.Context) {
 		return
 	}
 
-	err := h.storage.Admin().Delete(c.Request.Context(), &models.AdminPrimaryKey{Id: id})
+	before, err := h.storage.Admin().GetByID(c.Request.Context(), &models.AdminPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Admin.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Admin().Delete(c.Request.Context(), &models.AdminPrimaryKey{Id: id})
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Admin.Delete!")
 		c.JSON(http.StatusInternalServerError, "Unable to delete data, please try again later!")
 		return
 	}
 
+	h.audit(c, models.AuditActionDelete, models.AuditEntityAdmin, id, before, nil)
+
 	h.logger.Info("Admin Deleted Successfully!")
 	c.JSON(http.StatusNoContent, nil)
 }
@@ -229,20 +260,6 @@ func (h *handler) AdminLogin(c *gin.Context) {
 		return
 	}
 
-	password, err := h.storage.Admin().GetByPhoneNumber(c.Request.Context(), loginReq.Login)
-	if err != nil {
-		handleResponseLog(c, h.logger, "error getting admin", http.StatusInternalServerError, err)
-		return
-	}
-
-	fmt.Println("loginReq: ", loginReq)
-	if loginReq.Password != password.Password {
-		handleResponseLog(c, h.logger, "wrong password", http.StatusBadRequest, err)
-		return
-	}
- 
-	//TODO: need validate login & password
-
 	loginResp, err := h.service.AuthAdmin().AdminLogin(c.Request.Context(), loginReq)
 	if err != nil {
 		handleResponseLog(c, h.logger, "unauthorized", http.StatusUnauthorized, err)
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"e-commerce/models"
+	"e-commerce/pkg/helper"
+	"e-commerce/pkg/logger"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// auditIgnoredFields change on every write and only add noise to the diff.
+var auditIgnoredFields = map[string]bool{
+	"updated_at": true,
+}
+
+// audit records an admin mutation with the fields that changed between
+// before and after, nil before means create and nil after means delete.
+// A failure to write the entry is logged and does not fail the request.
+func (h *handler) audit(c *gin.Context, action, entityType, entityID string, before, after interface{}) {
+	info, _ := getAuthInfo(c)
+
+	beforeState, err := auditState(before)
+	if err != nil {
+		h.logger.Error("error while encoding audit state", logger.Error(err))
+		return
+	}
+
+	afterState, err := auditState(after)
+	if err != nil {
+		h.logger.Error("error while encoding audit state", logger.Error(err))
+		return
+	}
+
+	if beforeState != nil && afterState != nil {
+		beforeState, afterState = auditDiff(beforeState, afterState)
+	}
+
+	err = h.storage.Audit().Create(c.Request.Context(), &models.AuditLogCreate{
+		ActorId:    info.UserID,
+		ActorRole:  info.UserRole,
+		Action:     action,
+		EntityType: entityType,
+		EntityId:   entityID,
+		Before:     beforeState,
+		After:      afterState,
+	})
+	if err != nil {
+		h.logger.Error("error while writing audit log", logger.Error(err), logger.String("entity_type", entityType), logger.String("entity_id", entityID))
+	}
+}
+
+func auditState(v interface{}) (map[string]interface{}, error) {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	state := map[string]interface{}{}
+	if err = json.Unmarshal(body, &state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// auditDiff keeps only the fields whose values differ.
+func auditDiff(before, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	changedBefore := map[string]interface{}{}
+	changedAfter := map[string]interface{}{}
+
+	for key, value := range before {
+		if auditIgnoredFields[key] {
+			continue
+		}
+		if newValue, ok := after[key]; !ok || !reflect.DeepEqual(value, newValue) {
+			changedBefore[key] = value
+			if ok {
+				changedAfter[key] = newValue
+			}
+		}
+	}
+
+	for key, value := range after {
+		if _, ok := before[key]; !ok && !auditIgnoredFields[key] {
+			changedAfter[key] = value
+		}
+	}
+
+	return changedBefore, changedAfter
+}
+
+// GetList Audit godoc
+// @ID get_list_audit
+// @Router /e_commerce/api/v1/audit [GET]
+// @Security ApiKeyAuth
+// @Summary Get List Audit
+// @Description Admin changes, newest first
+// @Tags Audit
+// @Accept json
+// @Produce json
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Param actor_id query string false "actor_id"
+// @Param action query string false "create, update, delete, import, restore or stock"
+// @Param entity_type query string false "product, color, category, brand, banner, location, order, admin, role, variant, media, review or customer"
+// @Param entity_id query string false "entity_id"
+// @Param from query string false "from date, 2006-01-02"
+// @Param to query string false "to date (exclusive), 2006-01-02"
+// @Success 200 {object} models.AuditGetListResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) GetListAudit(c *gin.Context) {
+	offset, err := h.getOffsetQuery(c.Query("offset"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListAudit INVALID OFFSET!")
+		c.JSON(http.StatusBadRequest, "INVALID OFFSET")
+		return
+	}
+
+	limit, err := h.getLimitQuery(c.Query("limit"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListAudit INVALID LIMIT!")
+		c.JSON(http.StatusBadRequest, "INVALID LIMIT")
+		return
+	}
+
+	req := models.AuditGetListRequest{
+		Offset:     offset,
+		Limit:      limit,
+		ActorId:    c.Query("actor_id"),
+		Action:     c.Query("action"),
+		EntityType: c.Query("entity_type"),
+		EntityId:   c.Query("entity_id"),
+		From:       c.Query("from"),
+		To:         c.Query("to"),
+	}
+
+	if req.ActorId != "" && !helper.IsValidUUID(req.ActorId) {
+		h.logger.Error("is invalid actor uuid!")
+		c.JSON(http.StatusBadRequest, "invalid actor_id")
+		return
+	}
+
+	for _, date := range []string{req.From, req.To} {
+		if date == "" {
+			continue
+		}
+		if _, err = time.Parse("2006-01-02", date); err != nil {
+			h.logger.Error(err.Error() + "  :  " + "GetListAudit INVALID DATE!")
+			c.JSON(http.StatusBadRequest, "INVALID DATE")
+			return
+		}
+	}
+
+	resp, err := h.storage.Audit().GetList(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Audit.GetList!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("GetListAudit Response!")
+	c.JSON(http.StatusOK, resp)
+}
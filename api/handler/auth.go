@@ -2,6 +2,8 @@ package handler
 
 import (
 	"e-commerce/models"
+	"e-commerce/service"
+	"errors"
 	"fmt"
 
 	// check "food/pkg/validation"
@@ -31,10 +33,6 @@ func (h *handler) UserLogin(c *gin.Context) {
 		return
 	}
 
-	fmt.Println("loginReq: ", loginReq)
-
-	//TODO: need validate login & password
-
 	loginResp, err := h.service.Auth().UserLogin(c.Request.Context(), loginReq)
 	if err != nil {
 		h.logger.Error(err.Error() + ":" + "error while login")
@@ -58,6 +56,8 @@ func (h *handler) UserLogin(c *gin.Context) {
 // @Success      201  {object}  models.Response
 // @Failure      400  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      429  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h *handler) UserRegister(c *gin.Context) {
 	loginReq := models.UserRegisterRequest{}
@@ -81,7 +81,7 @@ func (h *handler) UserRegister(c *gin.Context) {
 	err := h.service.Auth().UserRegister(c.Request.Context(), loginReq)
 	if err != nil {
 		h.logger.Error(err.Error() + ":" + "error while registering")
-		c.JSON(http.StatusInternalServerError, "error while registering")
+		handleAuthError(c, err, "error while registering")
 		return
 	}
 
@@ -99,7 +99,10 @@ func (h *handler) UserRegister(c *gin.Context) {
 // @Param        register body models.UserRegisterConfRequest true "register"
 // @Success      201  {object}  models.UserLoginResponse
 // @Failure      400  {object}  models.Response
+// @Failure      401  {object}  models.Response
 // @Failure      404  {object}  models.Response
+// @Failure      409  {object}  models.Response
+// @Failure      429  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h *handler) UserRegisterConfirm(c *gin.Context) {
 	req := models.UserRegisterConfRequest{}
@@ -109,14 +112,10 @@ func (h *handler) UserRegisterConfirm(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, "error while binding body")
 		return
 	}
-	fmt.Println("req: ", req)
-
-	//TODO: need validate login & password
-
 	confResp, err := h.service.Auth().UserRegisterConfirm(c.Request.Context(), req)
 	if err != nil {
 		h.logger.Error(err.Error() + ":" + "error while registering")
-		c.JSON(http.StatusInternalServerError, "error while registering")
+		handleAuthError(c, err, "error while registering")
 		return
 	}
 	h.logger.Info("Successfully login")
@@ -124,6 +123,45 @@ func (h *handler) UserRegisterConfirm(c *gin.Context) {
 
 }
 
+// UserLoginByPhone godoc
+// @Router       /e_commerce/api/v1/byphone [POST]
+// @Summary      Customer login by phone
+// @Description  Sends a login code to a registered phone number
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        login body models.UserLoginByPhoneRequest true "login"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      429  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h *handler) UserLoginByPhone(c *gin.Context) {
+	var req models.UserLoginByPhoneRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("error while binding request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, models.Response{
+			StatusCode:  http.StatusBadRequest,
+			Description: err.Error(),
+		})
+		return
+	}
+
+	err := h.service.Auth().UserLoginByPhone(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("error in UserLoginByPhone: " + err.Error())
+		handleAuthError(c, err, "Tizim xatosi yuz berdi")
+		return
+	}
+
+	h.logger.Info("Successfully sent login code")
+	c.JSON(http.StatusOK, models.Response{
+		StatusCode:  http.StatusOK,
+		Description: "Tasdiqlash kodi yuborildi",
+	})
+}
+
 // UserLoginByPhoneConfirm godoc
 // @Router       /e_commerce/api/v1/byphoneconfirm [POST]
 // @Summary      Customer login by phone confirmation
@@ -135,6 +173,8 @@ func (h *handler) UserRegisterConfirm(c *gin.Context) {
 // @Success      200  {object}  models.UserLoginResponse
 // @Failure      400  {object}  models.Response
 // @Failure      401  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      429  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h *handler) UserLoginByPhoneConfirm(c *gin.Context) {
 	var req models.UserLoginByPhoneConfirmRequest
@@ -150,22 +190,133 @@ func (h *handler) UserLoginByPhoneConfirm(c *gin.Context) {
 	}
 	resp, err := h.service.Auth().UserLoginByPhoneConfirm(c.Request.Context(), req)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		message := "Tizim xatosi yuz berdi"
+		h.logger.Error("error in UserLoginByPhoneConfirm: " + err.Error())
+		handleAuthError(c, err, "Tizim xatosi yuz berdi")
+		return
+	}
 
-		if err.Error() == "OTP kod topilmadi yoki muddati tugagan" || err.Error() == "Noto'g'ri OTP kod" {
-			statusCode = http.StatusUnauthorized
-			message = err.Error()
-		}
+	h.logger.Info("Successfully logged in by phone")
+	c.JSON(http.StatusOK, resp)
+}
 
-		h.logger.Error("error in UserLoginByPhoneConfirm: " + err.Error())
-		c.JSON(statusCode, models.Response{
-			StatusCode:  statusCode,
-			Description: message,
+// RefreshToken godoc
+// @Router       /e_commerce/api/v1/refresh [POST]
+// @Summary      Refresh tokens
+// @Description  Exchange a refresh token for a new token pair, the old refresh token stops working
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        refresh body models.RefreshTokenRequest true "refresh"
+// @Success      200  {object}  models.UserLoginResponse
+// @Failure      400  {object}  models.Response
+// @Failure      401  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h *handler) RefreshToken(c *gin.Context) {
+	var req models.RefreshTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil || req.RefreshToken == "" {
+		h.logger.Error("error while binding refresh token request")
+		c.JSON(http.StatusBadRequest, models.Response{
+			StatusCode:  http.StatusBadRequest,
+			Description: "refresh_token is required",
 		})
 		return
 	}
 
-	h.logger.Info("Successfully logged in by phone")
+	resp, err := h.service.Token().Refresh(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("error in RefreshToken: " + err.Error())
+		if errors.Is(err, service.ErrInvalidRefreshToken) || errors.Is(err, service.ErrRefreshTokenReused) {
+			c.JSON(http.StatusUnauthorized, models.Response{
+				StatusCode:  http.StatusUnauthorized,
+				Description: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, models.Response{
+			StatusCode:  http.StatusInternalServerError,
+			Description: "error while refreshing token",
+		})
+		return
+	}
+
+	h.logger.Info("Successfully refreshed token")
 	c.JSON(http.StatusOK, resp)
 }
+
+// Logout godoc
+// @Router       /e_commerce/api/v1/logout [POST]
+// @Summary      Logout
+// @Description  Revoke the session the refresh token belongs to
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        logout body models.RefreshTokenRequest true "logout"
+// @Success      200  {object}  models.Response
+// @Failure      400  {object}  models.Response
+// @Failure      401  {object}  models.Response
+// @Failure      500  {object}  models.Response
+func (h *handler) Logout(c *gin.Context) {
+	var req models.RefreshTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil || req.RefreshToken == "" {
+		h.logger.Error("error while binding logout request")
+		c.JSON(http.StatusBadRequest, models.Response{
+			StatusCode:  http.StatusBadRequest,
+			Description: "refresh_token is required",
+		})
+		return
+	}
+
+	err := h.service.Token().Revoke(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("error in Logout: " + err.Error())
+		if errors.Is(err, service.ErrInvalidRefreshToken) {
+			c.JSON(http.StatusUnauthorized, models.Response{
+				StatusCode:  http.StatusUnauthorized,
+				Description: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, models.Response{
+			StatusCode:  http.StatusInternalServerError,
+			Description: "error while logging out",
+		})
+		return
+	}
+
+	h.logger.Info("Successfully logged out")
+	c.JSON(http.StatusOK, models.Response{
+		StatusCode:  http.StatusOK,
+		Description: "logged out",
+	})
+}
+
+// handleAuthError answers with the status matching the auth service error,
+// unknown errors are hidden behind the fallback message.
+func handleAuthError(c *gin.Context, err error, fallback string) {
+	statusCode := http.StatusInternalServerError
+	message := fallback
+
+	switch {
+	case errors.Is(err, service.ErrInvalidPhone):
+		statusCode = http.StatusBadRequest
+	case errors.Is(err, service.ErrOtpNotFound), errors.Is(err, service.ErrOtpInvalid):
+		statusCode = http.StatusUnauthorized
+	case errors.Is(err, service.ErrPhoneNotRegistered):
+		statusCode = http.StatusNotFound
+	case errors.Is(err, service.ErrPhoneRegistered):
+		statusCode = http.StatusConflict
+	case errors.Is(err, service.ErrOtpCooldown), errors.Is(err, service.ErrOtpLocked):
+		statusCode = http.StatusTooManyRequests
+	}
+
+	if statusCode != http.StatusInternalServerError {
+		message = err.Error()
+	}
+
+	c.JSON(statusCode, models.Response{
+		StatusCode:  statusCode,
+		Description: message,
+	})
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"e-commerce/config"
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
+	"e-commerce/storage"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -11,6 +14,7 @@ import (
 // Create Banner godoc
 // @ID create_banner
 // @Router /e_commerce/api/v1/banner [POST]
+// @Security ApiKeyAuth
 // @Summary Create Banner
 // @Description Create Banner
 // @Tags Banner
@@ -37,6 +41,8 @@ func (h *handler) CreateBanner(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, models.AuditActionCreate, models.AuditEntityBanner, resp.Id, nil, resp)
+
 	h.logger.Info("Banner created successfully")
 	c.JSON(http.StatusOK, resp)
 }
@@ -44,12 +50,14 @@ func (h *handler) CreateBanner(c *gin.Context) {
 // GetByID Banner godoc
 // @ID get_by_id_banner
 // @Router /e_commerce/api/v1/banner/{id} [GET]
+// @Security ApiKeyAuth
 // @Summary Get By ID Banner
 // @Description Get By ID Banner
 // @Tags Banner
 // @Accept json
 // @Banner json
 // @Param id path string false "id"
+// @Param include_deleted query bool false "find it when soft deleted as well, admins only"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
 // @Failure 500 {object} Response{data=string} "Server error"
@@ -62,7 +70,12 @@ func (h *handler) GetByIdBanner(c *gin.Context) {
 		return
 	}
 
-	request, err := h.storage.Banner().GetByID(c.Request.Context(), &models.BannerPrimaryKey{Id: id})
+	includeDeleted, ok := h.getIncludeDeleted(c, config.PERMISSION_BANNER_WRITE)
+	if !ok {
+		return
+	}
+
+	request, err := h.storage.Banner().GetByID(c.Request.Context(), &models.BannerPrimaryKey{Id: id, IncludeDeleted: includeDeleted})
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Banner.GetByID!")
 		c.JSON(http.StatusInternalServerError, "Server Error!")
@@ -76,6 +89,7 @@ func (h *handler) GetByIdBanner(c *gin.Context) {
 // GetList Banner godoc
 // @ID get_list_banner
 // @Router /e_commerce/api/v1/banner [GET]
+// @Security ApiKeyAuth
 // @Summary Get List Banner
 // @Description Get List Banner
 // @Tags Banner
@@ -83,6 +97,7 @@ func (h *handler) GetByIdBanner(c *gin.Context) {
 // @Banner json
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
+// @Param include_deleted query bool false "list soft deleted rows as well, admins only"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
 // @Failure 500 {object} Response{data=string} "Server error"
@@ -102,9 +117,15 @@ func (h *handler) GetListBanner(c *gin.Context) {
 		return
 	}
 
+	includeDeleted, ok := h.getIncludeDeleted(c, config.PERMISSION_BANNER_WRITE)
+	if !ok {
+		return
+	}
+
 	resp, err := h.storage.Banner().GetList(c.Request.Context(), &models.BannerGetListRequest{
-		Offset: offset,
-		Limit:  limit,
+		Offset:         offset,
+		Limit:          limit,
+		IncludeDeleted: includeDeleted,
 	})
 
 	if err != nil && err.Error() != "no rows in result set" {
@@ -120,6 +141,7 @@ func (h *handler) GetListBanner(c *gin.Context) {
 // Update Banner godoc
 // @ID update_banner
 // @Router /e_commerce/api/v1/banner/{id} [PUT]
+// @Security ApiKeyAuth
 // @Summary Update Banner
 // @Description Update Banner
 // @Tags Banner
@@ -150,6 +172,14 @@ func (h *handler) UpdateBanner(c *gin.Context) {
 	}
 
 	bannerUpdate.Id = id
+
+	before, err := h.storage.Banner().GetByID(c.Request.Context(), &models.BannerPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Banner.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
 	rowsAffected, err := h.storage.Banner().Update(c.Request.Context(), &bannerUpdate)
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Banner.Update!")
@@ -170,6 +200,8 @@ func (h *handler) UpdateBanner(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, models.AuditActionUpdate, models.AuditEntityBanner, id, before, resp)
+
 	h.logger.Info("Update Banner Successfully!")
 	c.JSON(http.StatusAccepted, resp)
 }
@@ -177,6 +209,7 @@ func (h *handler) UpdateBanner(c *gin.Context) {
 // Delete Banner godoc
 // @ID delete_banner
 // @Router /e_commerce/api/v1/banner/{id} [DELETE]
+// @Security ApiKeyAuth
 // @Summary Delete Banner
 // @Description Delete Banner
 // @Tags Banner
@@ -195,13 +228,76 @@ func (h *handler) DeleteBanner(c *gin.Context) {
 		return
 	}
 
-	err := h.storage.Banner().Delete(c.Request.Context(), &models.BannerPrimaryKey{Id: id})
+	before, err := h.storage.Banner().GetByID(c.Request.Context(), &models.BannerPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Banner.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Banner().Delete(c.Request.Context(), &models.BannerPrimaryKey{Id: id})
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Banner.Delete!")
 		c.JSON(http.StatusInternalServerError, "Unable to delete data, please try again later!")
 		return
 	}
 
+	h.audit(c, models.AuditActionDelete, models.AuditEntityBanner, id, before, nil)
+
 	h.logger.Info("Banner Deleted Successfully!")
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// Restore Banner godoc
+// @ID restore_banner
+// @Router /e_commerce/api/v1/banner/{id}/restore [POST]
+// @Security ApiKeyAuth
+// @Summary Restore Banner
+// @Description Restore a soft deleted Banner
+// @Tags Banner
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Banner "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Not found among the deleted"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) RestoreBanner(c *gin.Context) {
+	var id = c.Param("id")
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is not valid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id!")
+		return
+	}
+
+	before, err := h.storage.Banner().GetByID(c.Request.Context(), &models.BannerPrimaryKey{Id: id, IncludeDeleted: true})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Banner.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Banner().Restore(c.Request.Context(), &models.BannerPrimaryKey{Id: id})
+	if errors.Is(err, storage.ErrNotDeleted) {
+		c.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Banner.Restore!")
+		c.JSON(http.StatusInternalServerError, "Unable to restore data, please try again later!")
+		return
+	}
+
+	resp, err := h.storage.Banner().GetByID(c.Request.Context(), &models.BannerPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Banner.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionRestore, models.AuditEntityBanner, id, before, resp)
+
+	h.logger.Info("Banner Restored Successfully!")
+	c.JSON(http.StatusOK, resp)
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"e-commerce/config"
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
+	"e-commerce/storage"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -11,6 +14,7 @@ import (
 // Create Brand godoc
 // @ID create_brends
 // @Router /e_commerce/api/v1/brand [POST]
+// @Security ApiKeyAuth
 // @Summary Create Brand
 // @Description Create Brand
 // @Tags Brand
@@ -33,6 +37,12 @@ func (h *handler) CreateBrand(c *gin.Context) {
 		return
 	}
 
+	var msg string
+	if brandCreate.NameI18n, msg = validTranslations("name_i18n", brandCreate.NameI18n, 100); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
 	resp, err := h.storage.Brand().Create(c.Request.Context(), &brandCreate)
 	if err != nil {
 		h.logger.Error(err.Error() + ":" + "Error Brand Create")
@@ -40,6 +50,8 @@ func (h *handler) CreateBrand(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, models.AuditActionCreate, models.AuditEntityBrand, resp.Id, nil, resp)
+
 	h.logger.Info("Creating Brand Successfully!!")
 	c.JSON(http.StatusCreated, resp)
 }
@@ -47,31 +59,42 @@ func (h *handler) CreateBrand(c *gin.Context) {
 // GetByID Brand godoc
 // @ID get_by_id_brand
 // @Router /e_commerce/api/v1/brand/{id} [GET]
+// @Security ApiKeyAuth
 // @Summary Get By ID Brand
-// @Description Get By ID Brand
+// @Description Get By ID or slug, an old slug redirects to the current one
 // @Tags Brand
 // @Accept json
 // @Brand json
-// @Param id path string false "id"
+// @Param id path string true "id or slug"
+// @Param lang query string false "uz, uz-Cyrl, ru or en, the Accept-Language header is used when empty"
+// @Param include_deleted query bool false "find it when soft deleted as well, admins only"
 // @Success 200 {object} Response{data=string} "Success Request"
-// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 301 {object} Response{data=string} "Moved to the current slug"
+// @Response 404 {object} Response{data=string} "Not found"
 // @Failure 500 {object} Response{data=string} "Server error"
 func (h *handler) GetByIdBrand(c *gin.Context) {
-	id := c.Param("id")
+	id, slug := slugOrId(c)
 
-	if !helper.IsValidUUID(id) {
-		h.logger.Error("is valid uuid!")
-		c.JSON(http.StatusBadRequest, "invalid id")
+	includeDeleted, ok := h.getIncludeDeleted(c, config.PERMISSION_BRAND_WRITE)
+	if !ok {
 		return
 	}
 
-	request, err := h.storage.Brand().GetByID(c.Request.Context(), &models.BrandPrimaryKey{Id: id})
+	request, err := h.storage.Brand().GetByID(c.Request.Context(), &models.BrandPrimaryKey{Id: id, Slug: slug, Lang: getLang(c), IncludeDeleted: includeDeleted})
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Brand.GetByID!")
 		c.JSON(http.StatusInternalServerError, "Server Error!")
 		return
 	}
 
+	if request.Id == "" {
+		if slug != "" && h.redirectSlug(c, models.SlugEntityBrand, slug) {
+			return
+		}
+		c.JSON(http.StatusNotFound, "brand not found")
+		return
+	}
+
 	h.logger.Info("GetByID Brand Response!")
 	c.JSON(http.StatusOK, request)
 }
@@ -79,6 +102,7 @@ func (h *handler) GetByIdBrand(c *gin.Context) {
 // GetList Brand godoc
 // @ID get_list_brand
 // @Router /e_commerce/api/v1/brand [GET]
+// @Security ApiKeyAuth
 // @Summary Get List Brand
 // @Description Get List Brand
 // @Tags Brand
@@ -86,6 +110,8 @@ func (h *handler) GetByIdBrand(c *gin.Context) {
 // @Brand json
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
+// @Param lang query string false "uz, uz-Cyrl, ru or en, the Accept-Language header is used when empty"
+// @Param include_deleted query bool false "list soft deleted rows as well, admins only"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
 // @Failure 500 {object} Response{data=string} "Server error"
@@ -105,9 +131,16 @@ func (h *handler) GetListBrand(c *gin.Context) {
 		return
 	}
 
+	includeDeleted, ok := h.getIncludeDeleted(c, config.PERMISSION_BRAND_WRITE)
+	if !ok {
+		return
+	}
+
 	resp, err := h.storage.Brand().GetList(c.Request.Context(), &models.BrandGetListRequest{
-		Offset: offset,
-		Limit:  limit,
+		Offset:         offset,
+		Limit:          limit,
+		Lang:           getLang(c),
+		IncludeDeleted: includeDeleted,
 	})
 
 	if err != nil && err.Error() != "no rows in result set" {
@@ -123,6 +156,7 @@ func (h *handler) GetListBrand(c *gin.Context) {
 // Update Brand godoc
 // @ID update_brand
 // @Router /e_commerce/api/v1/brand/{id} [PUT]
+// @Security ApiKeyAuth
 // @Summary Update Brand
 // @Description Update Brand
 // @Tags Brand
@@ -152,7 +186,21 @@ func (h *handler) UpdateBrand(c *gin.Context) {
 		return
 	}
 
+	var msg string
+	if brandUpdate.NameI18n, msg = validTranslations("name_i18n", brandUpdate.NameI18n, 100); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
 	brandUpdate.Id = id
+
+	before, err := h.storage.Brand().GetByID(c.Request.Context(), &models.BrandPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Brand.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
 	rowsAffected, err := h.storage.Brand().Update(c.Request.Context(), &brandUpdate)
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Brand.Update!")
@@ -173,6 +221,8 @@ func (h *handler) UpdateBrand(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, models.AuditActionUpdate, models.AuditEntityBrand, id, before, resp)
+
 	h.logger.Info("Update Brand Successfully!")
 	c.JSON(http.StatusAccepted, resp)
 }
@@ -180,6 +230,7 @@ func (h *handler) UpdateBrand(c *gin.Context) {
 // Delete Brand godoc
 // @ID delete_brand
 // @Router /e_commerce/api/v1/brand/{id} [DELETE]
+// @Security ApiKeyAuth
 // @Summary Delete Brand
 // @Description Delete Brand
 // @Tags Brand
@@ -198,13 +249,76 @@ func (h *handler) DeleteBrand(c *gin.Context) {
 		return
 	}
 
-	err := h.storage.Brand().Delete(c.Request.Context(), &models.BrandPrimaryKey{Id: id})
+	before, err := h.storage.Brand().GetByID(c.Request.Context(), &models.BrandPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Brand.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Brand().Delete(c.Request.Context(), &models.BrandPrimaryKey{Id: id})
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Brand.Delete!")
 		c.JSON(http.StatusInternalServerError, "Unable to delete data, please try again later!")
 		return
 	}
 
+	h.audit(c, models.AuditActionDelete, models.AuditEntityBrand, id, before, nil)
+
 	h.logger.Info("Brand Deleted Successfully!")
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// Restore Brand godoc
+// @ID restore_brand
+// @Router /e_commerce/api/v1/brand/{id}/restore [POST]
+// @Security ApiKeyAuth
+// @Summary Restore Brand
+// @Description Restore a soft deleted Brand
+// @Tags Brand
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Brand "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Not found among the deleted"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) RestoreBrand(c *gin.Context) {
+	var id = c.Param("id")
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is not valid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id!")
+		return
+	}
+
+	before, err := h.storage.Brand().GetByID(c.Request.Context(), &models.BrandPrimaryKey{Id: id, IncludeDeleted: true})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Brand.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Brand().Restore(c.Request.Context(), &models.BrandPrimaryKey{Id: id})
+	if errors.Is(err, storage.ErrNotDeleted) {
+		c.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Brand.Restore!")
+		c.JSON(http.StatusInternalServerError, "Unable to restore data, please try again later!")
+		return
+	}
+
+	resp, err := h.storage.Brand().GetByID(c.Request.Context(), &models.BrandPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Brand.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionRestore, models.AuditEntityBrand, id, before, resp)
+
+	h.logger.Info("Brand Restored Successfully!")
+	c.JSON(http.StatusOK, resp)
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"e-commerce/config"
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
+	"e-commerce/storage"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -11,6 +14,7 @@ import (
 // Create Category godoc
 // @ID create_categorys
 // @Router /e_commerce/api/v1/category [POST]
+// @Security ApiKeyAuth
 // @Summary Create Category
 // @Description Create Category
 // @Tags Category
@@ -33,6 +37,12 @@ func (h *handler) CreateCategory(c *gin.Context) {
 		return
 	}
 
+	var msg string
+	if categoryCreate.NameI18n, msg = validTranslations("name_i18n", categoryCreate.NameI18n, 100); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
 	resp, err := h.storage.Category().Create(c.Request.Context(), &categoryCreate)
 	if err != nil {
 		h.logger.Error(err.Error() + ":" + "Error Category Create")
@@ -40,6 +50,8 @@ func (h *handler) CreateCategory(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, models.AuditActionCreate, models.AuditEntityCategory, resp.Id, nil, resp)
+
 	h.logger.Info("Creating Category Successfully!!")
 	c.JSON(http.StatusCreated, resp)
 }
@@ -47,31 +59,42 @@ func (h *handler) CreateCategory(c *gin.Context) {
 // GetByID Category godoc
 // @ID get_by_id_category
 // @Router /e_commerce/api/v1/category/{id} [GET]
+// @Security ApiKeyAuth
 // @Summary Get By ID Category
-// @Description Get By ID Category
+// @Description Get By ID or slug, an old slug redirects to the current one
 // @Tags Category
 // @Accept json
 // @Category json
-// @Param id path string false "id"
+// @Param id path string true "id or slug"
+// @Param lang query string false "uz, uz-Cyrl, ru or en, the Accept-Language header is used when empty"
+// @Param include_deleted query bool false "find it when soft deleted as well, admins only"
 // @Success 200 {object} Response{data=string} "Success Request"
-// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 301 {object} Response{data=string} "Moved to the current slug"
+// @Response 404 {object} Response{data=string} "Not found"
 // @Failure 500 {object} Response{data=string} "Server error"
 func (h *handler) GetByIdCategory(c *gin.Context) {
-	id := c.Param("id")
+	id, slug := slugOrId(c)
 
-	if !helper.IsValidUUID(id) {
-		h.logger.Error("is valid uuid!")
-		c.JSON(http.StatusBadRequest, "invalid id")
+	includeDeleted, ok := h.getIncludeDeleted(c, config.PERMISSION_CATEGORY_WRITE)
+	if !ok {
 		return
 	}
 
-	request, err := h.storage.Category().GetByID(c.Request.Context(), &models.CategoryPrimaryKey{Id: id})
+	request, err := h.storage.Category().GetByID(c.Request.Context(), &models.CategoryPrimaryKey{Id: id, Slug: slug, Lang: getLang(c), IncludeDeleted: includeDeleted})
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Category.GetByID!")
 		c.JSON(http.StatusInternalServerError, "Server Error!")
 		return
 	}
 
+	if request.Id == "" {
+		if slug != "" && h.redirectSlug(c, models.SlugEntityCategory, slug) {
+			return
+		}
+		c.JSON(http.StatusNotFound, "category not found")
+		return
+	}
+
 	h.logger.Info("GetByID Category Response!")
 	c.JSON(http.StatusOK, request)
 }
@@ -79,6 +102,7 @@ func (h *handler) GetByIdCategory(c *gin.Context) {
 // GetList Category godoc
 // @ID get_list_category
 // @Router /e_commerce/api/v1/category [GET]
+// @Security ApiKeyAuth
 // @Summary Get List Category
 // @Description Get List Category
 // @Tags Category
@@ -87,6 +111,8 @@ func (h *handler) GetByIdCategory(c *gin.Context) {
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
 // @Param name query string false "name"
+// @Param lang query string false "uz, uz-Cyrl, ru or en, the Accept-Language header is used when empty"
+// @Param include_deleted query bool false "list soft deleted rows as well, admins only"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
 // @Failure 500 {object} Response{data=string} "Server error"
@@ -108,10 +134,17 @@ func (h *handler) GetListCategory(c *gin.Context) {
 
 	name := c.Query("name")
 
+	includeDeleted, ok := h.getIncludeDeleted(c, config.PERMISSION_CATEGORY_WRITE)
+	if !ok {
+		return
+	}
+
 	resp, err := h.storage.Category().GetList(c.Request.Context(), &models.CategoryGetListRequest{
-		Offset: offset,
-		Limit:  limit,
-		Name:   name,
+		Offset:         offset,
+		Limit:          limit,
+		Name:           name,
+		Lang:           getLang(c),
+		IncludeDeleted: includeDeleted,
 	})
 
 	if err != nil && err.Error() != "no rows in result set" {
@@ -124,11 +157,85 @@ func (h *handler) GetListCategory(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// GetTree Category godoc
+// @ID get_tree_category
+// @Router /e_commerce/api/v1/category/tree [GET]
+// @Summary Get Category Tree
+// @Description Categories nested under their parents, the whole tree or the subtree of root_id
+// @Tags Category
+// @Accept json
+// @Category json
+// @Param root_id query string false "root_id"
+// @Param lang query string false "uz, uz-Cyrl, ru or en, the Accept-Language header is used when empty"
+// @Success 200 {object} models.CategoryTreeResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Category not found"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) GetTreeCategory(c *gin.Context) {
+	rootId := c.Query("root_id")
+
+	if rootId != "" && !helper.IsValidUUID(rootId) {
+		h.logger.Error("is invalid root uuid!")
+		c.JSON(http.StatusBadRequest, "invalid root_id")
+		return
+	}
+
+	resp, err := h.storage.Category().GetTree(c.Request.Context(), &models.CategoryTreeRequest{RootId: rootId, Lang: getLang(c)})
+	if errors.Is(err, storage.ErrCategoryNotFound) {
+		c.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Category.GetTree!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("GetTreeCategory Response!")
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetBreadcrumbs Category godoc
+// @ID get_breadcrumbs_category
+// @Router /e_commerce/api/v1/category/{id}/breadcrumbs [GET]
+// @Summary Get Category Breadcrumbs
+// @Description The category with its ancestors, the root category first
+// @Tags Category
+// @Accept json
+// @Category json
+// @Param id path string true "id or slug"
+// @Param lang query string false "uz, uz-Cyrl, ru or en, the Accept-Language header is used when empty"
+// @Success 200 {object} models.CategoryBreadcrumbsResponse "Success Request"
+// @Response 301 {object} Response{data=string} "Moved to the current slug"
+// @Response 404 {object} Response{data=string} "Category not found"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) GetBreadcrumbsCategory(c *gin.Context) {
+	id, slug := slugOrId(c)
+
+	resp, err := h.storage.Category().GetBreadcrumbs(c.Request.Context(), &models.CategoryPrimaryKey{Id: id, Slug: slug, Lang: getLang(c)})
+	if errors.Is(err, storage.ErrCategoryNotFound) {
+		if slug != "" && h.redirectSlug(c, models.SlugEntityCategory, slug) {
+			return
+		}
+		c.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Category.GetBreadcrumbs!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("GetBreadcrumbsCategory Response!")
+	c.JSON(http.StatusOK, resp)
+}
+
 // Update Category godoc
 // @ID update_category
 // @Router /e_commerce/api/v1/category/{id} [PUT]
+// @Security ApiKeyAuth
 // @Summary Update Category
-// @Description Update Category
+// @Description Update Category, parent_id moves it with its subcategories and an empty parent_id makes it a root category
 // @Tags Category
 // @Accept json
 // @Category json
@@ -136,6 +243,8 @@ func (h *handler) GetListCategory(c *gin.Context) {
 // @Param Category body models.CategoryUpdate true "UpdateCategoryRequest"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Category not found"
+// @Response 409 {object} Response{data=string} "Move would create a cycle"
 // @Failure 500 {object} Response{data=string} "Server error"
 func (h *handler) UpdateCategory(c *gin.Context) {
 	var (
@@ -156,8 +265,41 @@ func (h *handler) UpdateCategory(c *gin.Context) {
 		return
 	}
 
+	var msg string
+	if categoryUpdate.NameI18n, msg = validTranslations("name_i18n", categoryUpdate.NameI18n, 100); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
+	if categoryUpdate.ParentId != nil && *categoryUpdate.ParentId != "" && !helper.IsValidUUID(*categoryUpdate.ParentId) {
+		h.logger.Error("is invalid parent uuid!")
+		c.JSON(http.StatusBadRequest, "invalid parent_id")
+		return
+	}
+
 	categoryUpdate.Id = id
+
+	before, err := h.storage.Category().GetByID(c.Request.Context(), &models.CategoryPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Category.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	if before.Id == "" {
+		c.JSON(http.StatusNotFound, storage.ErrCategoryNotFound.Error())
+		return
+	}
+
 	rowsAffected, err := h.storage.Category().Update(c.Request.Context(), &categoryUpdate)
+	if errors.Is(err, storage.ErrCategoryParentNotFound) {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, storage.ErrCategoryCycle) {
+		c.JSON(http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Category.Update!")
 		c.JSON(http.StatusInternalServerError, "Server Error!")
@@ -177,6 +319,8 @@ func (h *handler) UpdateCategory(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, models.AuditActionUpdate, models.AuditEntityCategory, id, before, resp)
+
 	h.logger.Info("Update Category Successfully!")
 	c.JSON(http.StatusAccepted, resp)
 }
@@ -184,14 +328,17 @@ func (h *handler) UpdateCategory(c *gin.Context) {
 // Delete Category godoc
 // @ID delete_category
 // @Router /e_commerce/api/v1/category/{id} [DELETE]
+// @Security ApiKeyAuth
 // @Summary Delete Category
-// @Description Delete Category
+// @Description Delete Category, categories with subcategories or products can not be deleted
 // @Tags Category
 // @Accept json
 // @Category json
 // @Param id path string true "id"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Category not found"
+// @Response 409 {object} Response{data=string} "Category has subcategories or products"
 // @Failure 500 {object} Response{data=string} "Server error"
 func (h *handler) DeleteCategory(c *gin.Context) {
 	var id = c.Param("id")
@@ -202,13 +349,90 @@ func (h *handler) DeleteCategory(c *gin.Context) {
 		return
 	}
 
-	err := h.storage.Category().Delete(c.Request.Context(), &models.CategoryPrimaryKey{Id: id})
+	before, err := h.storage.Category().GetByID(c.Request.Context(), &models.CategoryPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Category.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	if before.Id == "" {
+		c.JSON(http.StatusNotFound, storage.ErrCategoryNotFound.Error())
+		return
+	}
+
+	err = h.storage.Category().Delete(c.Request.Context(), &models.CategoryPrimaryKey{Id: id})
+	if errors.Is(err, storage.ErrCategoryHasChildren) || errors.Is(err, storage.ErrCategoryHasProducts) {
+		c.JSON(http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Category.Delete!")
 		c.JSON(http.StatusInternalServerError, "Unable to delete data, please try again later!")
 		return
 	}
 
+	h.audit(c, models.AuditActionDelete, models.AuditEntityCategory, id, before, nil)
+
 	h.logger.Info("Category Deleted Successfully!")
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// Restore Category godoc
+// @ID restore_category
+// @Router /e_commerce/api/v1/category/{id}/restore [POST]
+// @Security ApiKeyAuth
+// @Summary Restore Category
+// @Description Restore a soft deleted Category, its parent has to be restored first
+// @Tags Category
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Category "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Not found among the deleted"
+// @Response 409 {object} Response{data=string} "Parent category is deleted"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) RestoreCategory(c *gin.Context) {
+	var id = c.Param("id")
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is not valid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id!")
+		return
+	}
+
+	before, err := h.storage.Category().GetByID(c.Request.Context(), &models.CategoryPrimaryKey{Id: id, IncludeDeleted: true})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Category.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Category().Restore(c.Request.Context(), &models.CategoryPrimaryKey{Id: id})
+	if errors.Is(err, storage.ErrNotDeleted) {
+		c.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, storage.ErrParentDeleted) {
+		c.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Category.Restore!")
+		c.JSON(http.StatusInternalServerError, "Unable to restore data, please try again later!")
+		return
+	}
+
+	resp, err := h.storage.Category().GetByID(c.Request.Context(), &models.CategoryPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Category.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionRestore, models.AuditEntityCategory, id, before, resp)
+
+	h.logger.Info("Category Restored Successfully!")
+	c.JSON(http.StatusOK, resp)
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"e-commerce/config"
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
+	"e-commerce/storage"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -11,6 +14,7 @@ import (
 // Create Color godoc
 // @ID create_color
 // @Router /e_commerce/api/v1/color [POST]
+// @Security ApiKeyAuth
 // @Summary Create Color
 // @Description Create Color
 // @Tags Color
@@ -30,6 +34,9 @@ func (h *handler) CreateColor(c *gin.Context) {
 		return
 	}
 
+	info, _ := getAuthInfo(c)
+	colorCreate.CreatedBy = info.UserID
+
 	resp, err := h.storage.Color().Create(c.Request.Context(), &colorCreate)
 	if err != nil {
 		h.logger.Error("Error while creating color: " + err.Error())
@@ -37,6 +44,8 @@ func (h *handler) CreateColor(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, models.AuditActionCreate, models.AuditEntityColor, resp.Id, nil, resp)
+
 	h.logger.Info("Color created successfully")
 	c.JSON(http.StatusOK, resp)
 }
@@ -44,6 +53,7 @@ func (h *handler) CreateColor(c *gin.Context) {
 // GetList Color godoc
 // @ID get_list_color
 // @Router /e_commerce/api/v1/color [GET]
+// @Security ApiKeyAuth
 // @Summary Get List Color
 // @Description Get List Color
 // @Tags Color
@@ -51,6 +61,7 @@ func (h *handler) CreateColor(c *gin.Context) {
 // @Color json
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
+// @Param include_deleted query bool false "list soft deleted rows as well, admins only"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
 // @Failure 500 {object} Response{data=string} "Server error"
@@ -70,9 +81,15 @@ func (h *handler) GetListColor(c *gin.Context) {
 		return
 	}
 
+	includeDeleted, ok := h.getIncludeDeleted(c, config.PERMISSION_COLOR_WRITE)
+	if !ok {
+		return
+	}
+
 	resp, err := h.storage.Color().GetList(c.Request.Context(), &models.ColorGetListRequest{
-		Offset: offset,
-		Limit:  limit,
+		Offset:         offset,
+		Limit:          limit,
+		IncludeDeleted: includeDeleted,
 	})
 
 	if err != nil && err.Error() != "no rows in result set" {
@@ -85,9 +102,83 @@ func (h *handler) GetListColor(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// Update Color godoc
+// @ID update_color
+// @Router /e_commerce/api/v1/color/{id} [PUT]
+// @Security ApiKeyAuth
+// @Summary Update Color
+// @Description Rename the color or replace its images, empty ones are kept. The stock is changed with POST /color/{id}/stock
+// @Tags Color
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param Color body models.ColorUpdate true "UpdateColorRequest"
+// @Success 202 {object} models.Color "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Color not found"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) UpdateColor(c *gin.Context) {
+	var (
+		id          = c.Param("id")
+		colorUpdate models.ColorUpdate
+	)
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	err := c.ShouldBindJSON(&colorUpdate)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "error Color Should Bind Json!")
+		c.JSON(http.StatusBadRequest, "Please, Enter Valid Data!")
+		return
+	}
+
+	colorUpdate.Id = id
+
+	before, err := h.storage.Color().GetByID(c.Request.Context(), &models.ColorPrimaryKey{Id: id})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			c.JSON(http.StatusNotFound, storage.ErrColorNotFound.Error())
+			return
+		}
+		h.logger.Error(err.Error() + "  :  " + "storage.Color.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	rowsAffected, err := h.storage.Color().Update(c.Request.Context(), &colorUpdate)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Color.Update!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	if rowsAffected <= 0 {
+		h.logger.Error("storage.Color.Update!")
+		c.JSON(http.StatusBadRequest, "Unable to update data. Please try again later!")
+		return
+	}
+
+	resp, err := h.storage.Color().GetByID(c.Request.Context(), &models.ColorPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Color.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionUpdate, models.AuditEntityColor, id, before, resp)
+
+	h.logger.Info("Update Color Successfully!")
+	c.JSON(http.StatusAccepted, resp)
+}
+
 // Delete Color godoc
 // @ID delete_color
 // @Router /e_commerce/api/v1/color/{id} [DELETE]
+// @Security ApiKeyAuth
 // @Summary Delete Color
 // @Description Delete Color
 // @Tags Color
@@ -106,13 +197,81 @@ func (h *handler) DeleteColor(c *gin.Context) {
 		return
 	}
 
-	err := h.storage.Color().Delete(c.Request.Context(), &models.ColorPrimaryKey{Id: id})
+	before, err := h.storage.Color().GetByID(c.Request.Context(), &models.ColorPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Color.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Color().Delete(c.Request.Context(), &models.ColorPrimaryKey{Id: id})
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Color.Delete!")
 		c.JSON(http.StatusInternalServerError, "Unable to delete data, please try again later!")
 		return
 	}
 
+	h.audit(c, models.AuditActionDelete, models.AuditEntityColor, id, before, nil)
+
 	h.logger.Info("Color Deleted Successfully!")
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// Restore Color godoc
+// @ID restore_color
+// @Router /e_commerce/api/v1/color/{id}/restore [POST]
+// @Security ApiKeyAuth
+// @Summary Restore Color
+// @Description Restore a soft deleted Color, its product has to be restored first
+// @Tags Color
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Color "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Not found among the deleted"
+// @Response 409 {object} Response{data=string} "Product is deleted"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) RestoreColor(c *gin.Context) {
+	var id = c.Param("id")
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is not valid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id!")
+		return
+	}
+
+	before, err := h.storage.Color().GetByID(c.Request.Context(), &models.ColorPrimaryKey{Id: id, IncludeDeleted: true})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Color.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Color().Restore(c.Request.Context(), &models.ColorPrimaryKey{Id: id})
+	if errors.Is(err, storage.ErrNotDeleted) {
+		c.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, storage.ErrParentDeleted) {
+		c.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Color.Restore!")
+		c.JSON(http.StatusInternalServerError, "Unable to restore data, please try again later!")
+		return
+	}
+
+	resp, err := h.storage.Color().GetByID(c.Request.Context(), &models.ColorPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Color.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionRestore, models.AuditEntityColor, id, before, resp)
+
+	h.logger.Info("Color Restored Successfully!")
+	c.JSON(http.StatusOK, resp)
+}
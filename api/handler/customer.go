@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"e-commerce/config"
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
+	"e-commerce/storage"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -11,6 +14,7 @@ import (
 // Create Customer godoc
 // @ID create_customers
 // @Router /e_commerce/api/v1/customer [POST]
+// @Security ApiKeyAuth
 // @Summary Create Customer
 // @Description Create Customer
 // @Tags Customer
@@ -47,12 +51,14 @@ func (h *handler) CreateCustomer(c *gin.Context) {
 // GetByID Customer godoc
 // @ID get_by_id_customer
 // @Router /e_commerce/api/v1/customer/{id} [GET]
+// @Security ApiKeyAuth
 // @Summary Get By ID Customer
 // @Description Get By ID Customer
 // @Tags Customer
 // @Accept json
 // @Customer json
 // @Param id path string false "id"
+// @Param include_deleted query bool false "find it when soft deleted as well, admins only"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
 // @Failure 500 {object} Response{data=string} "Server error"
@@ -65,7 +71,18 @@ func (h *handler) GetByIdCustomer(c *gin.Context) {
 		return
 	}
 
-	request, err := h.storage.Customer().GetByID(c.Request.Context(), &models.CustomerPrimaryKey{Id: id})
+	if !canAccessCustomer(c, id, config.PERMISSION_CUSTOMER_READ) {
+		h.logger.Error("forbidden access to customer!")
+		c.JSON(http.StatusForbidden, "forbidden")
+		return
+	}
+
+	includeDeleted, ok := h.getIncludeDeleted(c, config.PERMISSION_CUSTOMER_READ)
+	if !ok {
+		return
+	}
+
+	request, err := h.storage.Customer().GetByID(c.Request.Context(), &models.CustomerPrimaryKey{Id: id, IncludeDeleted: includeDeleted})
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Customer.GetByID!")
 		c.JSON(http.StatusInternalServerError, "Server Error!")
@@ -79,6 +96,7 @@ func (h *handler) GetByIdCustomer(c *gin.Context) {
 // GetList Customer godoc
 // @ID get_list_customer
 // @Router /e_commerce/api/v1/customer [GET]
+// @Security ApiKeyAuth
 // @Summary Get List Customer
 // @Description Get List Customer
 // @Tags Customer
@@ -86,6 +104,7 @@ func (h *handler) GetByIdCustomer(c *gin.Context) {
 // @Customer json
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
+// @Param include_deleted query bool false "list soft deleted rows as well, admins only"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
 // @Failure 500 {object} Response{data=string} "Server error"
@@ -105,9 +124,15 @@ func (h *handler) GetListCustomer(c *gin.Context) {
 		return
 	}
 
+	includeDeleted, ok := h.getIncludeDeleted(c, config.PERMISSION_CUSTOMER_READ)
+	if !ok {
+		return
+	}
+
 	resp, err := h.storage.Customer().GetList(c.Request.Context(), &models.CustomerGetListRequest{
-		Offset: offset,
-		Limit:  limit,
+		Offset:         offset,
+		Limit:          limit,
+		IncludeDeleted: includeDeleted,
 	})
 
 	if err != nil && err.Error() != "no rows in result set" {
@@ -123,6 +148,7 @@ func (h *handler) GetListCustomer(c *gin.Context) {
 // Update Customer godoc
 // @ID update_customer
 // @Router /e_commerce/api/v1/customer/{id} [PUT]
+// @Security ApiKeyAuth
 // @Summary Update Customer
 // @Description Update Customer
 // @Tags Customer
@@ -145,6 +171,12 @@ func (h *handler) UpdateCustomer(c *gin.Context) {
 		return
 	}
 
+	if !canAccessCustomer(c, id, config.PERMISSION_CUSTOMER_WRITE) {
+		h.logger.Error("forbidden access to customer!")
+		c.JSON(http.StatusForbidden, "forbidden")
+		return
+	}
+
 	err := c.ShouldBindJSON(&customerUpdate)
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "error Customer Should Bind Json!")
@@ -180,6 +212,7 @@ func (h *handler) UpdateCustomer(c *gin.Context) {
 // Delete Customer godoc
 // @ID delete_customer
 // @Router /e_commerce/api/v1/customer/{id} [DELETE]
+// @Security ApiKeyAuth
 // @Summary Delete Customer
 // @Description Delete Customer
 // @Tags Customer
@@ -198,13 +231,81 @@ func (h *handler) DeleteCustomer(c *gin.Context) {
 		return
 	}
 
-	err := h.storage.Customer().Delete(c.Request.Context(), &models.CustomerPrimaryKey{Id: id})
+	before, err := h.storage.Customer().GetByID(c.Request.Context(), &models.CustomerPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Customer.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Customer().Delete(c.Request.Context(), &models.CustomerPrimaryKey{Id: id})
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Customer.Delete!")
 		c.JSON(http.StatusInternalServerError, "Unable to delete data, please try again later!")
 		return
 	}
 
+	h.audit(c, models.AuditActionDelete, models.AuditEntityCustomer, id, before, nil)
+
 	h.logger.Info("Customer Deleted Successfully!")
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// Restore Customer godoc
+// @ID restore_customer
+// @Router /e_commerce/api/v1/customer/{id}/restore [POST]
+// @Security ApiKeyAuth
+// @Summary Restore Customer
+// @Description Restore a soft deleted Customer unless their phone number is registered again
+// @Tags Customer
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Customer "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Not found among the deleted"
+// @Response 409 {object} Response{data=string} "Phone number is registered by another customer"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) RestoreCustomer(c *gin.Context) {
+	var id = c.Param("id")
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is not valid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id!")
+		return
+	}
+
+	before, err := h.storage.Customer().GetByID(c.Request.Context(), &models.CustomerPrimaryKey{Id: id, IncludeDeleted: true})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Customer.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Customer().Restore(c.Request.Context(), &models.CustomerPrimaryKey{Id: id})
+	if errors.Is(err, storage.ErrNotDeleted) {
+		c.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, storage.ErrPhoneRegistered) {
+		c.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Customer.Restore!")
+		c.JSON(http.StatusInternalServerError, "Unable to restore data, please try again later!")
+		return
+	}
+
+	resp, err := h.storage.Customer().GetByID(c.Request.Context(), &models.CustomerPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Customer.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionRestore, models.AuditEntityCustomer, id, before, resp)
+
+	h.logger.Info("Customer Restored Successfully!")
+	c.JSON(http.StatusOK, resp)
+}
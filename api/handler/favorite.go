@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"e-commerce/models"
+	"e-commerce/pkg/helper"
+	"e-commerce/storage"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Create Favorite godoc
+// @ID create_favorite
+// @Router /e_commerce/api/v1/product/{id}/favorite [POST]
+// @Security ApiKeyAuth
+// @Summary Create Favorite
+// @Description Add the product to the wishlist of the customer, adding it again changes nothing
+// @Tags Favorite
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Success 201 {object} models.Favorite "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Product not found"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) CreateFavorite(c *gin.Context) {
+	productId := c.Param("id")
+
+	if !helper.IsValidUUID(productId) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	resp, err := h.storage.Favorite().Create(c.Request.Context(), &models.FavoritePrimaryKey{
+		CustomerId: getCustomerId(c),
+		ProductId:  productId,
+	})
+	if errors.Is(err, storage.ErrProductNotFound) {
+		c.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Favorite.Create!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("Create Favorite Successfully!")
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetList Favorite godoc
+// @ID get_list_favorite
+// @Router /e_commerce/api/v1/favorite [GET]
+// @Security ApiKeyAuth
+// @Summary Get List Favorite
+// @Description Products in the wishlist of the customer
+// @Tags Favorite
+// @Accept json
+// @Produce json
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Param lang query string false "uz, uz-Cyrl, ru or en, the Accept-Language header is used when empty"
+// @Success 200 {object} models.ProductGetListResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) GetListFavorite(c *gin.Context) {
+	offset, err := h.getOffsetQuery(c.Query("offset"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListFavorite INVALID OFFSET!")
+		c.JSON(http.StatusBadRequest, "INVALID OFFSET")
+		return
+	}
+
+	limit, err := h.getLimitQuery(c.Query("limit"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListFavorite INVALID LIMIT!")
+		c.JSON(http.StatusBadRequest, "INVALID LIMIT")
+		return
+	}
+
+	favorite := true
+	resp, err := h.storage.Product().GetList(c.Request.Context(), &models.ProductGetListRequest{
+		Offset:     offset,
+		Limit:      limit,
+		Favorite:   &favorite,
+		CustomerId: getCustomerId(c),
+		Lang:       getLang(c),
+	})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Product.GetList!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("GetListFavorite Response!")
+	c.JSON(http.StatusOK, resp)
+}
+
+// Delete Favorite godoc
+// @ID delete_favorite
+// @Router /e_commerce/api/v1/product/{id}/favorite [DELETE]
+// @Security ApiKeyAuth
+// @Summary Delete Favorite
+// @Description Remove the product from the wishlist of the customer
+// @Tags Favorite
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Success 204 {object} Response{data=string} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) DeleteFavorite(c *gin.Context) {
+	productId := c.Param("id")
+
+	if !helper.IsValidUUID(productId) {
+		h.logger.Error("is not valid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id!")
+		return
+	}
+
+	err := h.storage.Favorite().Delete(c.Request.Context(), &models.FavoritePrimaryKey{
+		CustomerId: getCustomerId(c),
+		ProductId:  productId,
+	})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Favorite.Delete!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("Favorite Deleted Successfully!")
+	c.JSON(http.StatusNoContent, nil)
+}
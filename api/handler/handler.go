@@ -4,9 +4,12 @@ import (
 	"e-commerce/config"
 	"e-commerce/models"
 	"e-commerce/pkg/logger"
+	"e-commerce/pkg/ratelimit"
 	"e-commerce/service"
 	"e-commerce/storage"
+	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,6 +19,7 @@ type handler struct {
 	logger  logger.LoggerI
 	storage storage.StorageI
 	service service.IServiceManager
+	limiter ratelimit.Limiter
 }
 
 type Response struct {
@@ -29,12 +33,13 @@ type ErrorResponse struct {
 	Error interface{} `json:"error"`
 }
 
-func NewHandler(cfg *config.Config, storage storage.StorageI, logger logger.LoggerI, service service.IServiceManager) *handler {
+func NewHandler(cfg *config.Config, storage storage.StorageI, logger logger.LoggerI, service service.IServiceManager, limiter ratelimit.Limiter) *handler {
 	return &handler{
 		cfg:     cfg,
 		logger:  logger,
 		storage: storage,
 		service: service,
+		limiter: limiter,
 	}
 }
 
@@ -56,6 +61,36 @@ func (h *handler) getLimitQuery(limit string) (int, error) {
 	return strconv.Atoi(limit)
 }
 
+// getListQuery collects a query parameter given either repeated or comma
+// separated, ?brand_id=a&brand_id=b and ?brand_id=a,b are the same.
+func (h *handler) getListQuery(c *gin.Context, key string) []string {
+	var values []string
+
+	for _, param := range c.QueryArray(key) {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return values
+}
+
+// getFloatQuery returns nil when the parameter is not given.
+func (h *handler) getFloatQuery(value string) (*float64, error) {
+	if len(value) <= 0 {
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
 func handleResponseLog(c *gin.Context, log logger.LoggerI, msg string, statusCode int, data interface{}) {
 	resp := models.Response{}
 
@@ -80,3 +115,28 @@ func handleResponseLog(c *gin.Context, log logger.LoggerI, msg string, statusCod
 
 	c.JSON(resp.StatusCode, resp)
 }
+
+// getIncludeDeleted reads the include_deleted query parameter, only admins
+// granted the permission may see soft deleted rows. When it returns false the
+// response is already written.
+func (h *handler) getIncludeDeleted(c *gin.Context, permission string) (bool, bool) {
+	param := c.Query("include_deleted")
+	if param == "" {
+		return false, true
+	}
+
+	includeDeleted, err := strconv.ParseBool(param)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "INVALID INCLUDE DELETED PARAM!")
+		c.JSON(http.StatusBadRequest, "INVALID INCLUDE DELETED PARAM")
+		return false, false
+	}
+
+	if includeDeleted && !hasPermission(c, permission) {
+		h.logger.Error("forbidden access to deleted rows!")
+		c.JSON(http.StatusForbidden, "Forbidden!")
+		return false, false
+	}
+
+	return includeDeleted, true
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"e-commerce/models"
+	"e-commerce/pkg/helper"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetList InventoryMovement godoc
+// @ID get_list_inventory_movement
+// @Router /e_commerce/api/v1/inventory/movement [GET]
+// @Security ApiKeyAuth
+// @Summary Get List Inventory Movement
+// @Description Stock changes of colors and variants newest first, every sale, cancellation, restock, return, damage, correction and import with the order or admin behind it
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Param product_id query string false "product_id"
+// @Param color_id query string false "color_id"
+// @Param variant_id query string false "variant_id"
+// @Param order_id query string false "order_id"
+// @Param kind query string false "initial, sale, cancellation, restock, return, damage, correction or import"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Success 200 {object} models.InventoryMovementGetListResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) GetListInventoryMovement(c *gin.Context) {
+	offset, err := h.getOffsetQuery(c.Query("offset"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListInventoryMovement INVALID OFFSET!")
+		c.JSON(http.StatusBadRequest, "INVALID OFFSET")
+		return
+	}
+
+	limit, err := h.getLimitQuery(c.Query("limit"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListInventoryMovement INVALID LIMIT!")
+		c.JSON(http.StatusBadRequest, "INVALID LIMIT")
+		return
+	}
+
+	req := models.InventoryMovementGetListRequest{
+		ProductId: c.Query("product_id"),
+		ColorId:   c.Query("color_id"),
+		VariantId: c.Query("variant_id"),
+		OrderId:   c.Query("order_id"),
+		Kind:      c.Query("kind"),
+		Offset:    offset,
+		Limit:     limit,
+	}
+
+	for _, id := range []string{req.ProductId, req.ColorId, req.VariantId, req.OrderId} {
+		if id != "" && !helper.IsValidUUID(id) {
+			h.logger.Error("is invalid uuid!")
+			c.JSON(http.StatusBadRequest, "invalid id")
+			return
+		}
+	}
+
+	resp, err := h.storage.Inventory().GetList(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Inventory.GetList!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("GetListInventoryMovement Response!")
+	c.JSON(http.StatusOK, resp)
+}
+
+// Reconcile Inventory godoc
+// @ID reconcile_inventory
+// @Router /e_commerce/api/v1/inventory/reconcile [GET]
+// @Security ApiKeyAuth
+// @Summary Reconcile Inventory
+// @Description Recompute the stock of every color and variant, or of the product's, from the ledger and list the ones whose stock does not match it
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Param product_id query string false "product_id"
+// @Success 200 {object} models.InventoryReconcileResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) ReconcileInventory(c *gin.Context) {
+	req := models.InventoryReconcileRequest{
+		ProductId: c.Query("product_id"),
+	}
+
+	if req.ProductId != "" && !helper.IsValidUUID(req.ProductId) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid product_id")
+		return
+	}
+
+	resp, err := h.storage.Inventory().Reconcile(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Inventory.Reconcile!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	if len(resp.Mismatches) > 0 {
+		h.logger.Warn("inventory does not match the ledger")
+	}
+
+	h.logger.Info("ReconcileInventory Response!")
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"e-commerce/models"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getLang is the locale the catalog texts are returned in, the lang query
+// wins over the Accept-Language header and the default locale is used when
+// neither names a known locale
+func getLang(c *gin.Context) string {
+	if lang := matchLocale(c.Query("lang")); lang != "" {
+		return lang
+	}
+
+	type weighted struct {
+		locale string
+		q      float64
+	}
+
+	var accepted []weighted
+	for _, part := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		if locale := matchLocale(tag); locale != "" && q > 0 {
+			accepted = append(accepted, weighted{locale: locale, q: q})
+		}
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].q > accepted[j].q
+	})
+	if len(accepted) > 0 {
+		return accepted[0].locale
+	}
+
+	return models.DefaultLocale
+}
+
+// matchLocale maps a language tag like "ru-RU" or "uz_Cyrl_UZ" to one of the
+// catalog locales, or to "" when there is none for it
+func matchLocale(tag string) string {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+
+	switch {
+	case tag == "uz-cyrl" || strings.HasPrefix(tag, "uz-cyrl-"):
+		return models.LocaleUzCyrl
+	case tag == "uz" || strings.HasPrefix(tag, "uz-"):
+		return models.LocaleUz
+	case tag == "ru" || strings.HasPrefix(tag, "ru-"):
+		return models.LocaleRu
+	case tag == "en" || strings.HasPrefix(tag, "en-"):
+		return models.LocaleEn
+	}
+
+	return ""
+}
+
+// validTranslations checks the translations of a text and drops the empty
+// ones, nil is kept so an update leaves the stored translations alone. The
+// message is empty when the translations are valid.
+func validTranslations(field string, translations models.Translations, maxLength int) (models.Translations, string) {
+	if translations == nil {
+		return nil, ""
+	}
+
+	valid := models.Translations{}
+	for locale, text := range translations {
+		known := false
+		for _, translationLocale := range models.TranslationLocales {
+			if locale == translationLocale {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, field + " has unknown locale " + strconv.Quote(locale) + ", the locales are " + strings.Join(models.TranslationLocales, ", ")
+		}
+
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		if maxLength > 0 && utf8.RuneCountInString(text) > maxLength {
+			return nil, field + " " + locale + " can not be longer than " + strconv.Itoa(maxLength) + " characters"
+		}
+		valid[locale] = text
+	}
+
+	return valid, ""
+}
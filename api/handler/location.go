@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"e-commerce/config"
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
+	"e-commerce/storage"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -11,6 +14,7 @@ import (
 // Create Location godoc
 // @ID create_location
 // @Router /e_commerce/api/v1/location [POST]
+// @Security ApiKeyAuth
 // @Summary Create Location
 // @Description Create Location
 // @Tags Location
@@ -32,6 +36,16 @@ func (h *handler) CreateLocation(c *gin.Context) {
 		return
 	}
 
+	var msg string
+	if locationCreate.NameI18n, msg = validTranslations("name_i18n", locationCreate.NameI18n, 255); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+	if locationCreate.InfoI18n, msg = validTranslations("info_i18n", locationCreate.InfoI18n, 0); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
 	resp, err := h.storage.Location().Create(c.Request.Context(), &locationCreate)
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "error Location.Create")
@@ -39,6 +53,8 @@ func (h *handler) CreateLocation(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, models.AuditActionCreate, models.AuditEntityLocation, resp.Id, nil, resp)
+
 	h.logger.Info("Create Location Successfully!!")
 	c.JSON(http.StatusCreated, resp)
 }
@@ -46,12 +62,15 @@ func (h *handler) CreateLocation(c *gin.Context) {
 // GetByID Location godoc
 // @ID get_by_id_location
 // @Router /e_commerce/api/v1/location/{id} [GET]
+// @Security ApiKeyAuth
 // @Summary Get By ID Location
 // @Description Get By ID Location
 // @Tags Location
 // @Accept json
 // @Location json
 // @Param id path string false "id"
+// @Param lang query string false "uz, uz-Cyrl, ru or en, the Accept-Language header is used when empty"
+// @Param include_deleted query bool false "find it when soft deleted as well, admins only"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
 // @Failure 500 {object} Response{data=string} "Server error"
@@ -64,7 +83,12 @@ func (h *handler) GetByIdLocation(c *gin.Context) {
 		return
 	}
 
-	request, err := h.storage.Location().GetByID(c.Request.Context(), &models.LacationPrimaryKey{Id: id})
+	includeDeleted, ok := h.getIncludeDeleted(c, config.PERMISSION_LOCATION_WRITE)
+	if !ok {
+		return
+	}
+
+	request, err := h.storage.Location().GetByID(c.Request.Context(), &models.LacationPrimaryKey{Id: id, Lang: getLang(c), IncludeDeleted: includeDeleted})
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Location.GetByID!")
 		c.JSON(http.StatusInternalServerError, "Server Error!")
@@ -78,6 +102,7 @@ func (h *handler) GetByIdLocation(c *gin.Context) {
 // GetList Location godoc
 // @ID get_list_location
 // @Router /e_commerce/api/v1/location [GET]
+// @Security ApiKeyAuth
 // @Summary Get List Location
 // @Description Get List Location
 // @Tags Location
@@ -85,6 +110,8 @@ func (h *handler) GetByIdLocation(c *gin.Context) {
 // @Location json
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
+// @Param lang query string false "uz, uz-Cyrl, ru or en, the Accept-Language header is used when empty"
+// @Param include_deleted query bool false "list soft deleted rows as well, admins only"
 // @Success 200 {object} Response{data=string} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
 // @Failure 500 {object} Response{data=string} "Server error"
@@ -104,9 +131,16 @@ func (h *handler) GetListLocation(c *gin.Context) {
 		return
 	}
 
+	includeDeleted, ok := h.getIncludeDeleted(c, config.PERMISSION_LOCATION_WRITE)
+	if !ok {
+		return
+	}
+
 	resp, err := h.storage.Location().GetList(c.Request.Context(), &models.LocationGetListRequest{
-		Offset: offset,
-		Limit:  limit,
+		Offset:         offset,
+		Limit:          limit,
+		Lang:           getLang(c),
+		IncludeDeleted: includeDeleted,
 	})
 
 	if err != nil && err.Error() != "no rows in result set" {
@@ -122,6 +156,7 @@ func (h *handler) GetListLocation(c *gin.Context) {
 // Update Location godoc
 // @ID update_location
 // @Router /e_commerce/api/v1/location/{id} [PUT]
+// @Security ApiKeyAuth
 // @Summary Update Location
 // @Description Update Location
 // @Tags Location
@@ -151,7 +186,25 @@ func (h *handler) UpdateLocation(c *gin.Context) {
 		return
 	}
 
+	var msg string
+	if locationUpdate.NameI18n, msg = validTranslations("name_i18n", locationUpdate.NameI18n, 255); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+	if locationUpdate.InfoI18n, msg = validTranslations("info_i18n", locationUpdate.InfoI18n, 0); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
 	locationUpdate.Id = id
+
+	before, err := h.storage.Location().GetByID(c.Request.Context(), &models.LacationPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Location.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
 	rowsAffected, err := h.storage.Location().Update(c.Request.Context(), &locationUpdate)
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Location.Update!")
@@ -172,6 +225,8 @@ func (h *handler) UpdateLocation(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, models.AuditActionUpdate, models.AuditEntityLocation, id, before, resp)
+
 	h.logger.Info("Update Location Successfully!")
 	c.JSON(http.StatusAccepted, resp)
 }
@@ -179,6 +234,7 @@ func (h *handler) UpdateLocation(c *gin.Context) {
 // Delete Location godoc
 // @ID delete_location
 // @Router /e_commerce/api/v1/location/{id} [DELETE]
+// @Security ApiKeyAuth
 // @Summary Delete Location
 // @Description Delete Location
 // @Tags Location
@@ -197,13 +253,76 @@ func (h *handler) DeleteLocation(c *gin.Context) {
 		return
 	}
 
-	err := h.storage.Location().Delete(c.Request.Context(), &models.LacationPrimaryKey{Id: id})
+	before, err := h.storage.Location().GetByID(c.Request.Context(), &models.LacationPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Location.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Location().Delete(c.Request.Context(), &models.LacationPrimaryKey{Id: id})
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Location.Delete!")
 		c.JSON(http.StatusInternalServerError, "Unable to delete data, please try again later!")
 		return
 	}
 
+	h.audit(c, models.AuditActionDelete, models.AuditEntityLocation, id, before, nil)
+
 	h.logger.Info("Location Deleted Successfully!")
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// Restore Location godoc
+// @ID restore_location
+// @Router /e_commerce/api/v1/location/{id}/restore [POST]
+// @Security ApiKeyAuth
+// @Summary Restore Location
+// @Description Restore a soft deleted Location
+// @Tags Location
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Location "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Not found among the deleted"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) RestoreLocation(c *gin.Context) {
+	var id = c.Param("id")
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is not valid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id!")
+		return
+	}
+
+	before, err := h.storage.Location().GetByID(c.Request.Context(), &models.LacationPrimaryKey{Id: id, IncludeDeleted: true})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Location.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Location().Restore(c.Request.Context(), &models.LacationPrimaryKey{Id: id})
+	if errors.Is(err, storage.ErrNotDeleted) {
+		c.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Location.Restore!")
+		c.JSON(http.StatusInternalServerError, "Unable to restore data, please try again later!")
+		return
+	}
+
+	resp, err := h.storage.Location().GetByID(c.Request.Context(), &models.LacationPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Location.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionRestore, models.AuditEntityLocation, id, before, resp)
+
+	h.logger.Info("Location Restored Successfully!")
+	c.JSON(http.StatusOK, resp)
+}
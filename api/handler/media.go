@@ -0,0 +1,326 @@
+package handler
+
+import (
+	"e-commerce/models"
+	"e-commerce/pkg/helper"
+	"e-commerce/storage"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Create Media godoc
+// @ID create_media
+// @Router /e_commerce/api/v1/product/{id}/media [POST]
+// @Security ApiKeyAuth
+// @Summary Create Media
+// @Description Append images uploaded with /upload-files to the end of the product gallery. An image marked primary becomes the product image
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Param Media body models.ProductMediaCreateRequest true "CreateMediaRequest"
+// @Success 201 {object} models.ProductMediaGetListResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Product not found"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) CreateMedia(c *gin.Context) {
+	var (
+		productId   = c.Param("id")
+		mediaCreate models.ProductMediaCreateRequest
+	)
+
+	if !helper.IsValidUUID(productId) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	err := c.ShouldBindJSON(&mediaCreate)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "error Media Should Bind Json!")
+		c.JSON(http.StatusBadRequest, "Please, Enter Valid Data!")
+		return
+	}
+
+	if len(mediaCreate.Media) == 0 {
+		c.JSON(http.StatusBadRequest, "media is required")
+		return
+	}
+
+	for i := range mediaCreate.Media {
+		media := &mediaCreate.Media[i]
+		media.Url = strings.TrimSpace(media.Url)
+		if media.Url == "" {
+			c.JSON(http.StatusBadRequest, "url is required")
+			return
+		}
+		if msg, ok := validMediaLink(media.VariantId, media.ColorId); !ok {
+			c.JSON(http.StatusBadRequest, msg)
+			return
+		}
+	}
+
+	mediaCreate.ProductId = productId
+
+	resp, err := h.storage.Media().Create(c.Request.Context(), &mediaCreate)
+	if err != nil {
+		h.handleMediaError(c, err, "storage.Media.Create!")
+		return
+	}
+
+	h.audit(c, models.AuditActionCreate, models.AuditEntityMedia, productId, nil, resp)
+
+	h.logger.Info("Create Media Successfully!")
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetList Media godoc
+// @ID get_list_media
+// @Router /e_commerce/api/v1/product/{id}/media [GET]
+// @Summary Get List Media
+// @Description Images of the product in gallery order
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Success 200 {object} models.ProductMediaGetListResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) GetListMedia(c *gin.Context) {
+	productId := c.Param("id")
+
+	if !helper.IsValidUUID(productId) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	resp, err := h.storage.Media().GetList(c.Request.Context(), &models.ProductMediaGetListRequest{ProductId: productId})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Media.GetList!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("GetListMedia Response!")
+	c.JSON(http.StatusOK, resp)
+}
+
+// Reorder Media godoc
+// @ID reorder_media
+// @Router /e_commerce/api/v1/product/{id}/media/order [PUT]
+// @Security ApiKeyAuth
+// @Summary Reorder Media
+// @Description Put the product images in the given order, media_ids must list every image of the product once
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Param Media body models.ProductMediaReorder true "ReorderMediaRequest"
+// @Success 202 {object} models.ProductMediaGetListResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Product not found"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) ReorderMedia(c *gin.Context) {
+	var (
+		productId    = c.Param("id")
+		mediaReorder models.ProductMediaReorder
+	)
+
+	if !helper.IsValidUUID(productId) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	err := c.ShouldBindJSON(&mediaReorder)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "error Media Should Bind Json!")
+		c.JSON(http.StatusBadRequest, "Please, Enter Valid Data!")
+		return
+	}
+
+	for _, id := range mediaReorder.MediaIds {
+		if !helper.IsValidUUID(id) {
+			c.JSON(http.StatusBadRequest, "invalid media id: "+id)
+			return
+		}
+	}
+
+	mediaReorder.ProductId = productId
+
+	before, err := h.storage.Media().GetList(c.Request.Context(), &models.ProductMediaGetListRequest{ProductId: productId})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Media.GetList!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Media().Reorder(c.Request.Context(), &mediaReorder)
+	if err != nil {
+		h.handleMediaError(c, err, "storage.Media.Reorder!")
+		return
+	}
+
+	resp, err := h.storage.Media().GetList(c.Request.Context(), &models.ProductMediaGetListRequest{ProductId: productId})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Media.GetList!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionUpdate, models.AuditEntityMedia, productId, before, resp)
+
+	h.logger.Info("Reorder Media Successfully!")
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// Update Media godoc
+// @ID update_media
+// @Router /e_commerce/api/v1/media/{id} [PUT]
+// @Security ApiKeyAuth
+// @Summary Update Media
+// @Description Change the alt text, primary flag and the variant or color of an image
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param Media body models.ProductMediaUpdate true "UpdateMediaRequest"
+// @Success 202 {object} models.ProductMedia "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Not found"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) UpdateMedia(c *gin.Context) {
+	var (
+		id          = c.Param("id")
+		mediaUpdate models.ProductMediaUpdate
+	)
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	err := c.ShouldBindJSON(&mediaUpdate)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "error Media Should Bind Json!")
+		c.JSON(http.StatusBadRequest, "Please, Enter Valid Data!")
+		return
+	}
+
+	if msg, ok := validMediaLink(mediaUpdate.VariantId, mediaUpdate.ColorId); !ok {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
+	mediaUpdate.Id = id
+
+	before, err := h.storage.Media().GetByID(c.Request.Context(), &models.ProductMediaPrimaryKey{Id: id})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			c.JSON(http.StatusNotFound, "media not found")
+			return
+		}
+		h.logger.Error(err.Error() + "  :  " + "storage.Media.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	rowsAffected, err := h.storage.Media().Update(c.Request.Context(), &mediaUpdate)
+	if err != nil {
+		h.handleMediaError(c, err, "storage.Media.Update!")
+		return
+	}
+
+	if rowsAffected <= 0 {
+		h.logger.Error("storage.Media.Update!")
+		c.JSON(http.StatusBadRequest, "Unable to update data. Please try again later!")
+		return
+	}
+
+	resp, err := h.storage.Media().GetByID(c.Request.Context(), &models.ProductMediaPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Media.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionUpdate, models.AuditEntityMedia, id, before, resp)
+
+	h.logger.Info("Update Media Successfully!")
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// Delete Media godoc
+// @ID delete_media
+// @Router /e_commerce/api/v1/media/{id} [DELETE]
+// @Security ApiKeyAuth
+// @Summary Delete Media
+// @Description Remove an image from the product gallery, the next image becomes primary when the primary one is removed
+// @Tags Media
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 204 {object} Response{data=string} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Not found"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) DeleteMedia(c *gin.Context) {
+	id := c.Param("id")
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is not valid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id!")
+		return
+	}
+
+	before, err := h.storage.Media().GetByID(c.Request.Context(), &models.ProductMediaPrimaryKey{Id: id})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			c.JSON(http.StatusNotFound, "media not found")
+			return
+		}
+		h.logger.Error(err.Error() + "  :  " + "storage.Media.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Media().Delete(c.Request.Context(), &models.ProductMediaPrimaryKey{Id: id})
+	if err != nil {
+		h.handleMediaError(c, err, "storage.Media.Delete!")
+		return
+	}
+
+	h.audit(c, models.AuditActionDelete, models.AuditEntityMedia, id, before, nil)
+
+	h.logger.Info("Media Deleted Successfully!")
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *handler) handleMediaError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, storage.ErrProductNotFound),
+		errors.Is(err, storage.ErrMediaNotFound):
+		c.JSON(http.StatusNotFound, err.Error())
+	case errors.Is(err, storage.ErrMediaLinkInvalid),
+		errors.Is(err, storage.ErrMediaOrderMismatch):
+		c.JSON(http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error(err.Error() + "  :  " + fallback)
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+	}
+}
+
+func validMediaLink(variantId, colorId string) (string, bool) {
+	if variantId != "" && !helper.IsValidUUID(variantId) {
+		return "invalid variant_id", false
+	}
+	if colorId != "" && !helper.IsValidUUID(colorId) {
+		return "invalid color_id", false
+	}
+	return "", true
+}
@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"bytes"
+	"e-commerce/config"
+	"e-commerce/models"
+	"e-commerce/pkg/jwt"
+	"e-commerce/pkg/logger"
+	"e-commerce/pkg/ratelimit"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+const authInfoKey = "auth_info"
+
+var (
+	errMissingToken  = errors.New("authorization token is missing")
+	errInvalidClaims = errors.New("token has invalid claims")
+)
+
+// AuthMiddleware validates the Bearer token from the Authorization header and
+// stores the caller as models.AuthInfo in the gin context.
+func (h *handler) AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		info, err := h.parseAuthHeader(c.GetHeader("Authorization"))
+		if err != nil {
+			h.logger.Error("unauthorized request: " + err.Error())
+			c.AbortWithStatusJSON(http.StatusUnauthorized, models.Response{
+				StatusCode:  http.StatusUnauthorized,
+				Description: "unauthorized",
+			})
+			return
+		}
+
+		c.Set(authInfoKey, info)
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware stores the caller like AuthMiddleware when a token is
+// sent and lets anonymous requests through. A token that is sent but invalid
+// is still rejected.
+func (h *handler) OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+
+		h.AuthMiddleware()(c)
+	}
+}
+
+// RoleMiddleware lets the request through only when the authenticated caller
+// has one of the given roles. It must run after AuthMiddleware.
+func (h *handler) RoleMiddleware(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		info, ok := getAuthInfo(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, models.Response{
+				StatusCode:  http.StatusUnauthorized,
+				Description: "unauthorized",
+			})
+			return
+		}
+
+		for _, role := range roles {
+			if info.UserRole == role {
+				c.Next()
+				return
+			}
+		}
+
+		h.logger.Error("forbidden request for role: " + info.UserRole)
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Response{
+			StatusCode:  http.StatusForbidden,
+			Description: "forbidden",
+		})
+	}
+}
+
+// PermissionMiddleware lets the request through only when the caller is an
+// admin whose role grants the permission. It must run after AuthMiddleware.
+func (h *handler) PermissionMiddleware(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		info, ok := getAuthInfo(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, models.Response{
+				StatusCode:  http.StatusUnauthorized,
+				Description: "unauthorized",
+			})
+			return
+		}
+
+		if info.UserRole != config.ADMIN_ROLE || !info.HasPermission(permission) {
+			h.logger.Error("forbidden request, missing permission: " + permission)
+			c.AbortWithStatusJSON(http.StatusForbidden, models.Response{
+				StatusCode:  http.StatusForbidden,
+				Description: "forbidden",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RateLimitByIP limits requests per client IP.
+func (h *handler) RateLimitByIP(policy ratelimit.Policy) gin.HandlerFunc {
+	return h.rateLimit(policy, func(c *gin.Context) string {
+		return c.ClientIP()
+	})
+}
+
+// RateLimitByPhone limits requests per phone number found in the JSON body,
+// requests without a phone are left to the other limits.
+func (h *handler) RateLimitByPhone(policy ratelimit.Policy) gin.HandlerFunc {
+	return h.rateLimit(policy, phoneFromBody)
+}
+
+// RateLimitByUser limits requests per authenticated caller, falling back to
+// the client IP. It must run after AuthMiddleware.
+func (h *handler) RateLimitByUser(policy ratelimit.Policy) gin.HandlerFunc {
+	return h.rateLimit(policy, func(c *gin.Context) string {
+		if info, ok := getAuthInfo(c); ok {
+			return info.UserRole + ":" + info.UserID
+		}
+		return c.ClientIP()
+	})
+}
+
+func (h *handler) rateLimit(policy ratelimit.Policy, key func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		k := key(c)
+		if k == "" {
+			c.Next()
+			return
+		}
+
+		allowed, retryAfter, err := h.limiter.Allow(c.Request.Context(), policy, k)
+		if err != nil {
+			// never lock users out because the limiter is broken
+			h.logger.Error("error while checking rate limit", logger.Error(err))
+			c.Next()
+			return
+		}
+
+		if !allowed {
+			h.logger.Warn("rate limit exceeded", logger.String("policy", policy.Name), logger.String("key", k))
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, models.Response{
+				StatusCode:  http.StatusTooManyRequests,
+				Description: "too many requests",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// maxAuthBodySize bounds the auth request bodies read before the caller is
+// known, a phone number and a password fit in a fraction of it.
+const maxAuthBodySize = 4 << 10
+
+// phoneFromBody reads the phone number the auth endpoints receive and puts
+// the body back for the handler. A body over maxAuthBodySize is cut there,
+// so the handler fails to bind it.
+func phoneFromBody(c *gin.Context) string {
+	if c.Request.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxAuthBodySize))
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	var fields struct {
+		MobilePhone string `json:"mobile_phone"`
+		PhoneNumber string `json:"phone_number"`
+		Login       string `json:"login"`
+	}
+	if err = json.Unmarshal(body, &fields); err != nil {
+		return ""
+	}
+
+	for _, phone := range []string{fields.MobilePhone, fields.PhoneNumber, fields.Login} {
+		if phone != "" {
+			return phone
+		}
+	}
+
+	return ""
+}
+
+func (h *handler) parseAuthHeader(header string) (models.AuthInfo, error) {
+	token := strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+	if token == "" {
+		return models.AuthInfo{}, errMissingToken
+	}
+
+	claims, err := jwt.ExtractClaims(token)
+	if err != nil {
+		return models.AuthInfo{}, err
+	}
+
+	if cast.ToString(claims["token_type"]) != jwt.AccessTokenType {
+		return models.AuthInfo{}, errInvalidClaims
+	}
+
+	info := models.AuthInfo{
+		UserID:      cast.ToString(claims["user_id"]),
+		UserRole:    cast.ToString(claims["user_role"]),
+		Permissions: cast.ToStringSlice(claims["permissions"]),
+	}
+	if info.UserID == "" || (info.UserRole != config.CUSTOMER_ROLE && info.UserRole != config.ADMIN_ROLE) {
+		return models.AuthInfo{}, errInvalidClaims
+	}
+
+	return info, nil
+}
+
+// getAuthInfo returns the caller stored by AuthMiddleware.
+func getAuthInfo(c *gin.Context) (models.AuthInfo, bool) {
+	value, exists := c.Get(authInfoKey)
+	if !exists {
+		return models.AuthInfo{}, false
+	}
+
+	info, ok := value.(models.AuthInfo)
+	return info, ok
+}
+
+// getCustomerId returns the id of the calling customer, it is empty for
+// anonymous callers and admins.
+func getCustomerId(c *gin.Context) string {
+	info, ok := getAuthInfo(c)
+	if !ok || info.UserRole != config.CUSTOMER_ROLE {
+		return ""
+	}
+	return info.UserID
+}
+
+// canAccessCustomer reports whether the caller is the customer identified by
+// customerID or an admin granted the permission.
+func canAccessCustomer(c *gin.Context, customerID string, permission string) bool {
+	info, ok := getAuthInfo(c)
+	if !ok {
+		return false
+	}
+
+	if info.UserRole == config.ADMIN_ROLE {
+		return info.HasPermission(permission)
+	}
+
+	return info.UserID == customerID
+}
+
+// hasPermission reports whether the caller is an admin granted the permission.
+func hasPermission(c *gin.Context, permission string) bool {
+	info, ok := getAuthInfo(c)
+	return ok && info.UserRole == config.ADMIN_ROLE && info.HasPermission(permission)
+}
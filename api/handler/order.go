@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"e-commerce/config"
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
 	"encoding/json"
@@ -14,6 +15,7 @@ import (
 // Create Order godoc
 // @ID          create_order
 // @Router      /e_commerce/api/v1/order [POST]
+// @Security    ApiKeyAuth
 // @Summary     Create Order
 // @Description Create Order
 // @Tags        Order
@@ -43,6 +45,10 @@ func (h *handler) CreateOrder(c *gin.Context) {
 		return
 	}
 
+	if info, ok := getAuthInfo(c); ok && info.UserRole == config.CUSTOMER_ROLE {
+		request.Order.CustomerId = info.UserID
+	}
+
 	if request.Order.CustomerId == "" {
 		h.logger.Error("Customer ID is empty!")
 		c.JSON(http.StatusBadRequest, Response{Data: "Customer ID is required!"})
@@ -54,6 +60,11 @@ func (h *handler) CreateOrder(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, Response{Data: "Product ID is required for each item!"})
 			return
 		}
+		if item.VariantId != "" && !helper.IsValidUUID(item.VariantId) {
+			h.logger.Error("Variant ID is invalid for one of the items!")
+			c.JSON(http.StatusBadRequest, Response{Data: "Invalid variant ID!"})
+			return
+		}
 	}
 
 	order, err := h.storage.Order().CreateOrder(&request)
@@ -70,6 +81,7 @@ func (h *handler) CreateOrder(c *gin.Context) {
 // GetByID Order godoc
 // @ID get_by_id_order
 // @Router /e_commerce/api/v1/order/{id} [GET]
+// @Security ApiKeyAuth
 // @Summary Get By ID Order
 // @Description Get By ID Order
 // @Tags Order
@@ -95,6 +107,12 @@ func (h *handler) GetByIdOrder(c *gin.Context) {
 		return
 	}
 
+	if !canAccessCustomer(c, order.Order.CustomerId, config.PERMISSION_ORDER_READ) {
+		h.logger.Error("forbidden access to order!")
+		c.JSON(http.StatusForbidden, Response{Data: "Forbidden!"})
+		return
+	}
+
 	h.logger.Info("Order Retrieved Successfully!")
 	c.JSON(http.StatusOK, Response{Data: order})
 }
@@ -102,6 +120,7 @@ func (h *handler) GetByIdOrder(c *gin.Context) {
 // GetList 		Order godoc
 // @ID   		get_all_orders
 // @Router      /e_commerce/api/v1/order [GET]
+// @Security    ApiKeyAuth
 // @Summary     Get All Orders
 // @Description Retrieve all orders
 // @Tags        Order
@@ -133,8 +152,15 @@ func (h *handler) GetAllOrders(c *gin.Context) {
 	}
 	req.Limit = limit
 
-	// Add customer_id filter
+	// Add customer_id filter, customers can only see their own orders
 	req.CustomerId = c.Query("customer_id")
+	if info, ok := getAuthInfo(c); ok && info.UserRole == config.CUSTOMER_ROLE {
+		req.CustomerId = info.UserID
+	} else if !hasPermission(c, config.PERMISSION_ORDER_READ) {
+		h.logger.Error("forbidden access to orders!")
+		c.JSON(http.StatusForbidden, Response{Data: "Forbidden!"})
+		return
+	}
 
 	orders, err := h.storage.Order().GetAll(context.Background(), &req)
 	if err != nil {
@@ -150,6 +176,7 @@ func (h *handler) GetAllOrders(c *gin.Context) {
 // Update Order godoc
 // @ID update_order
 // @Router /e_commerce/api/v1/order/{id} [PUT]
+// @Security ApiKeyAuth
 // @Summary Update Order
 // @Description Update Order
 // @Tags Order
@@ -178,7 +205,14 @@ func (h *handler) UpdateOrder(c *gin.Context) {
 
 	orderUpdate.Id = id
 
-	err := h.storage.Order().UpdateOrder(orderUpdate)
+	before, err := h.storage.Order().GetOrder(id)
+	if err != nil {
+		h.logger.Error("error in Order.GetOrder: " + err.Error())
+		c.JSON(http.StatusInternalServerError, Response{Data: "Server Error!"})
+		return
+	}
+
+	err = h.storage.Order().UpdateOrder(orderUpdate)
 	if err != nil {
 		h.logger.Error("error in Order.UpdateOrder: " + err.Error())
 		c.JSON(http.StatusInternalServerError, Response{Data: "Server Error!"})
@@ -191,6 +225,13 @@ func (h *handler) UpdateOrder(c *gin.Context) {
 	// 	return
 	// }
 
+	after, err := h.storage.Order().GetOrder(id)
+	if err != nil {
+		h.logger.Error("error in Order.GetOrder: " + err.Error())
+	} else {
+		h.audit(c, models.AuditActionUpdate, models.AuditEntityOrder, id, before.Order, after.Order)
+	}
+
 	h.logger.Info("Order Updated Successfully!")
 	c.JSON(http.StatusAccepted, Response{Data: orderUpdate})
 }
@@ -198,8 +239,9 @@ func (h *handler) UpdateOrder(c *gin.Context) {
 // Delete Order godoc
 // @ID delete_order
 // @Router /e_commerce/api/v1/order/{id} [DELETE]
+// @Security ApiKeyAuth
 // @Summary Delete Order
-// @Description Delete Order
+// @Description Cancel and delete the order, the stock of an order that is not delivered yet goes back and is recorded as a cancellation
 // @Tags Order
 // @Accept json
 // @Order json
@@ -216,12 +258,23 @@ func (h *handler) DeleteOrder(c *gin.Context) {
 		return
 	}
 
-	if err := h.storage.Order().DeleteOrder(id); err != nil {
+	before, err := h.storage.Order().GetOrder(id)
+	if err != nil {
+		h.logger.Error("error in Order.GetOrder: " + err.Error())
+		c.JSON(http.StatusInternalServerError, Response{Data: "Server Error!"})
+		return
+	}
+
+	info, _ := getAuthInfo(c)
+
+	if err := h.storage.Order().DeleteOrder(id, info.UserID); err != nil {
 		h.logger.Error("error in Order.DeleteOrder: " + err.Error())
 		c.JSON(http.StatusInternalServerError, Response{Data: "Unable to delete data, please try again later!"})
 		return
 	}
 
+	h.audit(c, models.AuditActionDelete, models.AuditEntityOrder, id, before, nil)
+
 	h.logger.Info("Order Deleted Successfully!")
 	c.JSON(http.StatusNoContent, nil)
 }
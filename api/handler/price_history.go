@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"e-commerce/models"
+	"e-commerce/pkg/helper"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetList PriceHistory godoc
+// @ID get_list_price_history
+// @Router /e_commerce/api/v1/product/{id}/price-history [GET]
+// @Security ApiKeyAuth
+// @Summary Get List Price History
+// @Description Price changes of the product, or of one of its variants, newest first with who made them and why, and the lowest price of the last 30 days
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Param variant_id query string false "variant_id"
+// @Param from query string false "from date, 2006-01-02"
+// @Param to query string false "to date (exclusive), 2006-01-02"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Success 200 {object} models.PriceHistoryGetListResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) GetListPriceHistory(c *gin.Context) {
+	productId := c.Param("id")
+
+	if !helper.IsValidUUID(productId) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	offset, err := h.getOffsetQuery(c.Query("offset"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListPriceHistory INVALID OFFSET!")
+		c.JSON(http.StatusBadRequest, "INVALID OFFSET")
+		return
+	}
+
+	limit, err := h.getLimitQuery(c.Query("limit"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListPriceHistory INVALID LIMIT!")
+		c.JSON(http.StatusBadRequest, "INVALID LIMIT")
+		return
+	}
+
+	req := models.PriceHistoryGetListRequest{
+		ProductId: productId,
+		VariantId: c.Query("variant_id"),
+		From:      c.Query("from"),
+		To:        c.Query("to"),
+		Offset:    offset,
+		Limit:     limit,
+	}
+
+	if req.VariantId != "" && !helper.IsValidUUID(req.VariantId) {
+		h.logger.Error("is invalid variant uuid!")
+		c.JSON(http.StatusBadRequest, "invalid variant_id")
+		return
+	}
+
+	for _, date := range []string{req.From, req.To} {
+		if date == "" {
+			continue
+		}
+		if _, err = time.Parse("2006-01-02", date); err != nil {
+			h.logger.Error(err.Error() + "  :  " + "GetListPriceHistory INVALID DATE!")
+			c.JSON(http.StatusBadRequest, "INVALID DATE")
+			return
+		}
+	}
+
+	resp, err := h.storage.PriceHistory().GetList(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.PriceHistory.GetList!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("GetListPriceHistory Response!")
+	c.JSON(http.StatusOK, resp)
+}
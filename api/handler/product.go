@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"e-commerce/config"
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
+	"e-commerce/storage"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -12,8 +15,9 @@ import (
 // Create Product godoc
 // @ID create_product
 // @Router /e_commerce/api/v1/product [POST]
+// @Security ApiKeyAuth
 // @Summary Create Product
-// @Description Create Product
+// @Description Create Product. A vremennaya_skidka product with a discount_percent gets its discount from discount_start_time, now when it is empty, until discount_end_time
 // @Tags Product
 // @Accept json
 // @Product json
@@ -33,13 +37,32 @@ func (h *handler) CreateProduct(c *gin.Context) {
 		return
 	}
 
+	var msg string
+	if productCreate.NameI18n, msg = validTranslations("name_i18n", productCreate.NameI18n, 100); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+	if productCreate.DescriptionI18n, msg = validTranslations("description_i18n", productCreate.DescriptionI18n, 1000); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
+	info, _ := getAuthInfo(c)
+	productCreate.PriceChangedBy = info.UserID
+
 	resp, err := h.storage.Product().Create(c.Request.Context(), &productCreate)
+	if errors.Is(err, storage.ErrDiscountInvalid) {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "error Product.Create")
 		c.JSON(http.StatusInternalServerError, "Server Error!")
 		return
 	}
 
+	h.audit(c, models.AuditActionCreate, models.AuditEntityProduct, resp.Id, nil, resp)
+
 	h.logger.Info("Create Product Successfully!!")
 	c.JSON(http.StatusCreated, resp)
 }
@@ -47,31 +70,58 @@ func (h *handler) CreateProduct(c *gin.Context) {
 // GetByID Product godoc
 // @ID get_by_id_product
 // @Router /e_commerce/api/v1/product/{id} [GET]
+// @Security ApiKeyAuth
 // @Summary Get By ID Product
-// @Description Get By ID Product
+// @Description Get By ID or slug, an old slug redirects to the current one. is_favorite is set for a logged in customer
 // @Tags Product
 // @Accept json
 // @Product json
-// @Param id path string true "id"
+// @Param id path string true "id or slug"
+// @Param lang query string false "uz, uz-Cyrl, ru or en, the Accept-Language header is used when empty"
+// @Param include_deleted query bool false "find it when soft deleted as well, admins only"
 // @Success 200 {object} Response{data=string} "Success Request"
-// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 301 {object} Response{data=string} "Moved to the current slug"
+// @Response 404 {object} Response{data=string} "Not found"
 // @Failure 500 {object} Response{data=string} "Server error"
 func (h *handler) GetByIdProduct(c *gin.Context) {
-	id := c.Param("id")
+	id, slug := slugOrId(c)
 
-	if !helper.IsValidUUID(id) {
-		h.logger.Error("is valid uuid!")
-		c.JSON(http.StatusBadRequest, "invalid id")
+	includeDeleted, ok := h.getIncludeDeleted(c, config.PERMISSION_PRODUCT_WRITE)
+	if !ok {
 		return
 	}
 
-	request, err := h.storage.Product().GetByID(c.Request.Context(), &models.ProductPrimaryKey{Id: id})
+	request, err := h.storage.Product().GetByID(c.Request.Context(), &models.ProductPrimaryKey{Id: id, Slug: slug, CustomerId: getCustomerId(c), Lang: getLang(c), IncludeDeleted: includeDeleted})
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Product.GetByID!")
 		c.JSON(http.StatusInternalServerError, "Server Error!")
 		return
 	}
 
+	if request.Id == "" {
+		if slug != "" && h.redirectSlug(c, models.SlugEntityProduct, slug) {
+			return
+		}
+		c.JSON(http.StatusNotFound, "product not found")
+		return
+	}
+
+	variants, err := h.storage.Variant().GetList(c.Request.Context(), &models.ProductVariantGetListRequest{ProductId: request.Id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Variant.GetList!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+	request.Variants = variants.Variants
+
+	media, err := h.storage.Media().GetList(c.Request.Context(), &models.ProductMediaGetListRequest{ProductId: request.Id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Media.GetList!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+	request.Media = media.Media
+
 	h.logger.Info("GetByID Product Response!")
 	c.JSON(http.StatusOK, request)
 }
@@ -79,18 +129,30 @@ func (h *handler) GetByIdProduct(c *gin.Context) {
 // GetList Product godoc
 // @ID get_list_product
 // @Router /e_commerce/api/v1/product [GET]
+// @Security ApiKeyAuth
 // @Summary Get List Product
-// @Description Get List Product
+// @Description Get List Product. is_favorite is set for a logged in customer
 // @Tags Product
 // @Accept json
 // @Product json
 // @Param offset query string false "offset"
 // @Param limit query string false "limit"
-// @Param favorite query string false "favorite"
+// @Param favorite query boolean false "only products in, or not in, the wishlist of the logged in customer"
 // @Param category_id query string false "category_id"
-// @Param name query string false "name"
+// @Param brand_id query string false "brand ids, comma separated"
+// @Param status query string false "novinka, rasprodaja or vremennaya_skidka, comma separated"
+// @Param color query string false "color names, comma separated"
+// @Param min_price query number false "minimum price after discount"
+// @Param max_price query number false "maximum price after discount"
+// @Param min_rating query number false "min_rating"
+// @Param in_stock query boolean false "only products in stock"
+// @Param sort query string false "newest, price_asc, price_desc, rating or popular"
+// @Param name query string false "search by name, description, brand or category, ranked by relevance"
+// @Param lang query string false "uz, uz-Cyrl, ru or en, the Accept-Language header is used when empty"
+// @Param include_deleted query bool false "list soft deleted rows as well, admins only"
 // @Success 200 {object} Response{data=models.ProductGetListResponse} "Success Request"
 // @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 401 {object} Response{data=string} "The favorite filter needs a logged in customer"
 // @Failure 500 {object} Response{data=string} "Server error"
 func (h *handler) GetListProduct(c *gin.Context) {
 
@@ -108,6 +170,8 @@ func (h *handler) GetListProduct(c *gin.Context) {
 		return
 	}
 
+	customerId := getCustomerId(c)
+
 	var favorite *bool
 	if favParam := c.Query("favorite"); favParam != "" {
 		fav, err := strconv.ParseBool(favParam)
@@ -116,19 +180,102 @@ func (h *handler) GetListProduct(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, "INVALID FAVORITE PARAM")
 			return
 		}
+		if customerId == "" {
+			c.JSON(http.StatusUnauthorized, "login to filter by favorites")
+			return
+		}
 		favorite = &fav
 	}
 
 	categoryId := c.Query("category_id")
+	if categoryId != "" && !helper.IsValidUUID(categoryId) {
+		h.logger.Error("GetListProduct INVALID CATEGORY ID!")
+		c.JSON(http.StatusBadRequest, "INVALID CATEGORY ID")
+		return
+	}
 
 	name := c.Query("name")
 
+	brandIds := h.getListQuery(c, "brand_id")
+	for _, brandId := range brandIds {
+		if !helper.IsValidUUID(brandId) {
+			h.logger.Error("GetListProduct INVALID BRAND ID!")
+			c.JSON(http.StatusBadRequest, "INVALID BRAND ID")
+			return
+		}
+	}
+
+	statuses := h.getListQuery(c, "status")
+	for _, status := range statuses {
+		if status != models.ProductStatusNew && status != models.ProductStatusSale && status != models.ProductStatusDiscount {
+			h.logger.Error("GetListProduct INVALID STATUS!")
+			c.JSON(http.StatusBadRequest, "INVALID STATUS")
+			return
+		}
+	}
+
+	minPrice, err := h.getFloatQuery(c.Query("min_price"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListProduct INVALID MIN PRICE!")
+		c.JSON(http.StatusBadRequest, "INVALID MIN PRICE")
+		return
+	}
+
+	maxPrice, err := h.getFloatQuery(c.Query("max_price"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListProduct INVALID MAX PRICE!")
+		c.JSON(http.StatusBadRequest, "INVALID MAX PRICE")
+		return
+	}
+
+	minRating, err := h.getFloatQuery(c.Query("min_rating"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListProduct INVALID MIN RATING!")
+		c.JSON(http.StatusBadRequest, "INVALID MIN RATING")
+		return
+	}
+
+	var inStock bool
+	if inStockParam := c.Query("in_stock"); inStockParam != "" {
+		inStock, err = strconv.ParseBool(inStockParam)
+		if err != nil {
+			h.logger.Error(err.Error() + "  :  " + "GetListProduct INVALID IN STOCK PARAM!")
+			c.JSON(http.StatusBadRequest, "INVALID IN STOCK PARAM")
+			return
+		}
+	}
+
+	sort := c.Query("sort")
+	switch sort {
+	case "", models.ProductSortNewest, models.ProductSortPriceAsc, models.ProductSortPriceDesc, models.ProductSortRating, models.ProductSortPopular:
+	default:
+		h.logger.Error("GetListProduct INVALID SORT!")
+		c.JSON(http.StatusBadRequest, "INVALID SORT")
+		return
+	}
+
+	includeDeleted, ok := h.getIncludeDeleted(c, config.PERMISSION_PRODUCT_WRITE)
+	if !ok {
+		return
+	}
+
 	resp, err := h.storage.Product().GetList(c.Request.Context(), &models.ProductGetListRequest{
-		Offset:     offset,
-		Limit:      limit,
-		Favorite:   favorite,
-		CategoryId: categoryId,
-		Name:       name,
+		Offset:         offset,
+		Limit:          limit,
+		Favorite:       favorite,
+		CustomerId:     customerId,
+		Lang:           getLang(c),
+		CategoryId:     categoryId,
+		BrandIds:       brandIds,
+		Name:           name,
+		Statuses:       statuses,
+		Colors:         h.getListQuery(c, "color"),
+		MinPrice:       minPrice,
+		MaxPrice:       maxPrice,
+		MinRating:      minRating,
+		InStock:        inStock,
+		Sort:           sort,
+		IncludeDeleted: includeDeleted,
 	})
 
 	if err != nil && err.Error() != "no rows in result set" {
@@ -144,8 +291,9 @@ func (h *handler) GetListProduct(c *gin.Context) {
 // Update Product godoc
 // @ID update_product
 // @Router /e_commerce/api/v1/product/{id} [PUT]
+// @Security ApiKeyAuth
 // @Summary Update Product
-// @Description Update Product
+// @Description Update Product. A vremennaya_skidka product with a discount_percent gets its discount from discount_start_time, now when it is empty, until discount_end_time
 // @Tags Product
 // @Accept json
 // @Produce json
@@ -173,8 +321,33 @@ func (h *handler) UpdateProduct(c *gin.Context) {
 		return
 	}
 
+	var msg string
+	if productUpdate.NameI18n, msg = validTranslations("name_i18n", productUpdate.NameI18n, 100); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+	if productUpdate.DescriptionI18n, msg = validTranslations("description_i18n", productUpdate.DescriptionI18n, 1000); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
 	productUpdate.Id = id
+
+	info, _ := getAuthInfo(c)
+	productUpdate.PriceChangedBy = info.UserID
+
+	before, err := h.storage.Product().GetByID(c.Request.Context(), &models.ProductPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Product.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
 	rowsAffected, err := h.storage.Product().Update(c.Request.Context(), &productUpdate)
+	if errors.Is(err, storage.ErrDiscountInvalid) {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		h.logger.Error(err.Error() + "  :  " + "storage.Product.Update!")
 		c.JSON(http.StatusInternalServerError, "Server Error!")
@@ -194,6 +367,8 @@ func (h *handler) UpdateProduct(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, models.AuditActionUpdate, models.AuditEntityProduct, id, before, resp)
+
 	h.logger.Info("Update Product Successfully!")
 	c.JSON(http.StatusAccepted, resp)
 }
@@ -201,6 +376,7 @@ func (h *handler) UpdateProduct(c *gin.Context) {
 // Delete Product godoc
 // @ID delete_product
 // @Router /e_commerce/api/v1/product/{id} [DELETE]
+// @Security ApiKeyAuth
 // @Summary Delete Product
 // @Description Delete Product
 // @Tags Product
@@ -221,7 +397,16 @@ func (h *handler) DeleteProduct(c *gin.Context) {
 		return
 	}
 
-	if err := h.storage.Product().Delete(c.Request.Context(), &models.ProductPrimaryKey{Id: id}); err != nil {
+	before, err := h.storage.Product().GetByID(c.Request.Context(), &models.ProductPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error("error in Product.GetByID: " + err.Error())
+		c.JSON(http.StatusInternalServerError, Response{
+			Error: "Unable to delete data. Please try again later!",
+		})
+		return
+	}
+
+	if err = h.storage.Product().Delete(c.Request.Context(), &models.ProductPrimaryKey{Id: id}); err != nil {
 		h.logger.Error("error in Product.Delete: " + err.Error())
 		c.JSON(http.StatusInternalServerError, Response{
 			Error: "Unable to delete data. Please try again later!",
@@ -229,6 +414,67 @@ func (h *handler) DeleteProduct(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, models.AuditActionDelete, models.AuditEntityProduct, id, before, nil)
+
 	h.logger.Info("Product Deleted Successfully!")
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// Restore Product godoc
+// @ID restore_product
+// @Router /e_commerce/api/v1/product/{id}/restore [POST]
+// @Security ApiKeyAuth
+// @Summary Restore Product
+// @Description Restore a soft deleted Product with its colors, its category has to be restored first
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Product "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Not found among the deleted"
+// @Response 409 {object} Response{data=string} "Category is deleted"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) RestoreProduct(c *gin.Context) {
+	var id = c.Param("id")
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is not valid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id!")
+		return
+	}
+
+	before, err := h.storage.Product().GetByID(c.Request.Context(), &models.ProductPrimaryKey{Id: id, IncludeDeleted: true})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Product.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Product().Restore(c.Request.Context(), &models.ProductPrimaryKey{Id: id})
+	if errors.Is(err, storage.ErrNotDeleted) {
+		c.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, storage.ErrParentDeleted) {
+		c.JSON(http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Product.Restore!")
+		c.JSON(http.StatusInternalServerError, "Unable to restore data, please try again later!")
+		return
+	}
+
+	resp, err := h.storage.Product().GetByID(c.Request.Context(), &models.ProductPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Product.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionRestore, models.AuditEntityProduct, id, before, resp)
+
+	h.logger.Info("Product Restored Successfully!")
+	c.JSON(http.StatusOK, resp)
+}
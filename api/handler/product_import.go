@@ -0,0 +1,327 @@
+package handler
+
+import (
+	"e-commerce/models"
+	"e-commerce/pkg/spreadsheet"
+	"math"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	productImportMaxSize = 10 << 20
+	productImportMaxRows = 5000
+
+	// productImportUrlSeparator separates the images of a color in a cell
+	productImportUrlSeparator = "|"
+)
+
+// Import Product godoc
+// @ID import_product
+// @Router /e_commerce/api/v1/product/import [POST]
+// @Security ApiKeyAuth
+// @Summary Import Product
+// @Description Create or update products, their colors and stock from a csv or xlsx file with a row per product color and the columns of the export. A row updates the product with its slug, or without one the product with its name, and creates it when there is none. category and brand are names or slugs, color_url lists images separated by |, empty color_url and count cells keep what the color has. Nothing is saved when a row has an error
+// @Tags Product
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "csv or xlsx file"
+// @Param dry_run query boolean false "only check the file"
+// @Success 200 {object} models.ProductImportReport "Success Request"
+// @Response 400 {object} models.ProductImportReport "Rows with errors, nothing was saved"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) ImportProduct(c *gin.Context) {
+	var dryRun bool
+	if dryRunParam := c.Query("dry_run"); dryRunParam != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, "INVALID DRY RUN PARAM")
+			return
+		}
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "File error")
+		c.JSON(http.StatusBadRequest, "file is required")
+		return
+	}
+
+	if file.Size > productImportMaxSize {
+		c.JSON(http.StatusBadRequest, "file can not be larger than 10 MB")
+		return
+	}
+
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+	if format != spreadsheet.FormatCSV && format != spreadsheet.FormatXLSX {
+		c.JSON(http.StatusBadRequest, spreadsheet.ErrUnsupportedFormat.Error())
+		return
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "File error")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+	defer content.Close()
+
+	// the header row comes on top of the products
+	rows, err := spreadsheet.Read(format, content, file.Size, productImportMaxRows+1)
+	if err == spreadsheet.ErrTooManyRows {
+		c.JSON(http.StatusBadRequest, "file can not have more than "+strconv.Itoa(productImportMaxRows)+" rows")
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, "could not read the file: "+err.Error())
+		return
+	}
+
+	if len(rows) < 2 {
+		c.JSON(http.StatusBadRequest, "file has no products")
+		return
+	}
+
+	columns, msg := productImportColumns(rows[0].Cells)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
+	var (
+		valid       []models.ProductCatalogRow
+		parseErrors []models.ProductImportError
+	)
+	for _, row := range rows[1:] {
+		catalogRow, rowErrors := productCatalogRow(row, columns)
+		if len(rowErrors) > 0 {
+			parseErrors = append(parseErrors, rowErrors...)
+			continue
+		}
+		valid = append(valid, catalogRow)
+	}
+
+	info, _ := getAuthInfo(c)
+
+	report, err := h.storage.Product().Import(c.Request.Context(), &models.ProductImportRequest{
+		Rows:       valid,
+		DryRun:     dryRun || len(parseErrors) > 0,
+		ImportedBy: info.UserID,
+	})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Product.Import!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	report.DryRun = dryRun
+	report.Rows = len(rows) - 1
+	report.Errors = append(report.Errors, parseErrors...)
+	sort.SliceStable(report.Errors, func(i, j int) bool {
+		return report.Errors[i].Line < report.Errors[j].Line
+	})
+
+	if report.Applied {
+		h.audit(c, models.AuditActionImport, models.AuditEntityProduct, "", nil, report)
+	}
+
+	if len(report.Errors) > 0 && !dryRun {
+		c.JSON(http.StatusBadRequest, report)
+		return
+	}
+
+	h.logger.Info("Import Product Successfully!")
+	c.JSON(http.StatusOK, report)
+}
+
+// Export Product godoc
+// @ID export_product
+// @Router /e_commerce/api/v1/product/export [GET]
+// @Security ApiKeyAuth
+// @Summary Export Product
+// @Description Download the whole catalog as a csv or xlsx file with a row per product color, the file can be changed and imported back
+// @Tags Product
+// @Produce octet-stream
+// @Param format query string false "csv (default) or xlsx"
+// @Success 200 {file} file "Catalog file"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) ExportProduct(c *gin.Context) {
+	format := c.DefaultQuery("format", spreadsheet.FormatCSV)
+	if format != spreadsheet.FormatCSV && format != spreadsheet.FormatXLSX {
+		c.JSON(http.StatusBadRequest, spreadsheet.ErrUnsupportedFormat.Error())
+		return
+	}
+
+	filename := "products-" + time.Now().Format("2006-01-02") + "." + format
+	c.Header("Content-Type", spreadsheet.ContentType(format))
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+
+	writer, err := spreadsheet.NewWriter(format, c.Writer)
+	if err == nil {
+		err = writer.WriteRow(models.ProductColumns)
+	}
+	if err == nil {
+		err = h.storage.Product().Export(c.Request.Context(), func(row *models.ProductCatalogRow) error {
+			return writer.WriteRow(productCatalogCells(row))
+		})
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+
+	// the file is cut short, the status is already sent
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Product.Export!")
+		return
+	}
+
+	h.logger.Info("Export Product Successfully!")
+}
+
+// productImportColumns maps the columns of the header row to their index
+func productImportColumns(header []string) (map[string]int, string) {
+	known := map[string]bool{}
+	for _, column := range models.ProductColumns {
+		known[column] = true
+	}
+
+	columns := map[string]int{}
+	for i, cell := range header {
+		column := strings.ToLower(strings.TrimSpace(cell))
+		if column == "" {
+			continue
+		}
+		if !known[column] {
+			return nil, "unknown column " + strconv.Quote(cell) + ", the columns are " + strings.Join(models.ProductColumns, ", ")
+		}
+		if _, ok := columns[column]; ok {
+			return nil, "column " + column + " is given twice"
+		}
+		columns[column] = i
+	}
+
+	for _, column := range []string{models.ProductColumnName, models.ProductColumnCategory, models.ProductColumnPrice} {
+		if _, ok := columns[column]; !ok {
+			return nil, "column " + column + " is required"
+		}
+	}
+
+	return columns, ""
+}
+
+// productCatalogRow checks the cells of a row that do not need the catalog
+func productCatalogRow(row spreadsheet.Row, columns map[string]int) (models.ProductCatalogRow, []models.ProductImportError) {
+	var rowErrors []models.ProductImportError
+
+	cell := func(column string) string {
+		index, ok := columns[column]
+		if !ok || index >= len(row.Cells) {
+			return ""
+		}
+		return strings.TrimSpace(row.Cells[index])
+	}
+	fail := func(column, message string) {
+		rowErrors = append(rowErrors, models.ProductImportError{Line: row.Line, Column: column, Message: message})
+	}
+
+	catalogRow := models.ProductCatalogRow{
+		Line:        row.Line,
+		Slug:        cell(models.ProductColumnSlug),
+		Name:        cell(models.ProductColumnName),
+		Category:    cell(models.ProductColumnCategory),
+		Brand:       cell(models.ProductColumnBrand),
+		Description: cell(models.ProductColumnDescription),
+		Image:       cell(models.ProductColumnImage),
+		Status:      strings.ToLower(cell(models.ProductColumnStatus)),
+		Color:       cell(models.ProductColumnColor),
+	}
+
+	switch length := utf8.RuneCountInString(catalogRow.Name); {
+	case length == 0:
+		fail(models.ProductColumnName, "name is required")
+	case length > 100:
+		fail(models.ProductColumnName, "name can not be longer than 100 characters")
+	}
+
+	if catalogRow.Category == "" {
+		fail(models.ProductColumnCategory, "category is required")
+	}
+
+	if utf8.RuneCountInString(catalogRow.Description) > 1000 {
+		fail(models.ProductColumnDescription, "description can not be longer than 1000 characters")
+	}
+
+	if utf8.RuneCountInString(catalogRow.Color) > 100 {
+		fail(models.ProductColumnColor, "color can not be longer than 100 characters")
+	}
+
+	// spaces group thousands and a lone comma is the decimal separator in
+	// some locales
+	price := strings.ReplaceAll(cell(models.ProductColumnPrice), " ", "")
+	if !strings.Contains(price, ".") {
+		price = strings.Replace(price, ",", ".", 1)
+	}
+	value, err := strconv.ParseFloat(price, 64)
+	if err != nil || value <= 0 || value >= 1e8 || math.IsNaN(value) {
+		fail(models.ProductColumnPrice, "price must be a positive number below 100000000")
+	}
+	catalogRow.Price = math.Round(value*100) / 100
+
+	switch catalogRow.Status {
+	case "", models.ProductStatusNew, models.ProductStatusSale:
+	case models.ProductStatusDiscount:
+		fail(models.ProductColumnStatus, "discounts are set on the product, not by import")
+	default:
+		fail(models.ProductColumnStatus, "status must be empty, novinka or rasprodaja")
+	}
+
+	for _, url := range strings.FieldsFunc(cell(models.ProductColumnColorUrl), func(r rune) bool {
+		return strings.ContainsRune(productImportUrlSeparator+"\n", r)
+	}) {
+		if url = strings.TrimSpace(url); url != "" {
+			catalogRow.ColorUrl = append(catalogRow.ColorUrl, url)
+		}
+	}
+
+	if countCell := cell(models.ProductColumnCount); countCell != "" {
+		count, err := strconv.Atoi(countCell)
+		if err != nil || count < 0 {
+			fail(models.ProductColumnCount, "count must be a whole number, 0 or more")
+		}
+		catalogRow.Count = &count
+	}
+
+	return catalogRow, rowErrors
+}
+
+// productCatalogCells is the export row in the order of models.ProductColumns
+func productCatalogCells(row *models.ProductCatalogRow) []string {
+	count := ""
+	if row.Count != nil {
+		count = strconv.Itoa(*row.Count)
+	}
+
+	return []string{
+		row.Slug,
+		row.Name,
+		row.Category,
+		row.Brand,
+		strconv.FormatFloat(row.Price, 'f', -1, 64),
+		row.Description,
+		row.Image,
+		row.Status,
+		row.Color,
+		strings.Join(row.ColorUrl, productImportUrlSeparator),
+		count,
+	}
+}
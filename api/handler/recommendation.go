@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"e-commerce/models"
+	"e-commerce/pkg/helper"
+	"e-commerce/storage"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// recommendationMaxLimit caps how many products a recommendation returns
+const recommendationMaxLimit = 50
+
+// GetRelated Product godoc
+// @ID get_related_product
+// @Router /e_commerce/api/v1/product/{id}/related [GET]
+// @Summary Get Related Product
+// @Description Products in stock like the product, those of its category and brand first, then of its category, then of its brand. Products often ordered with it rank higher within each group
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Param limit query string false "limit, at most 50"
+// @Param lang query string false "uz, uz-Cyrl, ru or en, the Accept-Language header is used when empty"
+// @Success 200 {object} models.ProductRecommendationResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Product not found"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) GetRelatedProduct(c *gin.Context) {
+	h.getRecommendations(c, models.RecommendationRelated)
+}
+
+// GetBoughtTogether Product godoc
+// @ID get_bought_together_product
+// @Router /e_commerce/api/v1/product/{id}/bought-together [GET]
+// @Summary Get Bought Together Product
+// @Description Products in stock that customers ordered together with the product, most often first. When there are not enough of them the products of its category and then of its brand fill the list
+// @Tags Product
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Param limit query string false "limit, at most 50"
+// @Param lang query string false "uz, uz-Cyrl, ru or en, the Accept-Language header is used when empty"
+// @Success 200 {object} models.ProductRecommendationResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Product not found"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) GetBoughtTogetherProduct(c *gin.Context) {
+	h.getRecommendations(c, models.RecommendationBoughtTogether)
+}
+
+func (h *handler) getRecommendations(c *gin.Context, kind string) {
+	productId := c.Param("id")
+
+	if !helper.IsValidUUID(productId) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	limit, err := h.getLimitQuery(c.Query("limit"))
+	if err != nil || limit <= 0 {
+		h.logger.Error("getRecommendations INVALID LIMIT!")
+		c.JSON(http.StatusBadRequest, "INVALID LIMIT")
+		return
+	}
+	if limit > recommendationMaxLimit {
+		limit = recommendationMaxLimit
+	}
+
+	resp, err := h.storage.Product().GetRecommendations(c.Request.Context(), &models.ProductRecommendationRequest{
+		ProductId:  productId,
+		Kind:       kind,
+		Limit:      limit,
+		CustomerId: getCustomerId(c),
+		Lang:       getLang(c),
+	})
+	if errors.Is(err, storage.ErrProductNotFound) {
+		c.JSON(http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Product.GetRecommendations!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("GetRecommendations Response!")
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,425 @@
+package handler
+
+import (
+	"e-commerce/models"
+	"e-commerce/pkg/helper"
+	"e-commerce/storage"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	reviewMaxPhotos     = 5
+	reviewMaxPhotoSize  = 5 << 20
+	reviewMaxTextLength = 2000
+
+	// reviewPhotoPrefix is the url start of the files POST /review/photos
+	// uploads, reviews may only point at those
+	reviewPhotoPrefix = "https://firebasestorage.googleapis.com/v0/b/ecommece-e1b2e.appspot.com/o/reviews%2F"
+)
+
+var reviewPhotoExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".webp": true}
+
+// Create Review godoc
+// @ID create_review
+// @Router /e_commerce/api/v1/product/{id}/review [POST]
+// @Security ApiKeyAuth
+// @Summary Create Review
+// @Description Review a product the customer received, a 1-5 rating with text and photos from /review/photos. The review is shown once an admin approves it
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Param Review body models.ReviewCreate true "CreateReviewRequest"
+// @Success 201 {object} models.Review "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 403 {object} Response{data=string} "No delivered order of the product"
+// @Response 404 {object} Response{data=string} "Product not found"
+// @Response 409 {object} Response{data=string} "Already reviewed"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) CreateReview(c *gin.Context) {
+	var (
+		productId    = c.Param("id")
+		reviewCreate models.ReviewCreate
+	)
+
+	if !helper.IsValidUUID(productId) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	err := c.ShouldBindJSON(&reviewCreate)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "error Review Should Bind Json!")
+		c.JSON(http.StatusBadRequest, "Please, Enter Valid Data!")
+		return
+	}
+
+	reviewCreate.Text = strings.TrimSpace(reviewCreate.Text)
+	if msg, ok := validReview(&reviewCreate); !ok {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
+	info, _ := getAuthInfo(c)
+	reviewCreate.ProductId = productId
+	reviewCreate.CustomerId = info.UserID
+
+	resp, err := h.storage.Review().Create(c.Request.Context(), &reviewCreate)
+	if err != nil {
+		h.handleReviewError(c, err, "storage.Review.Create!")
+		return
+	}
+
+	h.logger.Info("Create Review Successfully!")
+	c.JSON(http.StatusCreated, resp)
+}
+
+// Upload Review Photos godoc
+// @ID upload_review_photos
+// @Router /e_commerce/api/v1/review/photos [POST]
+// @Security ApiKeyAuth
+// @Summary Upload Review Photos
+// @Description Upload up to 5 jpg, png or webp photos of at most 5 MB for a review
+// @Tags Review
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData []file true "Photos to upload"
+// @Success 200 {object} models.MultipleFileUploadResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) UploadReviewPhotos(c *gin.Context) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "File error")
+		c.JSON(http.StatusBadRequest, "Please, Enter Valid Data!")
+		return
+	}
+
+	files := form.File["file"]
+	if len(files) == 0 || len(files) > reviewMaxPhotos {
+		c.JSON(http.StatusBadRequest, "upload 1 to "+strconv.Itoa(reviewMaxPhotos)+" photos")
+		return
+	}
+
+	for _, file := range files {
+		ext := strings.ToLower(filepath.Ext(file.Filename))
+		if !reviewPhotoExtensions[ext] || file.Size > reviewMaxPhotoSize {
+			c.JSON(http.StatusBadRequest, "photos must be jpg, png or webp of at most 5 MB")
+			return
+		}
+		// customers do not choose the stored name, so they can not overwrite files
+		file.Filename = "reviews/" + uuid.New().String() + ext
+	}
+
+	resp, err := helper.UploadFiles(form)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "Upload error")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetList Product Review godoc
+// @ID get_list_product_review
+// @Router /e_commerce/api/v1/product/{id}/review [GET]
+// @Summary Get List Product Review
+// @Description Approved reviews of the product with its rating summary
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Param rating query integer false "only reviews with this rating"
+// @Param with_photos query boolean false "only reviews with photos"
+// @Param sort query string false "newest, rating_desc or rating_asc"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Success 200 {object} models.ReviewGetListResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) GetListProductReview(c *gin.Context) {
+	productId := c.Param("id")
+
+	if !helper.IsValidUUID(productId) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	req, ok := h.reviewListRequest(c)
+	if !ok {
+		return
+	}
+	req.ProductId = productId
+	req.Status = models.ReviewStatusApproved
+
+	resp, err := h.storage.Review().GetList(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Review.GetList!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("GetListProductReview Response!")
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetList Review godoc
+// @ID get_list_review
+// @Router /e_commerce/api/v1/review [GET]
+// @Security ApiKeyAuth
+// @Summary Get List Review
+// @Description Reviews of every status for moderation
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param status query string false "pending, approved or rejected"
+// @Param product_id query string false "product_id"
+// @Param customer_id query string false "customer_id"
+// @Param rating query integer false "only reviews with this rating"
+// @Param with_photos query boolean false "only reviews with photos"
+// @Param sort query string false "newest, rating_desc or rating_asc"
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Success 200 {object} models.ReviewGetListResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) GetListReview(c *gin.Context) {
+	req, ok := h.reviewListRequest(c)
+	if !ok {
+		return
+	}
+	req.Status = c.Query("status")
+	req.ProductId = c.Query("product_id")
+	req.CustomerId = c.Query("customer_id")
+
+	if req.Status != "" && !validReviewStatus(req.Status) {
+		c.JSON(http.StatusBadRequest, "INVALID STATUS")
+		return
+	}
+
+	for _, id := range []string{req.ProductId, req.CustomerId} {
+		if id != "" && !helper.IsValidUUID(id) {
+			c.JSON(http.StatusBadRequest, "invalid id")
+			return
+		}
+	}
+
+	resp, err := h.storage.Review().GetList(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Review.GetList!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("GetListReview Response!")
+	c.JSON(http.StatusOK, resp)
+}
+
+// Moderate Review godoc
+// @ID moderate_review
+// @Router /e_commerce/api/v1/review/{id}/status [PUT]
+// @Security ApiKeyAuth
+// @Summary Moderate Review
+// @Description Approve or reject a review, the product rating and review count follow the approved reviews
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param Review body models.ReviewModerate true "ModerateReviewRequest"
+// @Success 202 {object} models.Review "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Not found"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) ModerateReview(c *gin.Context) {
+	var (
+		id             = c.Param("id")
+		reviewModerate models.ReviewModerate
+	)
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	err := c.ShouldBindJSON(&reviewModerate)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "error Review Should Bind Json!")
+		c.JSON(http.StatusBadRequest, "Please, Enter Valid Data!")
+		return
+	}
+
+	if !validReviewStatus(reviewModerate.Status) {
+		c.JSON(http.StatusBadRequest, "status must be pending, approved or rejected")
+		return
+	}
+
+	before, err := h.storage.Review().GetByID(c.Request.Context(), &models.ReviewPrimaryKey{Id: id})
+	if err != nil {
+		h.handleReviewError(c, err, "storage.Review.GetByID!")
+		return
+	}
+
+	info, _ := getAuthInfo(c)
+	reviewModerate.Id = id
+	reviewModerate.ModeratedBy = info.UserID
+
+	err = h.storage.Review().Moderate(c.Request.Context(), &reviewModerate)
+	if err != nil {
+		h.handleReviewError(c, err, "storage.Review.Moderate!")
+		return
+	}
+
+	resp, err := h.storage.Review().GetByID(c.Request.Context(), &models.ReviewPrimaryKey{Id: id})
+	if err != nil {
+		h.handleReviewError(c, err, "storage.Review.GetByID!")
+		return
+	}
+
+	h.audit(c, models.AuditActionUpdate, models.AuditEntityReview, id, before, resp)
+
+	h.logger.Info("Moderate Review Successfully!")
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// Delete Review godoc
+// @ID delete_review
+// @Router /e_commerce/api/v1/review/{id} [DELETE]
+// @Security ApiKeyAuth
+// @Summary Delete Review
+// @Description Delete a review, the product rating and review count are recomputed
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 204 {object} Response{data=string} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Not found"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) DeleteReview(c *gin.Context) {
+	id := c.Param("id")
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is not valid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id!")
+		return
+	}
+
+	before, err := h.storage.Review().GetByID(c.Request.Context(), &models.ReviewPrimaryKey{Id: id})
+	if err != nil {
+		h.handleReviewError(c, err, "storage.Review.GetByID!")
+		return
+	}
+
+	err = h.storage.Review().Delete(c.Request.Context(), &models.ReviewPrimaryKey{Id: id})
+	if err != nil {
+		h.handleReviewError(c, err, "storage.Review.Delete!")
+		return
+	}
+
+	h.audit(c, models.AuditActionDelete, models.AuditEntityReview, id, before, nil)
+
+	h.logger.Info("Review Deleted Successfully!")
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// reviewListRequest reads the paging, filter and sort query parameters shared
+// by the review lists, it answers 400 itself when one is invalid
+func (h *handler) reviewListRequest(c *gin.Context) (*models.ReviewGetListRequest, bool) {
+	offset, err := h.getOffsetQuery(c.Query("offset"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListReview INVALID OFFSET!")
+		c.JSON(http.StatusBadRequest, "INVALID OFFSET")
+		return nil, false
+	}
+
+	limit, err := h.getLimitQuery(c.Query("limit"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListReview INVALID LIMIT!")
+		c.JSON(http.StatusBadRequest, "INVALID LIMIT")
+		return nil, false
+	}
+
+	req := &models.ReviewGetListRequest{
+		Offset: offset,
+		Limit:  limit,
+		Sort:   c.Query("sort"),
+	}
+
+	if rating := c.Query("rating"); rating != "" {
+		req.Rating, err = strconv.Atoi(rating)
+		if err != nil || req.Rating < 1 || req.Rating > 5 {
+			c.JSON(http.StatusBadRequest, "INVALID RATING")
+			return nil, false
+		}
+	}
+
+	if withPhotos := c.Query("with_photos"); withPhotos != "" {
+		req.WithPhotos, err = strconv.ParseBool(withPhotos)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, "INVALID WITH PHOTOS PARAM")
+			return nil, false
+		}
+	}
+
+	switch req.Sort {
+	case "", models.ReviewSortNewest, models.ReviewSortRatingDesc, models.ReviewSortRatingAsc:
+	default:
+		c.JSON(http.StatusBadRequest, "INVALID SORT")
+		return nil, false
+	}
+
+	return req, true
+}
+
+func (h *handler) handleReviewError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, storage.ErrProductNotFound),
+		errors.Is(err, storage.ErrReviewNotFound):
+		c.JSON(http.StatusNotFound, err.Error())
+	case errors.Is(err, storage.ErrReviewNotAllowed):
+		c.JSON(http.StatusForbidden, err.Error())
+	case errors.Is(err, storage.ErrReviewExists):
+		c.JSON(http.StatusConflict, err.Error())
+	default:
+		h.logger.Error(err.Error() + "  :  " + fallback)
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+	}
+}
+
+func validReview(review *models.ReviewCreate) (string, bool) {
+	if review.Rating < 1 || review.Rating > 5 {
+		return "rating must be from 1 to 5", false
+	}
+	if utf8.RuneCountInString(review.Text) > reviewMaxTextLength {
+		return "text can not be longer than " + strconv.Itoa(reviewMaxTextLength) + " characters", false
+	}
+	if len(review.Photos) > reviewMaxPhotos {
+		return "at most " + strconv.Itoa(reviewMaxPhotos) + " photos", false
+	}
+	for _, photo := range review.Photos {
+		if !strings.HasPrefix(photo, reviewPhotoPrefix) {
+			return "photos must be uploaded with /review/photos", false
+		}
+	}
+	return "", true
+}
+
+func validReviewStatus(status string) bool {
+	switch status {
+	case models.ReviewStatusPending, models.ReviewStatusApproved, models.ReviewStatusRejected:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"e-commerce/config"
+	"e-commerce/models"
+	"e-commerce/pkg/helper"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Create Role godoc
+// @ID create_role
+// @Router /e_commerce/api/v1/role [POST]
+// @Security ApiKeyAuth
+// @Summary Create Role
+// @Description Create admin role with a set of permissions
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Param Role body models.RoleCreate true "CreateRoleRequest"
+// @Success 201 {object} models.Role "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) CreateRole(c *gin.Context) {
+	var roleCreate models.RoleCreate
+
+	err := c.ShouldBindJSON(&roleCreate)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "error Role Should Bind Json!")
+		c.JSON(http.StatusBadRequest, "Please, Enter Valid Data!")
+		return
+	}
+
+	if roleCreate.Name == "" {
+		h.logger.Error("empty role name!")
+		c.JSON(http.StatusBadRequest, "name is required")
+		return
+	}
+
+	if permission, ok := validPermissions(roleCreate.Permissions); !ok {
+		h.logger.Error("unknown permission: " + permission)
+		c.JSON(http.StatusBadRequest, "unknown permission: "+permission)
+		return
+	}
+
+	resp, err := h.storage.Role().Create(c.Request.Context(), &roleCreate)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "error Role.Create")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionCreate, models.AuditEntityRole, resp.Id, nil, resp)
+
+	h.logger.Info("Create Role Successfully!!")
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetByID Role godoc
+// @ID get_by_id_role
+// @Router /e_commerce/api/v1/role/{id} [GET]
+// @Security ApiKeyAuth
+// @Summary Get By ID Role
+// @Description Get By ID Role
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} models.Role "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) GetByIdRole(c *gin.Context) {
+	id := c.Param("id")
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is valid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	resp, err := h.storage.Role().GetByID(c.Request.Context(), &models.RolePrimaryKey{Id: id})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			c.JSON(http.StatusNotFound, "role not found")
+			return
+		}
+		h.logger.Error(err.Error() + "  :  " + "storage.Role.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("GetByID Role Response!")
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetList Role godoc
+// @ID get_list_role
+// @Router /e_commerce/api/v1/role [GET]
+// @Security ApiKeyAuth
+// @Summary Get List Role
+// @Description Get List Role
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Param offset query string false "offset"
+// @Param limit query string false "limit"
+// @Success 200 {object} models.RoleGetListResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) GetListRole(c *gin.Context) {
+	offset, err := h.getOffsetQuery(c.Query("offset"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListRole INVALID OFFSET!")
+		c.JSON(http.StatusBadRequest, "INVALID OFFSET")
+		return
+	}
+
+	limit, err := h.getLimitQuery(c.Query("limit"))
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "GetListRole INVALID LIMIT!")
+		c.JSON(http.StatusBadRequest, "INVALID LIMIT")
+		return
+	}
+
+	resp, err := h.storage.Role().GetList(c.Request.Context(), &models.RoleGetListRequest{
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Role.GetList!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("GetListRole Response!")
+	c.JSON(http.StatusOK, resp)
+}
+
+// Update Role godoc
+// @ID update_role
+// @Router /e_commerce/api/v1/role/{id} [PUT]
+// @Security ApiKeyAuth
+// @Summary Update Role
+// @Description Rename the role and replace its permissions
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param Role body models.RoleUpdate true "UpdateRoleRequest"
+// @Success 202 {object} models.Role "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) UpdateRole(c *gin.Context) {
+	var (
+		id         = c.Param("id")
+		roleUpdate models.RoleUpdate
+	)
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	err := c.ShouldBindJSON(&roleUpdate)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "error Role Should Bind Json!")
+		c.JSON(http.StatusBadRequest, "Please, Enter Valid Data!")
+		return
+	}
+
+	if roleUpdate.Name == "" {
+		h.logger.Error("empty role name!")
+		c.JSON(http.StatusBadRequest, "name is required")
+		return
+	}
+
+	if permission, ok := validPermissions(roleUpdate.Permissions); !ok {
+		h.logger.Error("unknown permission: " + permission)
+		c.JSON(http.StatusBadRequest, "unknown permission: "+permission)
+		return
+	}
+
+	roleUpdate.Id = id
+
+	before, err := h.storage.Role().GetByID(c.Request.Context(), &models.RolePrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Role.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	rowsAffected, err := h.storage.Role().Update(c.Request.Context(), &roleUpdate)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Role.Update!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	if rowsAffected <= 0 {
+		h.logger.Error("storage.Role.Update!")
+		c.JSON(http.StatusBadRequest, "Unable to update data. Please try again later!")
+		return
+	}
+
+	resp, err := h.storage.Role().GetByID(c.Request.Context(), &models.RolePrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Role.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionUpdate, models.AuditEntityRole, id, before, resp)
+
+	h.logger.Info("Update Role Successfully!")
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// Update Admin Role godoc
+// @ID update_admin_role
+// @Router /e_commerce/api/v1/admin/{id}/role [PUT]
+// @Security ApiKeyAuth
+// @Summary Assign Admin Role
+// @Description Assign a role to the admin, an empty role_id removes it. Takes effect on the admin's next login or token refresh
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param Role body models.AdminRoleUpdate true "AdminRoleUpdateRequest"
+// @Success 202 {object} models.Admin "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) UpdateAdminRole(c *gin.Context) {
+	var (
+		id         = c.Param("id")
+		roleUpdate models.AdminRoleUpdate
+	)
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	err := c.ShouldBindJSON(&roleUpdate)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "error Admin Role Should Bind Json!")
+		c.JSON(http.StatusBadRequest, "Please, Enter Valid Data!")
+		return
+	}
+
+	if roleUpdate.RoleId != "" && !helper.IsValidUUID(roleUpdate.RoleId) {
+		h.logger.Error("is invalid role uuid!")
+		c.JSON(http.StatusBadRequest, "invalid role_id")
+		return
+	}
+
+	if info, ok := getAuthInfo(c); ok && info.UserID == id {
+		h.logger.Error("admin tried to change own role!")
+		c.JSON(http.StatusBadRequest, "you can not change your own role")
+		return
+	}
+
+	roleUpdate.AdminId = id
+
+	before, err := h.storage.Admin().GetByID(c.Request.Context(), &models.AdminPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Admin.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	rowsAffected, err := h.storage.Admin().UpdateRole(c.Request.Context(), &roleUpdate)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Admin.UpdateRole!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	if rowsAffected <= 0 {
+		h.logger.Error("storage.Admin.UpdateRole!")
+		c.JSON(http.StatusBadRequest, "Unable to update data. Please try again later!")
+		return
+	}
+
+	resp, err := h.storage.Admin().GetByID(c.Request.Context(), &models.AdminPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Admin.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionUpdate, models.AuditEntityAdmin, id, before, resp)
+
+	h.logger.Info("Update Admin Role Successfully!")
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// validPermissions returns the first unknown permission, if any.
+func validPermissions(permissions []string) (string, bool) {
+	for _, permission := range permissions {
+		if !config.IsValidPermission(permission) {
+			return permission, false
+		}
+	}
+	return "", true
+}
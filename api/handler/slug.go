@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"e-commerce/models"
+	"e-commerce/pkg/helper"
+	"e-commerce/storage"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// slugOrId reads the id path parameter, which is either the entity's id or
+// its slug.
+func slugOrId(c *gin.Context) (id, slug string) {
+	param := c.Param("id")
+	if helper.IsValidUUID(param) {
+		return param, ""
+	}
+	return "", param
+}
+
+// redirectSlug answers with a permanent redirect to the entity's current
+// slug when the requested one is a slug it had before being renamed.
+func (h *handler) redirectSlug(c *gin.Context, entityType, slug string) bool {
+	current, err := h.storage.Slug().GetRedirect(c.Request.Context(), &models.SlugRedirectRequest{
+		EntityType: entityType,
+		Slug:       slug,
+	})
+	if err != nil {
+		if !errors.Is(err, storage.ErrSlugNotFound) {
+			h.logger.Error(err.Error() + "  :  " + "storage.Slug.GetRedirect!")
+		}
+		return false
+	}
+
+	segments := strings.Split(c.Request.URL.Path, "/")
+	for i, segment := range segments {
+		if segment == slug {
+			segments[i] = current
+			break
+		}
+	}
+
+	location := strings.Join(segments, "/")
+	if c.Request.URL.RawQuery != "" {
+		location += "?" + c.Request.URL.RawQuery
+	}
+
+	c.Redirect(http.StatusMovedPermanently, location)
+	return true
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"e-commerce/models"
+	"e-commerce/pkg/helper"
+	"e-commerce/storage"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdjustStock Color godoc
+// @ID adjust_stock_color
+// @Router /e_commerce/api/v1/color/{id}/stock [POST]
+// @Security ApiKeyAuth
+// @Summary Adjust Color Stock
+// @Description Add delta to the stock of the color, a restock adds, a damage takes away and a correction goes either way. A return adds back what the order given in order_id bought. Checkouts running meanwhile are kept, the stock never goes below zero
+// @Tags Color
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param Stock body models.StockAdjustment true "StockAdjustmentRequest"
+// @Success 200 {object} models.StockAdjustmentResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Color not found"
+// @Response 409 {object} Response{data=string} "Stock would go below zero or the return exceeds the order"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) AdjustColorStock(c *gin.Context) {
+	h.adjustStock(c, models.AuditEntityColor, h.storage.Color().AdjustStock)
+}
+
+// AdjustStock Variant godoc
+// @ID adjust_stock_variant
+// @Router /e_commerce/api/v1/variant/{id}/stock [POST]
+// @Security ApiKeyAuth
+// @Summary Adjust Variant Stock
+// @Description Add delta to the stock of the variant, a restock adds, a damage takes away and a correction goes either way. A return adds back what the order given in order_id bought. Checkouts running meanwhile are kept, the stock never goes below zero
+// @Tags Variant
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param Stock body models.StockAdjustment true "StockAdjustmentRequest"
+// @Success 200 {object} models.StockAdjustmentResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Variant not found"
+// @Response 409 {object} Response{data=string} "Stock would go below zero or the return exceeds the order"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) AdjustVariantStock(c *gin.Context) {
+	h.adjustStock(c, models.AuditEntityVariant, h.storage.Variant().AdjustStock)
+}
+
+func (h *handler) adjustStock(c *gin.Context, entityType string, adjust func(ctx context.Context, req *models.StockAdjustment) (*models.StockAdjustmentResponse, error)) {
+	var (
+		id         = c.Param("id")
+		adjustment models.StockAdjustment
+	)
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	err := c.ShouldBindJSON(&adjustment)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "error Stock Should Bind Json!")
+		c.JSON(http.StatusBadRequest, "Please, Enter Valid Data!")
+		return
+	}
+
+	if msg, ok := validStockAdjustment(&adjustment); !ok {
+		h.logger.Error("invalid stock adjustment: " + msg)
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
+	info, _ := getAuthInfo(c)
+	adjustment.Id = id
+	adjustment.ChangedBy = info.UserID
+
+	resp, err := adjust(c.Request.Context(), &adjustment)
+	switch {
+	case errors.Is(err, storage.ErrColorNotFound), errors.Is(err, storage.ErrVariantNotFound):
+		c.JSON(http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, storage.ErrStockNegative), errors.Is(err, storage.ErrReturnExceedsOrder):
+		c.JSON(http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		h.logger.Error(err.Error() + "  :  " + "storage.AdjustStock!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionStock, entityType, id, nil, resp)
+
+	h.logger.Info("Stock Adjusted Successfully!")
+	c.JSON(http.StatusOK, resp)
+}
+
+func validStockAdjustment(req *models.StockAdjustment) (string, bool) {
+	switch {
+	case req.Delta == 0:
+		return "delta can not be zero", false
+	case req.Reason == models.StockReasonRestock && req.Delta < 0:
+		return "a restock adds to the stock, delta must be positive", false
+	case req.Reason == models.StockReasonDamage && req.Delta > 0:
+		return "a damage takes from the stock, delta must be negative", false
+	case req.Reason == models.StockReasonReturn && req.Delta < 0:
+		return "a return adds to the stock, delta must be positive", false
+	case req.Reason == models.StockReasonReturn && !helper.IsValidUUID(req.OrderId):
+		return "a return needs the order_id of the order it comes from", false
+	case req.Reason != models.StockReasonReturn && req.OrderId != "":
+		return "only a return comes from an order", false
+	case req.Reason != models.StockReasonRestock && req.Reason != models.StockReasonDamage &&
+		req.Reason != models.StockReasonCorrection && req.Reason != models.StockReasonReturn:
+		return "reason must be restock, damage, correction or return", false
+	}
+	return "", true
+}
@@ -9,6 +9,7 @@ import (
 // upload Multiple Files godoc
 // @ID upload_multiple_files
 // @Router /e_commerce/api/v1/upload-files [POST]
+// @Security ApiKeyAuth
 // @Summary Upload Multiple Files
 // @Description Upload Multiple Files
 // @Tags Upload File
@@ -37,6 +38,7 @@ func (h *handler) UploadFiles(c *gin.Context) {
 // delete file godoc
 // @ID delete_file
 // @Router /e_commerce/api/v1/delete-file [DELETE]
+// @Security ApiKeyAuth
 // @Summary Delete File
 // @Description Delete File
 // @Tags Upload File
@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"e-commerce/models"
+	"e-commerce/pkg/helper"
+	"e-commerce/storage"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Create Variant godoc
+// @ID create_variant
+// @Router /e_commerce/api/v1/product/{id}/variant [POST]
+// @Security ApiKeyAuth
+// @Summary Create Variant
+// @Description Add a variant with its own options, sku, price, stock and images to the product. An empty sku is generated from the product slug and the option values
+// @Tags Variant
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Param Variant body models.ProductVariantCreate true "CreateVariantRequest"
+// @Success 201 {object} models.ProductVariant "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 404 {object} Response{data=string} "Product not found"
+// @Response 409 {object} Response{data=string} "Options or sku already used"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) CreateVariant(c *gin.Context) {
+	var (
+		productId     = c.Param("id")
+		variantCreate models.ProductVariantCreate
+	)
+
+	if !helper.IsValidUUID(productId) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	err := c.ShouldBindJSON(&variantCreate)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "error Variant Should Bind Json!")
+		c.JSON(http.StatusBadRequest, "Please, Enter Valid Data!")
+		return
+	}
+
+	if msg, ok := validVariant(variantCreate.Options, variantCreate.Price, variantCreate.ColorId); !ok {
+		h.logger.Error("invalid variant: " + msg)
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
+	if variantCreate.Count < 0 {
+		h.logger.Error("invalid variant: count can not be negative")
+		c.JSON(http.StatusBadRequest, "count can not be negative")
+		return
+	}
+
+	variantCreate.ProductId = productId
+
+	info, _ := getAuthInfo(c)
+	variantCreate.PriceChangedBy = info.UserID
+
+	resp, err := h.storage.Variant().Create(c.Request.Context(), &variantCreate)
+	if err != nil {
+		h.handleVariantError(c, err, "storage.Variant.Create!")
+		return
+	}
+
+	h.audit(c, models.AuditActionCreate, models.AuditEntityVariant, resp.Id, nil, resp)
+
+	h.logger.Info("Create Variant Successfully!")
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetList Variant godoc
+// @ID get_list_variant
+// @Router /e_commerce/api/v1/product/{id}/variant [GET]
+// @Summary Get List Variant
+// @Description Variants of the product with their effective prices and stock
+// @Tags Variant
+// @Accept json
+// @Produce json
+// @Param id path string true "product id"
+// @Success 200 {object} models.ProductVariantGetListResponse "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) GetListVariant(c *gin.Context) {
+	productId := c.Param("id")
+
+	if !helper.IsValidUUID(productId) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	resp, err := h.storage.Variant().GetList(c.Request.Context(), &models.ProductVariantGetListRequest{ProductId: productId})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Variant.GetList!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.logger.Info("GetListVariant Response!")
+	c.JSON(http.StatusOK, resp)
+}
+
+// Update Variant godoc
+// @ID update_variant
+// @Router /e_commerce/api/v1/variant/{id} [PUT]
+// @Security ApiKeyAuth
+// @Summary Update Variant
+// @Description Replace the variant's options, price and images, an empty sku keeps the current one. The stock is changed by stock adjustments only
+// @Tags Variant
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param Variant body models.ProductVariantUpdate true "UpdateVariantRequest"
+// @Success 202 {object} models.ProductVariant "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 409 {object} Response{data=string} "Options or sku already used"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) UpdateVariant(c *gin.Context) {
+	var (
+		id            = c.Param("id")
+		variantUpdate models.ProductVariantUpdate
+	)
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is invalid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	err := c.ShouldBindJSON(&variantUpdate)
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "error Variant Should Bind Json!")
+		c.JSON(http.StatusBadRequest, "Please, Enter Valid Data!")
+		return
+	}
+
+	if msg, ok := validVariant(variantUpdate.Options, variantUpdate.Price, variantUpdate.ColorId); !ok {
+		h.logger.Error("invalid variant: " + msg)
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
+	variantUpdate.Id = id
+
+	info, _ := getAuthInfo(c)
+	variantUpdate.PriceChangedBy = info.UserID
+
+	before, err := h.storage.Variant().GetByID(c.Request.Context(), &models.ProductVariantPrimaryKey{Id: id})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			c.JSON(http.StatusNotFound, "variant not found")
+			return
+		}
+		h.logger.Error(err.Error() + "  :  " + "storage.Variant.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	rowsAffected, err := h.storage.Variant().Update(c.Request.Context(), &variantUpdate)
+	if err != nil {
+		h.handleVariantError(c, err, "storage.Variant.Update!")
+		return
+	}
+
+	if rowsAffected <= 0 {
+		h.logger.Error("storage.Variant.Update!")
+		c.JSON(http.StatusBadRequest, "Unable to update data. Please try again later!")
+		return
+	}
+
+	resp, err := h.storage.Variant().GetByID(c.Request.Context(), &models.ProductVariantPrimaryKey{Id: id})
+	if err != nil {
+		h.logger.Error(err.Error() + "  :  " + "storage.Variant.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	h.audit(c, models.AuditActionUpdate, models.AuditEntityVariant, id, before, resp)
+
+	h.logger.Info("Update Variant Successfully!")
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// Delete Variant godoc
+// @ID delete_variant
+// @Router /e_commerce/api/v1/variant/{id} [DELETE]
+// @Security ApiKeyAuth
+// @Summary Delete Variant
+// @Description Delete a variant that no order refers to
+// @Tags Variant
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 204 {object} Response{data=string} "Success Request"
+// @Response 400 {object} Response{data=string} "Bad Request"
+// @Response 409 {object} Response{data=string} "Variant is used by orders"
+// @Failure 500 {object} Response{data=string} "Server error"
+func (h *handler) DeleteVariant(c *gin.Context) {
+	id := c.Param("id")
+
+	if !helper.IsValidUUID(id) {
+		h.logger.Error("is not valid uuid!")
+		c.JSON(http.StatusBadRequest, "invalid id!")
+		return
+	}
+
+	before, err := h.storage.Variant().GetByID(c.Request.Context(), &models.ProductVariantPrimaryKey{Id: id})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			c.JSON(http.StatusNotFound, "variant not found")
+			return
+		}
+		h.logger.Error(err.Error() + "  :  " + "storage.Variant.GetByID!")
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+		return
+	}
+
+	err = h.storage.Variant().Delete(c.Request.Context(), &models.ProductVariantPrimaryKey{Id: id})
+	if err != nil {
+		h.handleVariantError(c, err, "storage.Variant.Delete!")
+		return
+	}
+
+	h.audit(c, models.AuditActionDelete, models.AuditEntityVariant, id, before, nil)
+
+	h.logger.Info("Variant Deleted Successfully!")
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *handler) handleVariantError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, storage.ErrProductNotFound):
+		c.JSON(http.StatusNotFound, err.Error())
+	case errors.Is(err, storage.ErrVariantExists),
+		errors.Is(err, storage.ErrVariantSkuTaken),
+		errors.Is(err, storage.ErrVariantInUse):
+		c.JSON(http.StatusConflict, err.Error())
+	default:
+		h.logger.Error(err.Error() + "  :  " + fallback)
+		c.JSON(http.StatusInternalServerError, "Server Error!")
+	}
+}
+
+func validVariant(options map[string]string, price *float64, colorId string) (string, bool) {
+	if len(options) == 0 {
+		return "options are required", false
+	}
+	if price != nil && *price < 0 {
+		return "price can not be negative", false
+	}
+	if colorId != "" && !helper.IsValidUUID(colorId) {
+		return "invalid color_id", false
+	}
+	return "", true
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"e-commerce/api"
 	"e-commerce/config"
+	"e-commerce/pkg/ratelimit"
+	"e-commerce/pkg/sms"
 	"e-commerce/service"
 	"fmt"
 	"net/http"
@@ -64,10 +67,23 @@ func main() {
 	r := gin.New()
 	r.Use(gin.Recovery(), gin.Logger())
 
+	smsSender, err := sms.New(cfg, log)
+	if err != nil {
+		panic("sms sender: " + err.Error())
+	}
+
 	newRedis := redis.New(cfg)
-	services := service.New(pgconn, log, newRedis)
+	services := service.New(pgconn, log, newRedis, smsSender)
+
+	var limiter ratelimit.Limiter = ratelimit.NewMemoryLimiter()
+	if cfg.RateLimitStore == "redis" {
+		limiter = ratelimit.NewRedisLimiter(newRedis, log)
+	}
+
+	api.NewApi(r, &cfg, pgconn, log, services, limiter)
 
-	api.NewApi(r, &cfg, pgconn, log, services)
+	go service.NewDiscountScheduler(pgconn, log, cfg.DiscountSchedulerInterval).Run(context.Background())
+	go service.NewAffinityJob(pgconn, log, cfg.AffinityRefreshInterval).Run(context.Background())
 
 	// Yangi qo'shilgan: Keep-alive funksiyasini ishga tushirish
 	go keepAlive(&cfg)
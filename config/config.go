@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
@@ -47,6 +48,27 @@ type Config struct {
 
 	AuthServiceHost string
 	AuthGRPCPort    string
+
+	SmsProvider string
+	SmsLogFile  string
+
+	EskizEmail    string
+	EskizPassword string
+	EskizFrom     string
+
+	TwilioAccountSID  string
+	TwilioAuthToken   string
+	TwilioPhoneNumber string
+
+	RateLimitStore        string
+	RateLimitSmsIP        string
+	RateLimitSmsPhone     string
+	RateLimitLoginIP      string
+	RateLimitLoginAccount string
+	RateLimitUser         string
+
+	DiscountSchedulerInterval time.Duration
+	AffinityRefreshInterval   time.Duration
 }
 
 // Load ...
@@ -85,6 +107,33 @@ func Load() Config {
 
 	config.SecretKey = cast.ToString(getOrReturnDefaultValue("SECRET_KEY", "NVWmbbPGxh7gy1igr4irX3qaAYun9nxi"))
 
+	// eskiz, twilio or log; log only writes messages to the logger and SMS_LOG_FILE.
+	// The eskiz and twilio credentials have no defaults, they come from the environment.
+	config.SmsProvider = cast.ToString(getOrReturnDefaultValue("SMS_PROVIDER", "log"))
+	config.SmsLogFile = cast.ToString(getOrReturnDefaultValue("SMS_LOG_FILE", ""))
+
+	config.EskizEmail = cast.ToString(getOrReturnDefaultValue("ESKIZ_EMAIL", ""))
+	config.EskizPassword = cast.ToString(getOrReturnDefaultValue("ESKIZ_PASSWORD", ""))
+	config.EskizFrom = cast.ToString(getOrReturnDefaultValue("ESKIZ_FROM", "4546"))
+
+	config.TwilioAccountSID = cast.ToString(getOrReturnDefaultValue("TWILIO_ACCOUNT_SID", ""))
+	config.TwilioAuthToken = cast.ToString(getOrReturnDefaultValue("TWILIO_AUTH_TOKEN", ""))
+	config.TwilioPhoneNumber = cast.ToString(getOrReturnDefaultValue("TWILIO_PHONE_NUMBER", ""))
+
+	// redis or memory; policies are written as <limit>/<window>
+	config.RateLimitStore = cast.ToString(getOrReturnDefaultValue("RATE_LIMIT_STORE", "redis"))
+	config.RateLimitSmsIP = cast.ToString(getOrReturnDefaultValue("RATE_LIMIT_SMS_IP", "20/1h"))
+	config.RateLimitSmsPhone = cast.ToString(getOrReturnDefaultValue("RATE_LIMIT_SMS_PHONE", "3/10m"))
+	config.RateLimitLoginIP = cast.ToString(getOrReturnDefaultValue("RATE_LIMIT_LOGIN_IP", "20/1m"))
+	config.RateLimitLoginAccount = cast.ToString(getOrReturnDefaultValue("RATE_LIMIT_LOGIN_ACCOUNT", "5/15m"))
+	config.RateLimitUser = cast.ToString(getOrReturnDefaultValue("RATE_LIMIT_USER", "120/1m"))
+
+	// how often scheduled discounts are activated and expired
+	config.DiscountSchedulerInterval = cast.ToDuration(getOrReturnDefaultValue("DISCOUNT_SCHEDULER_INTERVAL", "1m"))
+
+	// how often the bought together recommendations are recomputed from the orders
+	config.AffinityRefreshInterval = cast.ToDuration(getOrReturnDefaultValue("AFFINITY_REFRESH_INTERVAL", "1h"))
+
 	return config
 }
 
@@ -1,9 +1,6 @@
 package config
 
 const (
-	AccountSID          = "ACc39725a654edb7264186eca22f221a47"
-	AuthToken           = "0f95c1f4de45c8e8fed7a68302acea7d"
-	TwilioPhoneNumber   = "+13026045203"
 	RedisAddr           = "localhost:6379" // Redis server address
 	CUSTOMER_ROLE       = "customer"
 	ADMIN_ROLE          = "admin"
@@ -0,0 +1,54 @@
+package config
+
+// Admin permissions. Roles stored in the admin_role table are granted a
+// subset of these in admin_role_permission.
+const (
+	PERMISSION_ADMIN_MANAGE        = "admin:manage"
+	PERMISSION_PRODUCT_WRITE       = "product:write"
+	PERMISSION_COLOR_WRITE         = "color:write"
+	PERMISSION_CATEGORY_WRITE      = "category:write"
+	PERMISSION_BRAND_WRITE         = "brand:write"
+	PERMISSION_BANNER_WRITE        = "banner:write"
+	PERMISSION_LOCATION_WRITE      = "location:write"
+	PERMISSION_FILE_UPLOAD         = "file:upload"
+	PERMISSION_ORDER_READ          = "order:read"
+	PERMISSION_ORDER_UPDATE_STATUS = "order:update_status"
+	PERMISSION_ORDER_DELETE        = "order:delete"
+	PERMISSION_CUSTOMER_READ       = "customer:read"
+	PERMISSION_CUSTOMER_WRITE      = "customer:write"
+	PERMISSION_AUDIT_READ          = "audit:read"
+	PERMISSION_PRICE_HISTORY_READ  = "price_history:read"
+	PERMISSION_REVIEW_MODERATE     = "review:moderate"
+	PERMISSION_INVENTORY_READ      = "inventory:read"
+)
+
+// Permissions lists every permission a role can be granted.
+var Permissions = []string{
+	PERMISSION_ADMIN_MANAGE,
+	PERMISSION_PRODUCT_WRITE,
+	PERMISSION_COLOR_WRITE,
+	PERMISSION_CATEGORY_WRITE,
+	PERMISSION_BRAND_WRITE,
+	PERMISSION_BANNER_WRITE,
+	PERMISSION_LOCATION_WRITE,
+	PERMISSION_FILE_UPLOAD,
+	PERMISSION_ORDER_READ,
+	PERMISSION_ORDER_UPDATE_STATUS,
+	PERMISSION_ORDER_DELETE,
+	PERMISSION_CUSTOMER_READ,
+	PERMISSION_CUSTOMER_WRITE,
+	PERMISSION_AUDIT_READ,
+	PERMISSION_PRICE_HISTORY_READ,
+	PERMISSION_REVIEW_MODERATE,
+	PERMISSION_INVENTORY_READ,
+}
+
+// IsValidPermission reports whether p is a known permission.
+func IsValidPermission(p string) bool {
+	for _, permission := range Permissions {
+		if permission == p {
+			return true
+		}
+	}
+	return false
+}
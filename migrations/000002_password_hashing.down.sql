@@ -0,0 +1,5 @@
+ALTER TABLE "customer" ALTER COLUMN "password" TYPE VARCHAR(100);
+ALTER TABLE "admin" ALTER COLUMN "password" TYPE VARCHAR(100);
+
+UPDATE "customer" SET "password" = '' WHERE "password" IS NULL;
+ALTER TABLE "customer" ALTER COLUMN "password" SET NOT NULL;
@@ -0,0 +1,5 @@
+-- customers registered by OTP have no password
+ALTER TABLE "customer" ALTER COLUMN "password" DROP NOT NULL;
+
+ALTER TABLE "admin" ALTER COLUMN "password" TYPE VARCHAR(255);
+ALTER TABLE "customer" ALTER COLUMN "password" TYPE VARCHAR(255);
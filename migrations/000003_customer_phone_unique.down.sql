@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "customer_phone_number_key";
@@ -0,0 +1 @@
+CREATE UNIQUE INDEX IF NOT EXISTS "customer_phone_number_key" ON "customer" ("phone_number");
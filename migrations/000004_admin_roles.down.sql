@@ -0,0 +1,4 @@
+ALTER TABLE "admin" DROP COLUMN IF EXISTS "role_id";
+
+DROP TABLE IF EXISTS "admin_role_permission";
+DROP TABLE IF EXISTS "admin_role";
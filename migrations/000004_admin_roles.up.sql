@@ -0,0 +1,53 @@
+CREATE TABLE IF NOT EXISTS "admin_role" (
+    "id" UUID PRIMARY KEY,
+    "name" VARCHAR(100) NOT NULL UNIQUE,
+    "description" VARCHAR(255),
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS "admin_role_permission" (
+    "role_id" UUID NOT NULL REFERENCES "admin_role" ("id") ON DELETE CASCADE,
+    "permission" VARCHAR(100) NOT NULL,
+    PRIMARY KEY ("role_id", "permission")
+);
+
+ALTER TABLE "admin" ADD COLUMN IF NOT EXISTS "role_id" UUID REFERENCES "admin_role" ("id");
+
+INSERT INTO "admin_role" ("id", "name", "description") VALUES
+    ('5d1f2c8e-3a4b-4c6d-9e7f-000000000001', 'superadmin', 'Full access, manages admins and roles'),
+    ('5d1f2c8e-3a4b-4c6d-9e7f-000000000002', 'catalog_manager', 'Manages products, colors, categories and brands'),
+    ('5d1f2c8e-3a4b-4c6d-9e7f-000000000003', 'order_operator', 'Processes orders'),
+    ('5d1f2c8e-3a4b-4c6d-9e7f-000000000004', 'content_editor', 'Manages banners and locations')
+ON CONFLICT ("name") DO NOTHING;
+
+INSERT INTO "admin_role_permission" ("role_id", "permission")
+SELECT '5d1f2c8e-3a4b-4c6d-9e7f-000000000001', p FROM unnest(ARRAY[
+    'admin:manage',
+    'product:write', 'color:write', 'category:write', 'brand:write',
+    'banner:write', 'location:write', 'file:upload',
+    'order:read', 'order:update_status', 'order:delete',
+    'customer:read', 'customer:write'
+]) AS p
+ON CONFLICT DO NOTHING;
+
+INSERT INTO "admin_role_permission" ("role_id", "permission")
+SELECT '5d1f2c8e-3a4b-4c6d-9e7f-000000000002', p FROM unnest(ARRAY[
+    'product:write', 'color:write', 'category:write', 'brand:write', 'file:upload'
+]) AS p
+ON CONFLICT DO NOTHING;
+
+INSERT INTO "admin_role_permission" ("role_id", "permission")
+SELECT '5d1f2c8e-3a4b-4c6d-9e7f-000000000003', p FROM unnest(ARRAY[
+    'order:read', 'order:update_status', 'customer:read'
+]) AS p
+ON CONFLICT DO NOTHING;
+
+INSERT INTO "admin_role_permission" ("role_id", "permission")
+SELECT '5d1f2c8e-3a4b-4c6d-9e7f-000000000004', p FROM unnest(ARRAY[
+    'banner:write', 'location:write', 'file:upload'
+]) AS p
+ON CONFLICT DO NOTHING;
+
+-- admins created before roles existed could do everything, keep it that way
+UPDATE "admin" SET "role_id" = '5d1f2c8e-3a4b-4c6d-9e7f-000000000001' WHERE "role_id" IS NULL;
@@ -0,0 +1,3 @@
+DELETE FROM "admin_role_permission" WHERE "permission" = 'audit:read';
+
+DROP TABLE IF EXISTS "audit_log";
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS "audit_log" (
+    "id" UUID PRIMARY KEY,
+    "actor_id" UUID,
+    "actor_role" VARCHAR(50) NOT NULL,
+    "action" VARCHAR(50) NOT NULL,
+    "entity_type" VARCHAR(50) NOT NULL,
+    "entity_id" VARCHAR(100) NOT NULL,
+    "before" JSONB,
+    "after" JSONB,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "audit_log_entity_idx" ON "audit_log" ("entity_type", "entity_id");
+CREATE INDEX IF NOT EXISTS "audit_log_actor_idx" ON "audit_log" ("actor_id");
+CREATE INDEX IF NOT EXISTS "audit_log_created_at_idx" ON "audit_log" ("created_at");
+
+INSERT INTO "admin_role_permission" ("role_id", "permission")
+SELECT "id", 'audit:read' FROM "admin_role" WHERE "name" = 'superadmin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS "product_name_trgm_idx";
+DROP INDEX IF EXISTS "product_search_vector_idx";
+
+DROP TRIGGER IF EXISTS "category_product_search_trigger" ON "category";
+DROP TRIGGER IF EXISTS "brand_product_search_trigger" ON "brand";
+DROP TRIGGER IF EXISTS "product_search_vector_trigger" ON "product";
+
+DROP FUNCTION IF EXISTS product_search_vector_refresh_category();
+DROP FUNCTION IF EXISTS product_search_vector_refresh_brand();
+DROP FUNCTION IF EXISTS product_search_vector_update();
+
+ALTER TABLE "product" DROP COLUMN IF EXISTS "search_vector";
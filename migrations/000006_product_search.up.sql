@@ -0,0 +1,53 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE "product" ADD COLUMN IF NOT EXISTS "search_vector" TSVECTOR;
+
+-- name weighs most, then brand and category names, then the description;
+-- 'simple' keeps uzbek and russian words as they are
+CREATE OR REPLACE FUNCTION product_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('simple', COALESCE(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE((SELECT "name" FROM "brand" WHERE "id" = NEW.brand_id), '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE((SELECT "name" FROM "category" WHERE "id" = NEW.category_id), '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE(NEW.description, '')), 'C');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "product_search_vector_trigger" ON "product";
+CREATE TRIGGER "product_search_vector_trigger"
+    BEFORE INSERT OR UPDATE OF "name", "description", "brand_id", "category_id" ON "product"
+    FOR EACH ROW EXECUTE FUNCTION product_search_vector_update();
+
+-- renaming a brand or category refreshes the vectors of its products
+CREATE OR REPLACE FUNCTION product_search_vector_refresh_brand() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE "product" SET "brand_id" = "brand_id" WHERE "brand_id" = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "brand_product_search_trigger" ON "brand";
+CREATE TRIGGER "brand_product_search_trigger"
+    AFTER UPDATE OF "name" ON "brand"
+    FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+    EXECUTE FUNCTION product_search_vector_refresh_brand();
+
+CREATE OR REPLACE FUNCTION product_search_vector_refresh_category() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE "product" SET "category_id" = "category_id" WHERE "category_id" = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "category_product_search_trigger" ON "category";
+CREATE TRIGGER "category_product_search_trigger"
+    AFTER UPDATE OF "name" ON "category"
+    FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+    EXECUTE FUNCTION product_search_vector_refresh_category();
+
+UPDATE "product" SET "name" = "name";
+
+CREATE INDEX IF NOT EXISTS "product_search_vector_idx" ON "product" USING GIN ("search_vector");
+CREATE INDEX IF NOT EXISTS "product_name_trgm_idx" ON "product" USING GIN (lower("name") gin_trgm_ops);
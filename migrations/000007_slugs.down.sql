@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "slug_history";
+
+DROP INDEX IF EXISTS "brand_slug_key";
+DROP INDEX IF EXISTS "category_slug_key";
+DROP INDEX IF EXISTS "product_slug_key";
+
+ALTER TABLE "brand" DROP COLUMN IF EXISTS "slug";
+ALTER TABLE "category" DROP COLUMN IF EXISTS "slug";
+ALTER TABLE "product" DROP COLUMN IF EXISTS "slug";
@@ -0,0 +1,71 @@
+-- the application generates slugs from now on, this copy of its
+-- transliteration only backfills the existing rows
+CREATE FUNCTION pg_temp.slugify(name TEXT) RETURNS TEXT AS $$
+DECLARE
+    result TEXT := lower(COALESCE(name, ''));
+BEGIN
+    result := regexp_replace(result, '[''`‘’ʻʼ]', '', 'g');
+    result := replace(replace(replace(replace(replace(replace(replace(result,
+        'ё', 'yo'), 'ц', 'ts'), 'ч', 'ch'), 'ш', 'sh'), 'щ', 'sh'), 'ю', 'yu'), 'я', 'ya');
+    result := translate(result, 'абвгдежзийклмнопрстуфхыэўқғҳъь', 'abvgdejziyklmnoprstufxieoqgh');
+    RETURN trim(BOTH '-' FROM regexp_replace(result, '[^a-z0-9]+', '-', 'g'));
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE "product" ADD COLUMN IF NOT EXISTS "slug" VARCHAR(255);
+ALTER TABLE "category" ADD COLUMN IF NOT EXISTS "slug" VARCHAR(255);
+ALTER TABLE "brand" ADD COLUMN IF NOT EXISTS "slug" VARCHAR(255);
+
+-- the first row of a name gets the plain slug, so "AirPods 2" keeps airpods-2.
+-- The other rows get -2, -3 and so on like uniqueSlug does, skipping the
+-- slugs already given to any row.
+CREATE FUNCTION pg_temp.backfill_slugs(entity TEXT) RETURNS VOID AS $$
+DECLARE
+    r RECORD;
+    base TEXT;
+    candidate TEXT;
+    n INT;
+    taken BOOLEAN;
+BEGIN
+    EXECUTE format('
+        UPDATE %I t SET slug = s.base FROM (
+            SELECT id, base, row_number() OVER (PARTITION BY base ORDER BY created_at, id) AS n
+            FROM (SELECT id, created_at, COALESCE(NULLIF(pg_temp.slugify(name), ''''), %L) AS base FROM %I) b
+        ) s WHERE t.id = s.id AND s.n = 1', entity, entity, entity);
+
+    FOR r IN EXECUTE format('SELECT id, name FROM %I WHERE slug IS NULL ORDER BY created_at, id', entity) LOOP
+        base := COALESCE(NULLIF(pg_temp.slugify(r.name), ''), entity);
+        n := 2;
+        LOOP
+            candidate := base || '-' || n;
+            EXECUTE format('SELECT EXISTS (SELECT 1 FROM %I WHERE slug = $1)', entity) INTO taken USING candidate;
+            EXIT WHEN NOT taken;
+            n := n + 1;
+        END LOOP;
+        EXECUTE format('UPDATE %I SET slug = $1 WHERE id = $2', entity) USING candidate, r.id;
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+SELECT pg_temp.backfill_slugs('product');
+SELECT pg_temp.backfill_slugs('category');
+SELECT pg_temp.backfill_slugs('brand');
+
+ALTER TABLE "product" ALTER COLUMN "slug" SET NOT NULL;
+ALTER TABLE "category" ALTER COLUMN "slug" SET NOT NULL;
+ALTER TABLE "brand" ALTER COLUMN "slug" SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "product_slug_key" ON "product" ("slug");
+CREATE UNIQUE INDEX IF NOT EXISTS "category_slug_key" ON "category" ("slug");
+CREATE UNIQUE INDEX IF NOT EXISTS "brand_slug_key" ON "brand" ("slug");
+
+-- slugs an entity had before it was renamed, they redirect to the current one
+CREATE TABLE IF NOT EXISTS "slug_history" (
+    "entity_type" VARCHAR(20) NOT NULL,
+    "slug" VARCHAR(255) NOT NULL,
+    "entity_id" UUID NOT NULL,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("entity_type", "slug")
+);
+
+CREATE INDEX IF NOT EXISTS "slug_history_entity_idx" ON "slug_history" ("entity_type", "entity_id");
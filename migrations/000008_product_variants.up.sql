@@ -0,0 +1,19 @@
+-- a variant is one combination of option values, {"color": "black", "memory": "256GB"},
+-- with its own stock. Products without variants keep their stock in "color".
+CREATE TABLE IF NOT EXISTS "product_variant" (
+    "id" UUID PRIMARY KEY,
+    "product_id" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,
+    "sku" VARCHAR(64) NOT NULL,
+    "options" JSONB NOT NULL DEFAULT '{}',
+    "price" DECIMAL(10, 2),  -- NULL uses the product price
+    "count" INT NOT NULL DEFAULT 0 CHECK ("count" >= 0),
+    "images" TEXT[],
+    "color_id" UUID REFERENCES "color"("id") ON DELETE SET NULL,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "product_variant_sku_key" ON "product_variant" ("sku");
+CREATE UNIQUE INDEX IF NOT EXISTS "product_variant_options_key" ON "product_variant" ("product_id", "options");
+
+ALTER TABLE "order_items" ADD COLUMN IF NOT EXISTS "variant_id" UUID REFERENCES "product_variant"("id");
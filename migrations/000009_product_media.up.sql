@@ -0,0 +1,30 @@
+-- images of a product in gallery order, file_id and url come from POST /upload-files
+CREATE TABLE IF NOT EXISTS "product_media" (
+    "id" UUID PRIMARY KEY,
+    "product_id" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,
+    "file_id" VARCHAR(255),
+    "url" TEXT NOT NULL,
+    "alt" VARCHAR(255) NOT NULL DEFAULT '',
+    "position" INT NOT NULL DEFAULT 0,
+    "is_primary" BOOLEAN NOT NULL DEFAULT FALSE,
+    "variant_id" UUID REFERENCES "product_variant"("id") ON DELETE SET NULL,
+    "color_id" UUID REFERENCES "color"("id") ON DELETE SET NULL,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "product_media_product_idx" ON "product_media" ("product_id", "position");
+
+-- the current product image becomes the primary one, color images follow it
+INSERT INTO "product_media" (id, product_id, url, position, is_primary)
+SELECT gen_random_uuid(), p.id, p.image, 0, TRUE
+FROM "product" p
+WHERE COALESCE(p.image, '') <> '';
+
+INSERT INTO "product_media" (id, product_id, url, position, color_id)
+SELECT gen_random_uuid(), c.product_id, u.url,
+    row_number() OVER (PARTITION BY c.product_id ORDER BY c.created_at, u.n),
+    c.id
+FROM "color" c
+CROSS JOIN LATERAL unnest(c.color_url) WITH ORDINALITY AS u(url, n)
+WHERE c.product_id IS NOT NULL AND COALESCE(u.url, '') <> '';
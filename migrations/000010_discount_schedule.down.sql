@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "product_discount_transition";
+
+DROP INDEX IF EXISTS "product_discount_due_idx";
+
+ALTER TABLE "product" DROP COLUMN IF EXISTS "status_before_discount";
+ALTER TABLE "product" DROP COLUMN IF EXISTS "discount_start_time";
@@ -0,0 +1,33 @@
+ALTER TABLE "product" ADD COLUMN IF NOT EXISTS "discount_percent" DECIMAL(5, 2);
+ALTER TABLE "product" ADD COLUMN IF NOT EXISTS "discount_end_time" TIMESTAMP;
+ALTER TABLE "product" ADD COLUMN IF NOT EXISTS "discount_start_time" TIMESTAMP;
+-- the status a product goes back to when its discount expires
+ALTER TABLE "product" ADD COLUMN IF NOT EXISTS "status_before_discount" product_status;
+
+-- every activation and expiry of a product discount
+CREATE TABLE IF NOT EXISTS "product_discount_transition" (
+    "id" UUID PRIMARY KEY,
+    "product_id" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,
+    "transition" VARCHAR(20) NOT NULL CHECK ("transition" IN ('activated', 'expired')),
+    "status_before" product_status,
+    "status_after" product_status,
+    "price" DECIMAL(10, 2),
+    "with_discount_before" DECIMAL(10, 2),
+    "with_discount_after" DECIMAL(10, 2),
+    "discount_percent" DECIMAL(5, 2),
+    "discount_start_time" TIMESTAMP,
+    "discount_end_time" TIMESTAMP,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "product_discount_transition_product_idx" ON "product_discount_transition" ("product_id", "created_at");
+
+CREATE INDEX IF NOT EXISTS "product_discount_due_idx" ON "product" ("discount_start_time", "discount_end_time")
+    WHERE "discount_percent" > 0;
+
+-- running discounts without a start time started when the product was created,
+-- the ones that already ended are expired by the scheduler on its first run.
+-- created_at holds Asia/Tashkent wall time, the scheduler compares in UTC.
+UPDATE "product"
+SET "discount_start_time" = COALESCE("created_at" AT TIME ZONE 'Asia/Tashkent' AT TIME ZONE 'UTC', NOW() AT TIME ZONE 'UTC')
+WHERE "status" = 'vremennaya_skidka' AND "discount_percent" > 0 AND "discount_start_time" IS NULL;
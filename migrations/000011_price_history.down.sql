@@ -0,0 +1,14 @@
+DELETE FROM "admin_role_permission" WHERE "permission" = 'price_history:read';
+
+DROP TRIGGER IF EXISTS "variant_price_history_update_trigger" ON "product_variant";
+DROP TRIGGER IF EXISTS "variant_price_history_insert_trigger" ON "product_variant";
+DROP TRIGGER IF EXISTS "product_price_history_update_trigger" ON "product";
+DROP TRIGGER IF EXISTS "product_price_history_insert_trigger" ON "product";
+
+DROP FUNCTION IF EXISTS variant_price_history();
+DROP FUNCTION IF EXISTS variant_effective_price(DECIMAL, UUID);
+DROP FUNCTION IF EXISTS product_price_history();
+DROP FUNCTION IF EXISTS variant_price_at(DECIMAL, DECIMAL, DECIMAL, DECIMAL);
+DROP FUNCTION IF EXISTS record_price_change(UUID, UUID, DECIMAL, DECIMAL, DECIMAL, DECIMAL, DECIMAL, DECIMAL, VARCHAR);
+
+DROP TABLE IF EXISTS "price_history";
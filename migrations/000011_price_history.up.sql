@@ -0,0 +1,165 @@
+-- every change of a product or variant price; changes made in one transaction
+-- are merged into one entry. Variant entries outlive the variant.
+CREATE TABLE IF NOT EXISTS "price_history" (
+    "id" UUID PRIMARY KEY,
+    "product_id" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,
+    "variant_id" UUID,
+    "price_before" DECIMAL(10, 2),
+    "price_after" DECIMAL(10, 2),
+    "with_discount_before" DECIMAL(10, 2),
+    "with_discount_after" DECIMAL(10, 2),
+    "effective_price_before" DECIMAL(10, 2),
+    "effective_price_after" DECIMAL(10, 2),
+    "reason" VARCHAR(255) NOT NULL DEFAULT '',
+    "changed_by" UUID,
+    "tx_id" BIGINT NOT NULL,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "price_history_tx_idx"
+    ON "price_history" ("product_id", COALESCE("variant_id", '00000000-0000-0000-0000-000000000000'), "tx_id");
+CREATE INDEX IF NOT EXISTS "price_history_product_idx" ON "price_history" ("product_id", "variant_id", "created_at");
+
+-- the writer of a price change sets app.price_changed_by and
+-- app.price_change_reason with set_config(..., true) in its transaction
+CREATE OR REPLACE FUNCTION record_price_change(
+    p_product_id UUID,
+    p_variant_id UUID,
+    p_price_before DECIMAL,
+    p_price_after DECIMAL,
+    p_with_discount_before DECIMAL,
+    p_with_discount_after DECIMAL,
+    p_effective_before DECIMAL,
+    p_effective_after DECIMAL,
+    p_default_reason VARCHAR
+) RETURNS VOID AS $$
+BEGIN
+    INSERT INTO "price_history" (
+        "id",
+        "product_id",
+        "variant_id",
+        "price_before",
+        "price_after",
+        "with_discount_before",
+        "with_discount_after",
+        "effective_price_before",
+        "effective_price_after",
+        "reason",
+        "changed_by",
+        "tx_id"
+    )
+    VALUES (
+        gen_random_uuid(),
+        p_product_id,
+        p_variant_id,
+        p_price_before,
+        p_price_after,
+        p_with_discount_before,
+        p_with_discount_after,
+        p_effective_before,
+        p_effective_after,
+        COALESCE(NULLIF(current_setting('app.price_change_reason', true), ''), p_default_reason),
+        NULLIF(current_setting('app.price_changed_by', true), '')::UUID,
+        txid_current()
+    )
+    ON CONFLICT ("product_id", COALESCE("variant_id", '00000000-0000-0000-0000-000000000000'), "tx_id") DO UPDATE SET
+        "price_after" = EXCLUDED."price_after",
+        "with_discount_after" = EXCLUDED."with_discount_after",
+        "effective_price_after" = EXCLUDED."effective_price_after";
+
+    -- a change undone in the same transaction leaves no entry
+    DELETE FROM "price_history"
+    WHERE "product_id" = p_product_id
+        AND "variant_id" IS NOT DISTINCT FROM p_variant_id
+        AND "tx_id" = txid_current()
+        AND "price_before" IS NOT DISTINCT FROM "price_after"
+        AND "with_discount_before" IS NOT DISTINCT FROM "with_discount_after"
+        AND "effective_price_before" IS NOT DISTINCT FROM "effective_price_after"
+        AND "effective_price_before" IS NOT NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- a variant without its own price costs what the product costs, the running
+-- product discount applies to its own price as well
+CREATE OR REPLACE FUNCTION variant_price_at(
+    p_price DECIMAL,
+    p_product_price DECIMAL,
+    p_with_discount DECIMAL,
+    p_discount_percent DECIMAL
+) RETURNS DECIMAL AS $$
+    SELECT CASE
+        WHEN p_price IS NULL THEN (CASE WHEN p_with_discount > 0 THEN p_with_discount ELSE p_product_price END)
+        WHEN p_with_discount > 0 THEN ROUND(p_price * (100 - COALESCE(p_discount_percent, 0)) / 100, 2)
+        ELSE p_price
+    END
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION product_price_history() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM record_price_change(NEW.id, NULL, NULL, NEW.price, NULL, NEW.with_discount,
+            NULL, CASE WHEN NEW.with_discount > 0 THEN NEW.with_discount ELSE NEW.price END, 'created');
+    ELSE
+        PERFORM record_price_change(NEW.id, NULL, OLD.price, NEW.price, OLD.with_discount, NEW.with_discount,
+            CASE WHEN OLD.with_discount > 0 THEN OLD.with_discount ELSE OLD.price END,
+            CASE WHEN NEW.with_discount > 0 THEN NEW.with_discount ELSE NEW.price END, '');
+
+        -- a discount starting or ending, or a new product price, changes what
+        -- the variants cost without touching their rows
+        PERFORM record_price_change(NEW.id, v.id, v.price, v.price, NULL, NULL,
+            variant_price_at(v.price, OLD.price, OLD.with_discount, OLD.discount_percent),
+            variant_price_at(v.price, NEW.price, NEW.with_discount, NEW.discount_percent), '')
+        FROM "product_variant" v
+        WHERE v.product_id = NEW.id
+            AND variant_price_at(v.price, OLD.price, OLD.with_discount, OLD.discount_percent)
+                IS DISTINCT FROM variant_price_at(v.price, NEW.price, NEW.with_discount, NEW.discount_percent);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "product_price_history_insert_trigger" ON "product";
+CREATE TRIGGER "product_price_history_insert_trigger"
+    AFTER INSERT ON "product"
+    FOR EACH ROW EXECUTE FUNCTION product_price_history();
+
+DROP TRIGGER IF EXISTS "product_price_history_update_trigger" ON "product";
+CREATE TRIGGER "product_price_history_update_trigger"
+    AFTER UPDATE OF "price", "with_discount", "discount_percent" ON "product"
+    FOR EACH ROW WHEN (OLD.price IS DISTINCT FROM NEW.price OR OLD.with_discount IS DISTINCT FROM NEW.with_discount
+        OR OLD.discount_percent IS DISTINCT FROM NEW.discount_percent)
+    EXECUTE FUNCTION product_price_history();
+
+CREATE OR REPLACE FUNCTION variant_effective_price(p_price DECIMAL, p_product_id UUID) RETURNS DECIMAL AS $$
+    SELECT variant_price_at(p_price, p.price, p.with_discount, p.discount_percent)
+    FROM "product" p
+    WHERE p.id = p_product_id
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION variant_price_history() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM record_price_change(NEW.product_id, NEW.id, NULL, NEW.price, NULL, NULL,
+            NULL, variant_effective_price(NEW.price, NEW.product_id), 'created');
+    ELSE
+        PERFORM record_price_change(NEW.product_id, NEW.id, OLD.price, NEW.price, NULL, NULL,
+            variant_effective_price(OLD.price, NEW.product_id), variant_effective_price(NEW.price, NEW.product_id), '');
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "variant_price_history_insert_trigger" ON "product_variant";
+CREATE TRIGGER "variant_price_history_insert_trigger"
+    AFTER INSERT ON "product_variant"
+    FOR EACH ROW EXECUTE FUNCTION variant_price_history();
+
+DROP TRIGGER IF EXISTS "variant_price_history_update_trigger" ON "product_variant";
+CREATE TRIGGER "variant_price_history_update_trigger"
+    AFTER UPDATE OF "price" ON "product_variant"
+    FOR EACH ROW WHEN (OLD.price IS DISTINCT FROM NEW.price)
+    EXECUTE FUNCTION variant_price_history();
+
+INSERT INTO "admin_role_permission" ("role_id", "permission")
+SELECT "id", 'price_history:read' FROM "admin_role" WHERE "name" IN ('superadmin', 'catalog_manager', 'order_operator')
+ON CONFLICT DO NOTHING;
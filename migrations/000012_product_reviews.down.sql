@@ -0,0 +1,5 @@
+DELETE FROM "admin_role_permission" WHERE "permission" = 'review:moderate';
+
+ALTER TABLE "product" DROP COLUMN IF EXISTS "review_count";
+
+DROP TABLE IF EXISTS "product_review";
@@ -0,0 +1,30 @@
+-- one review per customer and product; only approved reviews are shown and
+-- counted in the product rating
+CREATE TABLE IF NOT EXISTS "product_review" (
+    "id" UUID PRIMARY KEY,
+    "product_id" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,
+    "customer_id" UUID NOT NULL REFERENCES "customer"("id") ON DELETE CASCADE,
+    "rating" SMALLINT NOT NULL CHECK ("rating" BETWEEN 1 AND 5),
+    "text" VARCHAR(2000) NOT NULL DEFAULT '',
+    "photos" TEXT[] NOT NULL DEFAULT '{}',
+    "status" VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'approved', 'rejected')),
+    "moderation_note" VARCHAR(500) NOT NULL DEFAULT '',
+    "moderated_by" UUID,
+    "moderated_at" TIMESTAMP,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP,
+    UNIQUE ("product_id", "customer_id")
+);
+
+CREATE INDEX IF NOT EXISTS "product_review_product_idx" ON "product_review" ("product_id", "status", "created_at");
+CREATE INDEX IF NOT EXISTS "product_review_status_idx" ON "product_review" ("status", "created_at");
+
+ALTER TABLE "product" ADD COLUMN IF NOT EXISTS "review_count" INT NOT NULL DEFAULT 0;
+
+-- the rating is computed from the approved reviews from now on, the ones
+-- typed in by admins do not come from any review
+UPDATE "product" SET "rating" = 0 WHERE "review_count" = 0 AND COALESCE("rating", 0) <> 0;
+
+INSERT INTO "admin_role_permission" ("role_id", "permission")
+SELECT "id", 'review:moderate' FROM "admin_role" WHERE "name" IN ('superadmin', 'catalog_manager')
+ON CONFLICT DO NOTHING;
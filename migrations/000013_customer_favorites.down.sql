@@ -0,0 +1,3 @@
+ALTER TABLE "product" ADD COLUMN IF NOT EXISTS "favorite" BOOLEAN;
+
+DROP TABLE IF EXISTS "customer_favorite";
@@ -0,0 +1,11 @@
+-- favorites are kept per customer, the global product flag is replaced
+CREATE TABLE IF NOT EXISTS "customer_favorite" (
+    "customer_id" UUID NOT NULL REFERENCES "customer"("id") ON DELETE CASCADE,
+    "product_id" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("customer_id", "product_id")
+);
+
+CREATE INDEX IF NOT EXISTS "customer_favorite_product_idx" ON "customer_favorite" ("product_id");
+
+ALTER TABLE "product" DROP COLUMN IF EXISTS "favorite";
@@ -0,0 +1,36 @@
+DROP TRIGGER IF EXISTS "category_product_search_trigger" ON "category";
+CREATE TRIGGER "category_product_search_trigger"
+    AFTER UPDATE OF "name" ON "category"
+    FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+    EXECUTE FUNCTION product_search_vector_refresh_category();
+
+DROP TRIGGER IF EXISTS "brand_product_search_trigger" ON "brand";
+CREATE TRIGGER "brand_product_search_trigger"
+    AFTER UPDATE OF "name" ON "brand"
+    FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+    EXECUTE FUNCTION product_search_vector_refresh_brand();
+
+DROP TRIGGER IF EXISTS "product_search_vector_trigger" ON "product";
+CREATE TRIGGER "product_search_vector_trigger"
+    BEFORE INSERT OR UPDATE OF "name", "description", "brand_id", "category_id" ON "product"
+    FOR EACH ROW EXECUTE FUNCTION product_search_vector_update();
+
+CREATE OR REPLACE FUNCTION product_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('simple', COALESCE(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE((SELECT "name" FROM "brand" WHERE "id" = NEW.brand_id), '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE((SELECT "name" FROM "category" WHERE "id" = NEW.category_id), '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE(NEW.description, '')), 'C');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS i18n_text(JSONB);
+
+ALTER TABLE "location" DROP COLUMN IF EXISTS "info_i18n";
+ALTER TABLE "location" DROP COLUMN IF EXISTS "name_i18n";
+ALTER TABLE "brand" DROP COLUMN IF EXISTS "name_i18n";
+ALTER TABLE "category" DROP COLUMN IF EXISTS "name_i18n";
+ALTER TABLE "product" DROP COLUMN IF EXISTS "description_i18n";
+ALTER TABLE "product" DROP COLUMN IF EXISTS "name_i18n";
@@ -0,0 +1,41 @@
+-- translations of the catalog texts keyed by locale (uz-Cyrl, ru, en), the
+-- plain columns keep the uzbek latin text
+ALTER TABLE "product" ADD COLUMN IF NOT EXISTS "name_i18n" JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE "product" ADD COLUMN IF NOT EXISTS "description_i18n" JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE "category" ADD COLUMN IF NOT EXISTS "name_i18n" JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE "brand" ADD COLUMN IF NOT EXISTS "name_i18n" JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE "location" ADD COLUMN IF NOT EXISTS "name_i18n" JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE "location" ADD COLUMN IF NOT EXISTS "info_i18n" JSONB NOT NULL DEFAULT '{}';
+
+-- search finds products by their names in every locale
+CREATE OR REPLACE FUNCTION i18n_text(translations JSONB) RETURNS TEXT AS $$
+    SELECT COALESCE(string_agg(value, ' '), '') FROM jsonb_each_text(translations);
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION product_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('simple', COALESCE(NEW.name, '') || ' ' || i18n_text(NEW.name_i18n)), 'A') ||
+        setweight(to_tsvector('simple', COALESCE((SELECT "name" || ' ' || i18n_text("name_i18n") FROM "brand" WHERE "id" = NEW.brand_id), '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE((SELECT "name" || ' ' || i18n_text("name_i18n") FROM "category" WHERE "id" = NEW.category_id), '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE(NEW.description, '') || ' ' || i18n_text(NEW.description_i18n)), 'C');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "product_search_vector_trigger" ON "product";
+CREATE TRIGGER "product_search_vector_trigger"
+    BEFORE INSERT OR UPDATE OF "name", "name_i18n", "description", "description_i18n", "brand_id", "category_id" ON "product"
+    FOR EACH ROW EXECUTE FUNCTION product_search_vector_update();
+
+DROP TRIGGER IF EXISTS "brand_product_search_trigger" ON "brand";
+CREATE TRIGGER "brand_product_search_trigger"
+    AFTER UPDATE OF "name", "name_i18n" ON "brand"
+    FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name OR OLD.name_i18n IS DISTINCT FROM NEW.name_i18n)
+    EXECUTE FUNCTION product_search_vector_refresh_brand();
+
+DROP TRIGGER IF EXISTS "category_product_search_trigger" ON "category";
+CREATE TRIGGER "category_product_search_trigger"
+    AFTER UPDATE OF "name", "name_i18n" ON "category"
+    FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name OR OLD.name_i18n IS DISTINCT FROM NEW.name_i18n)
+    EXECUTE FUNCTION product_search_vector_refresh_category();
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "order_items_order_product_idx";
+
+DROP TABLE IF EXISTS "product_affinity";
@@ -0,0 +1,14 @@
+-- co-purchase affinities computed from order_items by the recommendation job,
+-- score is the cosine of the orders of the two products
+CREATE TABLE IF NOT EXISTS "product_affinity" (
+    "product_id" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,
+    "related_product_id" UUID NOT NULL REFERENCES "product"("id") ON DELETE CASCADE,
+    "orders_together" INT NOT NULL,
+    "score" DOUBLE PRECISION NOT NULL,
+    "computed_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("product_id", "related_product_id")
+);
+
+CREATE INDEX IF NOT EXISTS "product_affinity_score_idx" ON "product_affinity" ("product_id", "score" DESC);
+
+CREATE INDEX IF NOT EXISTS "order_items_order_product_idx" ON "order_items" ("order_id", "product_id");
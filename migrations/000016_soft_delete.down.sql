@@ -0,0 +1,12 @@
+-- fails while a deleted customer's phone number is registered again, one of
+-- the two rows has to go first
+DROP INDEX IF EXISTS "customer_phone_number_key";
+CREATE UNIQUE INDEX IF NOT EXISTS "customer_phone_number_key" ON "customer" ("phone_number");
+
+DROP INDEX IF EXISTS "color_product_live_idx";
+DROP INDEX IF EXISTS "product_live_idx";
+
+ALTER TABLE "customer" DROP COLUMN IF EXISTS "deleted_at";
+ALTER TABLE "location" DROP COLUMN IF EXISTS "deleted_at";
+ALTER TABLE "color" DROP COLUMN IF EXISTS "deleted_at";
+ALTER TABLE "product" DROP COLUMN IF EXISTS "deleted_at";
@@ -0,0 +1,13 @@
+-- catalog entities and customers are soft deleted so orders keep what they
+-- refer to, brand, category and banner already have the column
+ALTER TABLE "product" ADD COLUMN IF NOT EXISTS "deleted_at" TIMESTAMP;
+ALTER TABLE "color" ADD COLUMN IF NOT EXISTS "deleted_at" TIMESTAMP;
+ALTER TABLE "location" ADD COLUMN IF NOT EXISTS "deleted_at" TIMESTAMP;
+ALTER TABLE "customer" ADD COLUMN IF NOT EXISTS "deleted_at" TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS "product_live_idx" ON "product" ("created_at" DESC) WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS "color_product_live_idx" ON "color" ("product_id") WHERE "deleted_at" IS NULL;
+
+-- a deleted customer's phone number can be registered again
+DROP INDEX IF EXISTS "customer_phone_number_key";
+CREATE UNIQUE INDEX IF NOT EXISTS "customer_phone_number_key" ON "customer" ("phone_number") WHERE "deleted_at" IS NULL;
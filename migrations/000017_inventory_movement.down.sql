@@ -0,0 +1,12 @@
+DELETE FROM "admin_role_permission" WHERE "permission" = 'inventory:read';
+
+DROP TRIGGER IF EXISTS "variant_stock_movement_update_trigger" ON "product_variant";
+DROP TRIGGER IF EXISTS "variant_stock_movement_insert_trigger" ON "product_variant";
+DROP TRIGGER IF EXISTS "color_stock_movement_update_trigger" ON "color";
+DROP TRIGGER IF EXISTS "color_stock_movement_insert_trigger" ON "color";
+DROP FUNCTION IF EXISTS stock_movement();
+
+DROP TRIGGER IF EXISTS "inventory_movement_append_only_trigger" ON "inventory_movement";
+DROP FUNCTION IF EXISTS inventory_movement_append_only();
+
+DROP TABLE IF EXISTS "inventory_movement";
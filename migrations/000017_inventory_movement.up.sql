@@ -0,0 +1,112 @@
+-- every change of a color or variant stock, entries are never changed or
+-- removed. Variant and order entries outlive the variant and the order.
+CREATE TABLE IF NOT EXISTS "inventory_movement" (
+    "id" UUID PRIMARY KEY,
+    "product_id" UUID REFERENCES "product"("id"),
+    "color_id" UUID REFERENCES "color"("id"),
+    "variant_id" UUID,
+    -- initial, sale, cancellation, restock, return, damage, correction or import
+    "kind" VARCHAR(20) NOT NULL,
+    "quantity" INT NOT NULL,
+    "count_after" INT NOT NULL,
+    "order_id" UUID,
+    "changed_by" UUID,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (("color_id" IS NULL) <> ("variant_id" IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS "inventory_movement_color_idx" ON "inventory_movement" ("color_id", "created_at") WHERE "color_id" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "inventory_movement_variant_idx" ON "inventory_movement" ("variant_id", "created_at") WHERE "variant_id" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "inventory_movement_order_idx" ON "inventory_movement" ("order_id") WHERE "order_id" IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION inventory_movement_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'inventory_movement is append only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "inventory_movement_append_only_trigger" ON "inventory_movement";
+CREATE TRIGGER "inventory_movement_append_only_trigger"
+    BEFORE UPDATE OR DELETE ON "inventory_movement"
+    FOR EACH ROW EXECUTE FUNCTION inventory_movement_append_only();
+
+-- the writer of a stock change sets app.stock_movement, app.stock_order_id
+-- and app.stock_changed_by with set_config(..., true) in its transaction. A
+-- new row is an initial entry and a change without a kind a correction.
+CREATE OR REPLACE FUNCTION stock_movement() RETURNS TRIGGER AS $$
+DECLARE
+    v_before INT := 0;
+    v_kind VARCHAR := 'initial';
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        v_before := COALESCE(OLD.count, 0);
+        v_kind := 'correction';
+    END IF;
+
+    IF COALESCE(NEW.count, 0) = v_before THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO "inventory_movement" (
+        "id",
+        "product_id",
+        "color_id",
+        "variant_id",
+        "kind",
+        "quantity",
+        "count_after",
+        "order_id",
+        "changed_by"
+    )
+    VALUES (
+        gen_random_uuid(),
+        NEW.product_id,
+        CASE WHEN TG_TABLE_NAME = 'color' THEN NEW.id END,
+        CASE WHEN TG_TABLE_NAME = 'product_variant' THEN NEW.id END,
+        COALESCE(NULLIF(current_setting('app.stock_movement', true), ''), v_kind),
+        COALESCE(NEW.count, 0) - v_before,
+        COALESCE(NEW.count, 0),
+        NULLIF(current_setting('app.stock_order_id', true), '')::UUID,
+        NULLIF(current_setting('app.stock_changed_by', true), '')::UUID
+    );
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "color_stock_movement_insert_trigger" ON "color";
+CREATE TRIGGER "color_stock_movement_insert_trigger"
+    AFTER INSERT ON "color"
+    FOR EACH ROW EXECUTE FUNCTION stock_movement();
+
+DROP TRIGGER IF EXISTS "color_stock_movement_update_trigger" ON "color";
+CREATE TRIGGER "color_stock_movement_update_trigger"
+    AFTER UPDATE OF "count" ON "color"
+    FOR EACH ROW WHEN (OLD.count IS DISTINCT FROM NEW.count)
+    EXECUTE FUNCTION stock_movement();
+
+DROP TRIGGER IF EXISTS "variant_stock_movement_insert_trigger" ON "product_variant";
+CREATE TRIGGER "variant_stock_movement_insert_trigger"
+    AFTER INSERT ON "product_variant"
+    FOR EACH ROW EXECUTE FUNCTION stock_movement();
+
+DROP TRIGGER IF EXISTS "variant_stock_movement_update_trigger" ON "product_variant";
+CREATE TRIGGER "variant_stock_movement_update_trigger"
+    AFTER UPDATE OF "count" ON "product_variant"
+    FOR EACH ROW WHEN (OLD.count IS DISTINCT FROM NEW.count)
+    EXECUTE FUNCTION stock_movement();
+
+-- the stock on hand opens the ledger
+INSERT INTO "inventory_movement" ("id", "product_id", "color_id", "kind", "quantity", "count_after")
+SELECT gen_random_uuid(), "product_id", "id", 'initial', "count", "count"
+FROM "color"
+WHERE COALESCE("count", 0) <> 0;
+
+INSERT INTO "inventory_movement" ("id", "product_id", "variant_id", "kind", "quantity", "count_after")
+SELECT gen_random_uuid(), "product_id", "id", 'initial', "count", "count"
+FROM "product_variant"
+WHERE "count" <> 0;
+
+INSERT INTO "admin_role_permission" ("role_id", "permission")
+SELECT "id", 'inventory:read' FROM "admin_role" WHERE "name" IN ('superadmin', 'catalog_manager', 'order_operator')
+ON CONFLICT DO NOTHING;
@@ -5,8 +5,10 @@ type Admin struct {
 	Name         string `json:"name,omitempty"`
 	Phone_number string `json:"phone_number,omitempty"`
 	Email        string `json:"email,omitempty"`
-	Password     string `json:"password,omitempty"`
+	Password     string `json:"-"`
 	Address      string `json:"addres,omitempty"`
+	RoleId       string `json:"role_id,omitempty"`
+	RoleName     string `json:"role_name,omitempty"`
 	CreatedAt    string `json:"created_at,omitempty"`
 	UpdatedAt    string `json:"updated_at,omitempty"`
 	DeletedAt    string `json:"delete_at,omitempty"`
@@ -18,6 +20,7 @@ type AdminCreate struct {
 	Email        string `json:"email"`
 	Password     string `json:"password"`
 	Address      string `json:"addres"`
+	RoleId       string `json:"role_id"`
 }
 
 type AdminUpdate struct {
@@ -0,0 +1,63 @@
+package models
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionImport  = "import"
+	AuditActionRestore = "restore"
+	// AuditActionStock is a stock adjustment of a color or a variant
+	AuditActionStock = "stock"
+
+	AuditEntityProduct  = "product"
+	AuditEntityColor    = "color"
+	AuditEntityCategory = "category"
+	AuditEntityBrand    = "brand"
+	AuditEntityBanner   = "banner"
+	AuditEntityLocation = "location"
+	AuditEntityOrder    = "order"
+	AuditEntityAdmin    = "admin"
+	AuditEntityRole     = "role"
+	AuditEntityVariant  = "variant"
+	AuditEntityMedia    = "media"
+	AuditEntityReview   = "review"
+	AuditEntityCustomer = "customer"
+)
+
+type AuditLog struct {
+	Id         string                 `json:"id"`
+	ActorId    string                 `json:"actor_id"`
+	ActorRole  string                 `json:"actor_role"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityId   string                 `json:"entity_id"`
+	Before     map[string]interface{} `json:"before"`
+	After      map[string]interface{} `json:"after"`
+	CreatedAt  string                 `json:"created_at"`
+}
+
+type AuditLogCreate struct {
+	ActorId    string
+	ActorRole  string
+	Action     string
+	EntityType string
+	EntityId   string
+	Before     map[string]interface{}
+	After      map[string]interface{}
+}
+
+type AuditGetListRequest struct {
+	Offset     int    `json:"offset"`
+	Limit      int    `json:"limit"`
+	ActorId    string `json:"actor_id"`
+	Action     string `json:"action"`
+	EntityType string `json:"entity_type"`
+	EntityId   string `json:"entity_id"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+}
+
+type AuditGetListResponse struct {
+	Count int         `json:"count"`
+	Audit []*AuditLog `json:"audit"`
+}
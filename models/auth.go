@@ -13,8 +13,19 @@ type UserLoginResponse struct {
 }
 
 type AuthInfo struct {
-	UserID   string `json:"user_id"`
-	UserRole string `json:"user_role"`
+	UserID      string   `json:"user_id"`
+	UserRole    string   `json:"user_role"`
+	Permissions []string `json:"permissions"`
+}
+
+// HasPermission reports whether the admin permission was granted to the caller.
+func (a AuthInfo) HasPermission(permission string) bool {
+	for _, p := range a.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
 }
 
 type UserRegisterRequest struct {
@@ -31,5 +42,10 @@ type UserLoginByPhoneConfirmRequest struct {
 }
 
 type UserRegisterConfRequest struct {
-	Customer    *CustomerCreate `json:"customer"`
+	Customer *CustomerCreate `json:"customer"`
+	OtpCode  string          `json:"otp_code"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
@@ -19,11 +19,15 @@ type BannerUpdate struct {
 
 type BannerPrimaryKey struct {
 	Id string `json:"id"`
+	// IncludeDeleted finds soft deleted rows as well
+	IncludeDeleted bool `json:"-"`
 }
 
 type BannerGetListRequest struct {
 	Offset int `json:"offset"`
 	Limit  int `json:"limit"`
+	// IncludeDeleted lists soft deleted rows as well
+	IncludeDeleted bool `json:"include_deleted"`
 }
 
 type BannerGetListResponse struct {
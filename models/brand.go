@@ -3,30 +3,44 @@ package models
 type Brand struct {
 	Id          string `json:"id,omitempty"`
 	Name        string `json:"name,omitempty"`
+	Slug        string `json:"slug,omitempty"`
 	Brand_image string `json:"brand_image,omitempty"`
 	CreatedAt   string `json:"created_at,omitempty"`
 	UpdatedAt   string `json:"updated_at,omitempty"`
 	DeletedAt   string `json:"delete_at,omitempty"`
+	// Name is in the requested locale, NameI18n holds every translation
+	NameI18n Translations `json:"name_i18n,omitempty"`
 }
 
 type BrandCreate struct {
-	Name        string `json:"name"`
-	Brand_image string `json:"brand_image"`
+	Name        string       `json:"name"`
+	NameI18n    Translations `json:"name_i18n"`
+	Brand_image string       `json:"brand_image"`
 }
 
+// BrandUpdate keeps the stored translations when NameI18n is nil
 type BrandUpdate struct {
-	Id          string `json:"id"`
-	Name        string `json:"name"`
-	Brand_image string `json:"brand_image"`
+	Id          string       `json:"id"`
+	Name        string       `json:"name"`
+	NameI18n    Translations `json:"name_i18n"`
+	Brand_image string       `json:"brand_image"`
 }
 
 type BrandPrimaryKey struct {
-	Id string `json:"id"`
+	Id   string `json:"id"`
+	Slug string `json:"slug"`
+	// Lang is the locale of the name, the default locale when empty
+	Lang string `json:"-"`
+	// IncludeDeleted finds soft deleted rows as well
+	IncludeDeleted bool `json:"-"`
 }
 
 type BrandGetListRequest struct {
-	Offset int `json:"offset"`
-	Limit  int `json:"limit"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+	Lang   string `json:"lang"`
+	// IncludeDeleted lists soft deleted rows as well
+	IncludeDeleted bool `json:"include_deleted"`
 }
 
 type BrandGetListResponse struct {
@@ -3,35 +3,79 @@ package models
 type Category struct {
 	Id        string `json:"id"`
 	Name      string `json:"name"`
+	Slug      string `json:"slug"`
 	ParentId  string `json:"parent_id"`
 	Url       string `json:"url"`
 	CreatedAt string `json:"created_at,omitempty"`
 	UpdatedAt string `json:"updated_at,omitempty"`
 	DeletedAt string `json:"delete_at,omitempty"`
+	// Name is in the requested locale, NameI18n holds every translation
+	NameI18n Translations `json:"name_i18n,omitempty"`
 }
 
 type CategoryCreate struct {
-	Name     string `json:"name"`
-	Url      string `json:"url"`
-	ParentId string `json:"parent_id"`
+	Name     string       `json:"name"`
+	NameI18n Translations `json:"name_i18n"`
+	Url      string       `json:"url"`
+	ParentId string       `json:"parent_id"`
 }
 
+// CategoryUpdate renames the category, an empty name or url keeps the current one.
+// ParentId moves the category with its subtree when given, an empty string
+// makes it a root category. Nil NameI18n keeps the stored translations.
 type CategoryUpdate struct {
-	Id   string `json:"id"`
-	Name string `json:"name"`
+	Id       string       `json:"id"`
+	Name     string       `json:"name"`
+	NameI18n Translations `json:"name_i18n"`
+	Url      string       `json:"url"`
+	ParentId *string      `json:"parent_id"`
 }
 
 type CategoryPrimaryKey struct {
-	Id string `json:"id"`
+	Id   string `json:"id"`
+	Slug string `json:"slug"`
+	// Lang is the locale of the names, the default locale when empty
+	Lang string `json:"-"`
+	// IncludeDeleted finds soft deleted rows as well
+	IncludeDeleted bool `json:"-"`
 }
 
 type CategoryGetListRequest struct {
 	Offset int    `json:"offset"`
 	Limit  int    `json:"limit"`
 	Name   string `json:"name"`
+	Lang   string `json:"lang"`
+	// IncludeDeleted lists soft deleted rows as well
+	IncludeDeleted bool `json:"include_deleted"`
 }
 
 type CategoryGetListResponse struct {
 	Count    int         `json:"count"`
 	Category []*Category `json:"category"`
 }
+
+// CategoryTree is a category with its subcategories
+type CategoryTree struct {
+	Id       string          `json:"id"`
+	Name     string          `json:"name"`
+	Slug     string          `json:"slug"`
+	ParentId string          `json:"parent_id"`
+	Url      string          `json:"url"`
+	NameI18n Translations    `json:"name_i18n,omitempty"`
+	Children []*CategoryTree `json:"children"`
+}
+
+type CategoryTreeRequest struct {
+	RootId string `json:"root_id"`
+	Lang   string `json:"lang"`
+}
+
+type CategoryTreeResponse struct {
+	Category []*CategoryTree `json:"category"`
+}
+
+// CategoryBreadcrumbsResponse lists the path from the root category down to
+// the requested one
+type CategoryBreadcrumbsResponse struct {
+	Category []*Category `json:"category"`
+}
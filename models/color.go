@@ -16,22 +16,27 @@ type ColorCreate struct {
 	Name      string   `json:"color_name"`
 	Url       []string `json:"color_url"`
 	Count     int      `json:"count"`
+	CreatedBy string   `json:"-"`
 }
 
+// ColorUpdate keeps the name or the images when they are empty, the stock is
+// changed by stock adjustments only so a checkout running meanwhile is kept
 type ColorUpdate struct {
-	ProductId string   `json:"product_id"`
-	Id        string   `json:"id"`
-	Name      string   `json:"color_name"`
-	Url       []string `json:"color_url"`
-	Count     int      `json:"count"`
+	Id   string   `json:"-"`
+	Name string   `json:"color_name"`
+	Url  []string `json:"color_url"`
 }
 type ColorPrimaryKey struct {
 	Id string `json:"id"`
+	// IncludeDeleted finds soft deleted rows as well
+	IncludeDeleted bool `json:"-"`
 }
 
 type ColorGetListRequest struct {
 	Offset int `json:"offset"`
 	Limit  int `json:"limit"`
+	// IncludeDeleted lists soft deleted rows as well
+	IncludeDeleted bool `json:"include_deleted"`
 }
 
 type ColorGetListResponse struct {
@@ -7,6 +7,7 @@ type Customer struct {
 	Phone_number string `json:"phone_number"`
 	Birthday     string `json:"birthday"`
 	Gender       string `json:"gender"`
+	Password     string `json:"-"`
 	CreatedAt    string `json:"created_at,omitempty"`
 	UpdatedAt    string `json:"updated_at,omitempty"`
 	DeletedAt    string `json:"delete_at,omitempty"`
@@ -18,6 +19,7 @@ type CustomerCreate struct {
 	Phone_number string `json:"phone_number"`
 	Birthday     string `json:"birthday"`
 	Gender       string `json:"gender"`
+	Password     string `json:"password,omitempty"`
 }
 
 type CustomerUpdate struct {
@@ -27,15 +29,20 @@ type CustomerUpdate struct {
 	Phone_number string `json:"phone_number"`
 	Birthday     string `json:"birthday"`
 	Gender       string `json:"gender"`
+	Password     string `json:"password,omitempty"`
 }
 
 type CustomerPrimaryKey struct {
 	Id string `json:"id"`
+	// IncludeDeleted finds soft deleted rows as well
+	IncludeDeleted bool `json:"-"`
 }
 
 type CustomerGetListRequest struct {
 	Offset int `json:"offset"`
 	Limit  int `json:"limit"`
+	// IncludeDeleted lists soft deleted rows as well
+	IncludeDeleted bool `json:"include_deleted"`
 }
 
 type CustomerGetListResponse struct {
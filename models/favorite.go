@@ -0,0 +1,13 @@
+package models
+
+// Favorite is a product in the wishlist of a customer
+type Favorite struct {
+	CustomerId string `json:"customer_id"`
+	ProductId  string `json:"product_id"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type FavoritePrimaryKey struct {
+	CustomerId string `json:"customer_id"`
+	ProductId  string `json:"product_id"`
+}
@@ -0,0 +1,37 @@
+package models
+
+// Locales of the catalog texts. The plain text fields hold the default
+// locale text, the translations to the other locales are kept next to them.
+const (
+	LocaleUz      = "uz"
+	LocaleUzCyrl  = "uz-Cyrl"
+	LocaleRu      = "ru"
+	LocaleEn      = "en"
+	DefaultLocale = LocaleUz
+)
+
+// TranslationLocales are the locales a text can be translated to
+var TranslationLocales = []string{LocaleUzCyrl, LocaleRu, LocaleEn}
+
+// localeFallbacks are the locales tried in order when a text has no
+// translation to the locale, the default locale text comes after them
+var localeFallbacks = map[string][]string{
+	LocaleEn: {LocaleRu},
+}
+
+// Translations maps a translation locale to the text in it
+type Translations map[string]string
+
+// Localize returns the text in the locale, following the fallback chain of
+// the locale down to the default locale text
+func (t Translations) Localize(text, locale string) string {
+	if value := t[locale]; value != "" {
+		return value
+	}
+	for _, fallback := range localeFallbacks[locale] {
+		if value := t[fallback]; value != "" {
+			return value
+		}
+	}
+	return text
+}
@@ -0,0 +1,65 @@
+package models
+
+// Kinds of inventory movements written by the service itself, the rest are
+// the stock adjustment reasons
+const (
+	InventoryMovementInitial      = "initial"
+	InventoryMovementSale         = "sale"
+	InventoryMovementCancellation = "cancellation"
+	InventoryMovementImport       = "import"
+)
+
+// InventoryMovement is a change of the color stock, or of the variant stock
+// when VariantId is set. An import sets the stock of the colors it lists. Quantity is signed, CountAfter is the stock the
+// change left. OrderId is set on sales, cancellations and returns, ChangedBy
+// is the admin, or the customer on a sale.
+type InventoryMovement struct {
+	Id         string `json:"id"`
+	ProductId  string `json:"product_id"`
+	ColorId    string `json:"color_id,omitempty"`
+	VariantId  string `json:"variant_id,omitempty"`
+	Kind       string `json:"kind"`
+	Quantity   int    `json:"quantity"`
+	CountAfter int    `json:"count_after"`
+	OrderId    string `json:"order_id,omitempty"`
+	ChangedBy  string `json:"changed_by,omitempty"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type InventoryMovementGetListRequest struct {
+	ProductId string `json:"product_id"`
+	ColorId   string `json:"color_id"`
+	VariantId string `json:"variant_id"`
+	OrderId   string `json:"order_id"`
+	Kind      string `json:"kind"`
+	Offset    int    `json:"offset"`
+	Limit     int    `json:"limit"`
+}
+
+// InventoryMovementGetListResponse lists the movements newest first
+type InventoryMovementGetListResponse struct {
+	Count     int                  `json:"count"`
+	Movements []*InventoryMovement `json:"movements"`
+}
+
+type InventoryReconcileRequest struct {
+	ProductId string `json:"product_id"`
+}
+
+// InventoryMismatch is a color or variant whose stock is not the sum of its
+// ledger, Difference is Count - LedgerCount
+type InventoryMismatch struct {
+	ProductId   string `json:"product_id"`
+	ColorId     string `json:"color_id,omitempty"`
+	VariantId   string `json:"variant_id,omitempty"`
+	LedgerCount int    `json:"ledger_count"`
+	Count       int    `json:"count"`
+	Difference  int    `json:"difference"`
+}
+
+// InventoryReconcileResponse reports how many colors and variants were
+// checked and which of them do not match the ledger
+type InventoryReconcileResponse struct {
+	Checked    int                  `json:"checked"`
+	Mismatches []*InventoryMismatch `json:"mismatches"`
+}
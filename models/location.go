@@ -12,6 +12,10 @@ type Location struct {
 	CreatedAt string  `json:"created_at,omitempty"`
 	UpdatedAt string  `json:"updated_at,omitempty"`
 	DeletedAt string  `json:"delete_at,omitempty"`
+	// Name and Info are in the requested locale, the I18n fields hold every
+	// translation
+	NameI18n Translations `json:"name_i18n,omitempty"`
+	InfoI18n Translations `json:"info_i18n,omitempty"`
 }
 
 type LocationCreate struct {
@@ -22,6 +26,10 @@ type LocationCreate struct {
 	Image     string  `json:"image"`
 	OpensAt   string  `json:"opens_at"`
 	ClosesAt  string  `json:"closes_at"`
+	// NameI18n and InfoI18n are the translations of Name and Info by
+	// locale, an update keeps the stored ones when nil
+	NameI18n Translations `json:"name_i18n"`
+	InfoI18n Translations `json:"info_i18n"`
 }
 
 type LocationUpdate struct {
@@ -33,15 +41,26 @@ type LocationUpdate struct {
 	Image     string  `json:"image"`
 	OpensAt   string  `json:"opens_at"`
 	ClosesAt  string  `json:"closes_at"`
+	// NameI18n and InfoI18n are the translations of Name and Info by
+	// locale, an update keeps the stored ones when nil
+	NameI18n Translations `json:"name_i18n"`
+	InfoI18n Translations `json:"info_i18n"`
 }
 
 type LacationPrimaryKey struct {
 	Id string `json:"id"`
+	// Lang is the locale of the texts, the default locale when empty
+	Lang string `json:"-"`
+	// IncludeDeleted finds soft deleted rows as well
+	IncludeDeleted bool `json:"-"`
 }
 
 type LocationGetListRequest struct {
-	Offset int `json:"offset"`
-	Limit  int `json:"limit"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+	Lang   string `json:"lang"`
+	// IncludeDeleted lists soft deleted rows as well
+	IncludeDeleted bool `json:"include_deleted"`
 }
 
 type LocationGetListResponse struct {
@@ -0,0 +1,60 @@
+package models
+
+// ProductMedia is an image in the product gallery. FileId and Url are the
+// ones returned by POST /upload-files, the primary image is also the
+// product's image. An image may belong to one variant or color.
+type ProductMedia struct {
+	Id        string `json:"id"`
+	ProductId string `json:"product_id"`
+	FileId    string `json:"file_id,omitempty"`
+	Url       string `json:"url"`
+	Alt       string `json:"alt"`
+	Position  int    `json:"position"`
+	IsPrimary bool   `json:"is_primary"`
+	VariantId string `json:"variant_id,omitempty"`
+	ColorId   string `json:"color_id,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type ProductMediaCreate struct {
+	FileId    string `json:"file_id"`
+	Url       string `json:"url"`
+	Alt       string `json:"alt"`
+	IsPrimary bool   `json:"is_primary"`
+	VariantId string `json:"variant_id"`
+	ColorId   string `json:"color_id"`
+}
+
+// ProductMediaCreateRequest appends the images to the end of the gallery
+type ProductMediaCreateRequest struct {
+	ProductId string               `json:"-"`
+	Media     []ProductMediaCreate `json:"media"`
+}
+
+type ProductMediaUpdate struct {
+	Id        string `json:"-"`
+	Alt       string `json:"alt"`
+	IsPrimary bool   `json:"is_primary"`
+	VariantId string `json:"variant_id"`
+	ColorId   string `json:"color_id"`
+}
+
+// ProductMediaReorder lists every image of the product in the new order
+type ProductMediaReorder struct {
+	ProductId string   `json:"-"`
+	MediaIds  []string `json:"media_ids"`
+}
+
+type ProductMediaPrimaryKey struct {
+	Id string `json:"id"`
+}
+
+type ProductMediaGetListRequest struct {
+	ProductId string `json:"product_id"`
+}
+
+type ProductMediaGetListResponse struct {
+	Count int             `json:"count"`
+	Media []*ProductMedia `json:"media"`
+}
@@ -5,6 +5,7 @@ type OrderItems struct {
 	OrderId    string  `json:"order_id,omitempty"`
 	ProductId  string  `json:"product_id,omitempty"`
 	ColorId    string  `json:"color_id,omitempty"` // Yangi qo'shilgan maydon
+	VariantId  string  `json:"variant_id,omitempty"`
 	Quantity   int     `json:"quantity,omitempty"`
 	Price      float64 `json:"price,omitempty"`
 	TotalPrice float64 `json:"total,omitempty"`
@@ -13,9 +14,12 @@ type OrderItems struct {
 	DeletedAt  string  `json:"delete_at,omitempty"`
 }
 
+// SwaggerOrderItems takes variant_id for products with variants and
+// color_id for the others
 type SwaggerOrderItems struct {
 	ProductId string `json:"product_id,omitempty"`
 	ColorId   string `json:"color_id,omitempty"` // Yangi qo'shilgan maydon
+	VariantId string `json:"variant_id,omitempty"`
 	Quantity  int    `json:"quantity,omitempty"`
 }
 
@@ -0,0 +1,47 @@
+package models
+
+// Price change reasons written by the service itself, admins may give any
+// other reason with the change
+const (
+	PriceChangeReasonCreated           = "created"
+	PriceChangeReasonDiscountActivated = "discount_activated"
+	PriceChangeReasonDiscountExpired   = "discount_expired"
+	PriceChangeReasonImport            = "import"
+)
+
+// PriceHistory is a change of the product price, or of the variant's own
+// price when VariantId is set. EffectivePrice is what the customer paid,
+// the discounted price while a discount ran. The before values are empty on
+// the entry of a new product or variant.
+type PriceHistory struct {
+	Id                   string   `json:"id"`
+	ProductId            string   `json:"product_id"`
+	VariantId            string   `json:"variant_id,omitempty"`
+	PriceBefore          *float64 `json:"price_before"`
+	PriceAfter           *float64 `json:"price_after"`
+	WithDiscountBefore   *float64 `json:"with_discount_before"`
+	WithDiscountAfter    *float64 `json:"with_discount_after"`
+	EffectivePriceBefore *float64 `json:"effective_price_before"`
+	EffectivePriceAfter  *float64 `json:"effective_price_after"`
+	Reason               string   `json:"reason"`
+	ChangedBy            string   `json:"changed_by"`
+	CreatedAt            string   `json:"created_at"`
+}
+
+type PriceHistoryGetListRequest struct {
+	ProductId string `json:"product_id"`
+	VariantId string `json:"variant_id"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Offset    int    `json:"offset"`
+	Limit     int    `json:"limit"`
+}
+
+// PriceHistoryGetListResponse lists the changes newest first. LowestPrice30Days
+// is the lowest effective price of the product, or of the variant, over the
+// last 30 days including the current one.
+type PriceHistoryGetListResponse struct {
+	Count             int             `json:"count"`
+	LowestPrice30Days float64         `json:"lowest_price_30_days"`
+	History           []*PriceHistory `json:"history"`
+}
@@ -1,40 +1,63 @@
 package models
 
 type Product struct {
-	Id              string  `json:"id"`
-	CategoryId      string  `json:"category_id"`
-	BrandId         string  `json:"brand_id"`
-	Image           string  `json:"image"`
-	Favorite        bool    `json:"favorite"`
-	Name            string  `json:"name,omitempty"`
-	Price           float64 `json:"price,omitempty"`
-	WithDiscount    float64 `json:"with_discount"`
-	Rating          float64 `json:"rating,omitempty"`
-	Description     string  `json:"description,omitempty"`
-	ItemCount       int     `json:"item_count"`
-	Color           []Color `json:"color,omitempty"`
-	CreatedAt       string  `json:"created_at"`
-	UpdatedAt       string  `json:"updated_at,omitempty"`
-	DeletedAt       string  `json:"deleted_at,omitempty"`
-	Status          string  `json:"status"`
-	DiscountPercent float64 `json:"discount_percent"`
-	DiscountEndTime string  `json:"discount_end_time"`
+	Id           string  `json:"id"`
+	CategoryId   string  `json:"category_id"`
+	BrandId      string  `json:"brand_id"`
+	Image        string  `json:"image"`
+	IsFavorite   bool    `json:"is_favorite"`
+	Name         string  `json:"name,omitempty"`
+	Slug         string  `json:"slug"`
+	Price        float64 `json:"price,omitempty"`
+	WithDiscount float64 `json:"with_discount"`
+	MinPrice     float64 `json:"min_price"`
+	MaxPrice     float64 `json:"max_price"`
+	// Rating is the average of the approved reviews, ReviewCount their number
+	Rating      float64 `json:"rating,omitempty"`
+	ReviewCount int     `json:"review_count"`
+	Description string  `json:"description,omitempty"`
+	ItemCount   int     `json:"item_count"`
+	Color       []Color `json:"color,omitempty"`
+	// Name and Description are in the requested locale, the I18n fields
+	// hold every translation
+	NameI18n        Translations `json:"name_i18n,omitempty"`
+	DescriptionI18n Translations `json:"description_i18n,omitempty"`
+	// Variants and Media are only loaded by GetByID
+	Variants        []*ProductVariant `json:"variants,omitempty"`
+	Media           []*ProductMedia   `json:"media,omitempty"`
+	CreatedAt       string            `json:"created_at"`
+	UpdatedAt       string            `json:"updated_at,omitempty"`
+	DeletedAt       string            `json:"deleted_at,omitempty"`
+	Status          string            `json:"status"`
+	DiscountPercent float64           `json:"discount_percent"`
+	// DiscountStartTime is set while a discount is scheduled or running,
+	// WithDiscount is only set while it runs
+	DiscountStartTime string `json:"discount_start_time,omitempty"`
+	DiscountEndTime   string `json:"discount_end_time"`
 }
 
 type ProductCreate struct {
 	CategoryId      string  `json:"category_id"`
 	BrandId         string  `json:"brand_id,omitempty"`
 	Image           string  `json:"image,omitempty"`
-	Favorite        bool    `json:"favorite"`
 	Name            string  `json:"name"`
 	Price           float64 `json:"price"`
 	With_discount   float64 `json:"with_discount"`
-	Rating          float64 `json:"rating"`
 	Description     string  `json:"description"`
 	ItemCount       int     `json:"item_count,omitempty"`
 	Status          string  `json:"status"`
 	DiscountPercent float64 `json:"discount_percent"`
-	DiscountEndTime string  `json:"discount_end_time"`
+	// DiscountStartTime and DiscountEndTime are RFC3339, the discount of the
+	// vremennaya_skidka status starts now when the start time is empty
+	DiscountStartTime string `json:"discount_start_time"`
+	DiscountEndTime   string `json:"discount_end_time"`
+	// NameI18n and DescriptionI18n are the translations of Name and
+	// Description by locale, an update keeps the stored ones when nil
+	NameI18n        Translations `json:"name_i18n"`
+	DescriptionI18n Translations `json:"description_i18n"`
+	// PriceChangeReason is kept in the price history when the price changes
+	PriceChangeReason string `json:"price_change_reason"`
+	PriceChangedBy    string `json:"-"`
 }
 
 type ProductUpdate struct {
@@ -42,31 +65,105 @@ type ProductUpdate struct {
 	CategoryId      string  `json:"category_id"`
 	BrandId         string  `json:"brand_id,omitempty"`
 	Image           string  `json:"image,omitempty"`
-	Favorite        bool    `json:"favorite"`
 	Name            string  `json:"name"`
 	Price           float64 `json:"price"`
 	With_discount   float64 `json:"with_discount"`
-	Rating          float64 `json:"rating"`
 	Description     string  `json:"description"`
 	ItemCount       int     `json:"item_count,omitempty"`
 	Status          string  `json:"status"`
 	DiscountPercent float64 `json:"discount_percent"`
-	DiscountEndTime string  `json:"discount_end_time"`
+	// DiscountStartTime and DiscountEndTime are RFC3339, the discount of the
+	// vremennaya_skidka status starts now when the start time is empty
+	DiscountStartTime string `json:"discount_start_time"`
+	DiscountEndTime   string `json:"discount_end_time"`
+	// NameI18n and DescriptionI18n are the translations of Name and
+	// Description by locale, an update keeps the stored ones when nil
+	NameI18n        Translations `json:"name_i18n"`
+	DescriptionI18n Translations `json:"description_i18n"`
+	// PriceChangeReason is kept in the price history when the price changes
+	PriceChangeReason string `json:"price_change_reason"`
+	PriceChangedBy    string `json:"-"`
 }
 
 type ProductPrimaryKey struct {
-	Id string `json:"id"`
+	Id   string `json:"id"`
+	Slug string `json:"slug"`
+	// CustomerId is the caller is_favorite is reported for
+	CustomerId string `json:"-"`
+	// Lang is the locale of the texts, the default locale when empty
+	Lang string `json:"-"`
+	// IncludeDeleted finds soft deleted rows as well
+	IncludeDeleted bool `json:"-"`
 }
+
+// Product statuses, the values of the product_status enum
+const (
+	ProductStatusNew      = "novinka"
+	ProductStatusSale     = "rasprodaja"
+	ProductStatusDiscount = "vremennaya_skidka"
+)
+
+// Product list sort options, newest is the default unless searching by name
+const (
+	ProductSortNewest    = "newest"
+	ProductSortPriceAsc  = "price_asc"
+	ProductSortPriceDesc = "price_desc"
+	ProductSortRating    = "rating"
+	ProductSortPopular   = "popular"
+)
+
 type ProductGetListRequest struct {
-	CategoryId string `json:"category_id"`
-	BrandId    string `json:"brand_id"`
-	Favorite   *bool  `json:"favorite"`
-	Offset     int    `json:"offset"`
-	Limit      int    `json:"limit"`
-	Name       string `json:"name"`
+	CategoryId string   `json:"category_id"`
+	BrandIds   []string `json:"brand_ids"`
+	// Favorite keeps only the products in, or not in, the wishlist of the
+	// customer given by CustomerId, who is also the one is_favorite is for
+	Favorite   *bool    `json:"favorite"`
+	CustomerId string   `json:"customer_id"`
+	Lang       string   `json:"lang"`
+	Offset     int      `json:"offset"`
+	Limit      int      `json:"limit"`
+	Name       string   `json:"name"`
+	Statuses   []string `json:"statuses"`
+	Colors     []string `json:"colors"`
+	MinPrice   *float64 `json:"min_price"`
+	MaxPrice   *float64 `json:"max_price"`
+	MinRating  *float64 `json:"min_rating"`
+	InStock    bool     `json:"in_stock"`
+	Sort       string   `json:"sort"`
+	// IncludeDeleted lists soft deleted rows as well
+	IncludeDeleted bool `json:"include_deleted"`
 }
 
 type ProductGetListResponse struct {
-	Count   int       `json:"count"`
-	Product []Product `json:"product"`
+	Count   int            `json:"count"`
+	Product []Product      `json:"product"`
+	Facets  *ProductFacets `json:"facets,omitempty"`
+}
+
+// ProductFacets counts the products matching the filters per brand, status
+// and price bucket. Each facet ignores its own filter so the other options
+// stay visible.
+type ProductFacets struct {
+	Brands   []BrandFacet  `json:"brands"`
+	Statuses []StatusFacet `json:"statuses"`
+	Prices   []PriceFacet  `json:"prices"`
+}
+
+type BrandFacet struct {
+	BrandId string `json:"brand_id"`
+	Name    string `json:"name"`
+	Count   int    `json:"count"`
+}
+
+type StatusFacet struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+// PriceFacet covers prices from From up to but not including To, the last
+// bucket has no upper bound and To is omitted.
+type PriceFacet struct {
+	From  float64  `json:"from"`
+	To    *float64 `json:"to,omitempty"`
+	Count int      `json:"count"`
 }
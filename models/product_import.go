@@ -0,0 +1,78 @@
+package models
+
+// Columns of the product import and export files, a file has a row per
+// product color and a product without colors has a single row
+const (
+	ProductColumnSlug        = "slug"
+	ProductColumnName        = "name"
+	ProductColumnCategory    = "category"
+	ProductColumnBrand       = "brand"
+	ProductColumnPrice       = "price"
+	ProductColumnDescription = "description"
+	ProductColumnImage       = "image"
+	ProductColumnStatus      = "status"
+	ProductColumnColor       = "color"
+	ProductColumnColorUrl    = "color_url"
+	ProductColumnCount       = "count"
+)
+
+// ProductColumns is the column order of the export
+var ProductColumns = []string{
+	ProductColumnSlug,
+	ProductColumnName,
+	ProductColumnCategory,
+	ProductColumnBrand,
+	ProductColumnPrice,
+	ProductColumnDescription,
+	ProductColumnImage,
+	ProductColumnStatus,
+	ProductColumnColor,
+	ProductColumnColorUrl,
+	ProductColumnCount,
+}
+
+// ProductCatalogRow is a row of the import or export file. Category and
+// Brand are names or slugs, Count is nil when the stock is not given or is
+// kept by the product variants.
+type ProductCatalogRow struct {
+	Line        int
+	Slug        string
+	Name        string
+	Category    string
+	Brand       string
+	Price       float64
+	Description string
+	Image       string
+	Status      string
+	Color       string
+	ColorUrl    []string
+	Count       *int
+}
+
+type ProductImportRequest struct {
+	Rows []ProductCatalogRow
+	// DryRun checks the rows against the catalog without saving them
+	DryRun     bool
+	ImportedBy string
+}
+
+// ProductImportError tells why a row of the file can not be imported, Line
+// is the line of the file and Column is empty when the whole row is wrong
+type ProductImportError struct {
+	Line    int    `json:"line"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// ProductImportReport is the outcome of an import. Nothing is saved when a
+// row has an error, the counts then tell what a fixed file would change.
+type ProductImportReport struct {
+	DryRun          bool                 `json:"dry_run"`
+	Applied         bool                 `json:"applied"`
+	Rows            int                  `json:"rows"`
+	ProductsCreated int                  `json:"products_created"`
+	ProductsUpdated int                  `json:"products_updated"`
+	ColorsCreated   int                  `json:"colors_created"`
+	ColorsUpdated   int                  `json:"colors_updated"`
+	Errors          []ProductImportError `json:"errors"`
+}
@@ -0,0 +1,43 @@
+package models
+
+// Kinds of product recommendations
+const (
+	// RecommendationBoughtTogether ranks the products ordered together with
+	// the product first
+	RecommendationBoughtTogether = "bought_together"
+	// RecommendationRelated ranks the products of the same category and
+	// brand first
+	RecommendationRelated = "related"
+)
+
+// Sources a recommended product comes from, in the order they are tried
+const (
+	RecommendationSourceBoughtTogether = "bought_together"
+	RecommendationSourceSameCategory   = "same_category"
+	RecommendationSourceSameBrand      = "same_brand"
+)
+
+// ProductRecommendationRequest asks for the products to recommend next to
+// ProductId, only products in stock are recommended
+type ProductRecommendationRequest struct {
+	ProductId string `json:"product_id"`
+	Kind      string `json:"kind"`
+	Limit     int    `json:"limit"`
+	// CustomerId is the caller is_favorite is reported for
+	CustomerId string `json:"-"`
+	Lang       string `json:"-"`
+}
+
+// ProductRecommendation is a recommended product with where it comes from,
+// Score is the co-purchase affinity with the product, 0 when never ordered
+// together
+type ProductRecommendation struct {
+	Source  string  `json:"source"`
+	Score   float64 `json:"score"`
+	Product Product `json:"product"`
+}
+
+type ProductRecommendationResponse struct {
+	Count           int                      `json:"count"`
+	Recommendations []*ProductRecommendation `json:"recommendations"`
+}
@@ -0,0 +1,81 @@
+package models
+
+// Review statuses, new reviews wait for an admin to approve them
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// Review list sort options
+const (
+	ReviewSortNewest     = "newest"
+	ReviewSortRatingDesc = "rating_desc"
+	ReviewSortRatingAsc  = "rating_asc"
+)
+
+type Review struct {
+	Id             string   `json:"id"`
+	ProductId      string   `json:"product_id"`
+	CustomerId     string   `json:"customer_id"`
+	CustomerName   string   `json:"customer_name"`
+	Rating         int      `json:"rating"`
+	Text           string   `json:"text"`
+	Photos         []string `json:"photos"`
+	Status         string   `json:"status"`
+	ModerationNote string   `json:"moderation_note,omitempty"`
+	ModeratedBy    string   `json:"moderated_by,omitempty"`
+	ModeratedAt    string   `json:"moderated_at,omitempty"`
+	CreatedAt      string   `json:"created_at"`
+	UpdatedAt      string   `json:"updated_at,omitempty"`
+}
+
+// ReviewCreate is posted by a customer with a delivered order of the
+// product, Photos are urls returned by POST /review/photos
+type ReviewCreate struct {
+	ProductId  string   `json:"-"`
+	CustomerId string   `json:"-"`
+	Rating     int      `json:"rating"`
+	Text       string   `json:"text"`
+	Photos     []string `json:"photos"`
+}
+
+type ReviewModerate struct {
+	Id             string `json:"-"`
+	Status         string `json:"status"`
+	ModerationNote string `json:"moderation_note"`
+	ModeratedBy    string `json:"-"`
+}
+
+type ReviewPrimaryKey struct {
+	Id string `json:"id"`
+}
+
+type ReviewGetListRequest struct {
+	ProductId  string `json:"product_id"`
+	CustomerId string `json:"customer_id"`
+	Status     string `json:"status"`
+	Rating     int    `json:"rating"`
+	WithPhotos bool   `json:"with_photos"`
+	Sort       string `json:"sort"`
+	Offset     int    `json:"offset"`
+	Limit      int    `json:"limit"`
+}
+
+type ReviewGetListResponse struct {
+	Count   int            `json:"count"`
+	Summary *ReviewSummary `json:"summary,omitempty"`
+	Reviews []*Review      `json:"reviews"`
+}
+
+// ReviewSummary is the rating of a product over its approved reviews
+type ReviewSummary struct {
+	Rating       float64       `json:"rating"`
+	ReviewCount  int           `json:"review_count"`
+	Distribution []RatingCount `json:"distribution"`
+}
+
+type RatingCount struct {
+	Rating int `json:"rating"`
+	Count  int `json:"count"`
+}
@@ -0,0 +1,42 @@
+package models
+
+type Role struct {
+	Id          string   `json:"id,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Permissions []string `json:"permissions"`
+	CreatedAt   string   `json:"created_at,omitempty"`
+	UpdatedAt   string   `json:"updated_at,omitempty"`
+}
+
+type RoleCreate struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type RoleUpdate struct {
+	Id          string   `json:"-"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type RolePrimaryKey struct {
+	Id string `json:"id"`
+}
+
+type RoleGetListRequest struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type RoleGetListResponse struct {
+	Count int     `json:"count"`
+	Roles []*Role `json:"roles"`
+}
+
+type AdminRoleUpdate struct {
+	AdminId string `json:"-"`
+	RoleId  string `json:"role_id"`
+}
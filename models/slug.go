@@ -0,0 +1,14 @@
+package models
+
+// Entities with slugs, the values are also their table names
+const (
+	SlugEntityProduct  = "product"
+	SlugEntityCategory = "category"
+	SlugEntityBrand    = "brand"
+)
+
+// SlugRedirectRequest looks up an old slug of an entity
+type SlugRedirectRequest struct {
+	EntityType string `json:"entity_type"`
+	Slug       string `json:"slug"`
+}
@@ -0,0 +1,29 @@
+package models
+
+// Reasons an admin adjusts the stock of a color or a variant for
+const (
+	StockReasonRestock    = "restock"
+	StockReasonDamage     = "damage"
+	StockReasonCorrection = "correction"
+	StockReasonReturn     = "return"
+)
+
+// StockAdjustment changes the stock by Delta, a restock adds to it, a damage
+// takes from it and a correction goes either way. A return adds back what
+// the order with OrderId bought.
+type StockAdjustment struct {
+	Id        string `json:"-"`
+	Delta     int    `json:"delta"`
+	Reason    string `json:"reason"`
+	OrderId   string `json:"order_id"`
+	ChangedBy string `json:"-"`
+}
+
+// StockAdjustmentResponse is the stock right before and after the adjustment
+type StockAdjustmentResponse struct {
+	Id          string `json:"id"`
+	Reason      string `json:"reason"`
+	Delta       int    `json:"delta"`
+	CountBefore int    `json:"count_before"`
+	Count       int    `json:"count"`
+}
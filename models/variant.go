@@ -0,0 +1,60 @@
+package models
+
+// ProductVariant is a sellable combination of option values of a product,
+// like color × memory × size. Price overrides the product price when set,
+// EffectivePrice is what the customer pays with the product discount applied.
+type ProductVariant struct {
+	Id             string            `json:"id"`
+	ProductId      string            `json:"product_id"`
+	Sku            string            `json:"sku"`
+	Options        map[string]string `json:"options"`
+	Price          *float64          `json:"price"`
+	EffectivePrice float64           `json:"effective_price"`
+	Count          int               `json:"count"`
+	Images         []string          `json:"images"`
+	ColorId        string            `json:"color_id,omitempty"`
+	CreatedAt      string            `json:"created_at,omitempty"`
+	UpdatedAt      string            `json:"updated_at,omitempty"`
+}
+
+// ProductVariantCreate generates the sku from the product slug and the option
+// values when it is empty
+type ProductVariantCreate struct {
+	ProductId string            `json:"-"`
+	Sku       string            `json:"sku"`
+	Options   map[string]string `json:"options"`
+	Price     *float64          `json:"price"`
+	Count     int               `json:"count"`
+	Images    []string          `json:"images"`
+	ColorId   string            `json:"color_id"`
+	// PriceChangeReason is kept in the price history with the price
+	PriceChangeReason string `json:"price_change_reason"`
+	PriceChangedBy    string `json:"-"`
+}
+
+// ProductVariantUpdate leaves the stock as it is, the stock is changed by
+// stock adjustments only so a checkout running meanwhile is kept
+type ProductVariantUpdate struct {
+	Id      string            `json:"-"`
+	Sku     string            `json:"sku"`
+	Options map[string]string `json:"options"`
+	Price   *float64          `json:"price"`
+	Images  []string          `json:"images"`
+	ColorId string            `json:"color_id"`
+	// PriceChangeReason is kept in the price history when the price changes
+	PriceChangeReason string `json:"price_change_reason"`
+	PriceChangedBy    string `json:"-"`
+}
+
+type ProductVariantPrimaryKey struct {
+	Id string `json:"id"`
+}
+
+type ProductVariantGetListRequest struct {
+	ProductId string `json:"product_id"`
+}
+
+type ProductVariantGetListResponse struct {
+	Count    int               `json:"count"`
+	Variants []*ProductVariant `json:"variants"`
+}
@@ -8,6 +8,16 @@ import (
 	"github.com/dgrijalva/jwt-go"
 )
 
+const (
+	AccessTokenType  = "access"
+	RefreshTokenType = "refresh"
+
+	AccessTokenTTL  = 24 * time.Hour
+	RefreshTokenTTL = 10 * 24 * time.Hour
+)
+
+// GenJWT signs an access and a refresh token with the given claims.
+// The "jti" claim, when present, is only put into the refresh token.
 func GenJWT(m map[interface{}]interface{}) (string, string, error) {
 	var (
 		accessToken, refreshToken *jwt.Token
@@ -21,17 +31,23 @@ func GenJWT(m map[interface{}]interface{}) (string, string, error) {
 	rClaims := refreshToken.Claims.(jwt.MapClaims)
 
 	for k, v := range m {
+		if k.(string) == "jti" {
+			rClaims["jti"] = v
+			continue
+		}
 		claims[k.(string)] = v
 		rClaims[k.(string)] = v
 	}
 
 	claims["iss"] = "user"
 	claims["iat"] = time.Now().Unix()
-	claims["exp"] = time.Now().AddDate(0, 0, 1).Unix()
+	claims["exp"] = time.Now().Add(AccessTokenTTL).Unix()
+	claims["token_type"] = AccessTokenType
 
 	rClaims["iss"] = "user"
 	rClaims["iat"] = time.Now().Unix()
-	rClaims["exp"] = time.Now().AddDate(0, 0, 10).Unix()
+	rClaims["exp"] = time.Now().Add(RefreshTokenTTL).Unix()
+	rClaims["token_type"] = RefreshTokenType
 
 	accessTokenString, err := accessToken.SignedString(config.SignedKey)
 	if err != nil {
@@ -49,22 +65,14 @@ func GenJWT(m map[interface{}]interface{}) (string, string, error) {
 }
 
 func ExtractClaims(tokenStr string) (jwt.MapClaims, error) {
-	var (
-		token *jwt.Token
-		err   error
-	)
-	token, err = jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-		// check token signing method etc
+	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
 		return config.SignedKey, nil
 	})
 	if err != nil {
-		token, err = jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			// check token signing method etc
-			return config.SignedKey, nil
-		})
-		if err != nil {
-			return nil, err
-		}
+		return nil, err
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
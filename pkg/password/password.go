@@ -0,0 +1,49 @@
+package password
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrMismatchedPassword = errors.New("password mismatch")
+
+// HashPassword returns the bcrypt hash of the password.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// IsHashed reports whether the stored value is a bcrypt hash rather than a
+// legacy plaintext password.
+func IsHashed(stored string) bool {
+	_, err := bcrypt.Cost([]byte(stored))
+	return err == nil
+}
+
+// CompareHashAndPassword checks the password against the stored value.
+// Legacy plaintext values are compared in constant time so they can be
+// re-hashed after the first successful login.
+func CompareHashAndPassword(stored, password string) error {
+	if stored == "" {
+		return ErrMismatchedPassword
+	}
+
+	if !IsHashed(stored) {
+		if subtle.ConstantTimeCompare([]byte(stored), []byte(password)) != 1 {
+			return ErrMismatchedPassword
+		}
+		return nil
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(stored), []byte(password)); err != nil {
+		return ErrMismatchedPassword
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryCounter struct {
+	start    time.Time
+	window   time.Duration
+	current  int64
+	previous int64
+}
+
+// memoryLimiter keeps counters in process memory. It only limits correctly
+// when a single instance serves the traffic, so it is meant for dev runs
+// and as a fallback when redis is unavailable.
+type memoryLimiter struct {
+	mu       sync.Mutex
+	counters map[string]*memoryCounter
+	calls    int
+}
+
+func NewMemoryLimiter() *memoryLimiter {
+	return &memoryLimiter{
+		counters: make(map[string]*memoryCounter),
+	}
+}
+
+func (m *memoryLimiter) Allow(ctx context.Context, policy Policy, key string) (bool, time.Duration, error) {
+	allowed, retryAfter := m.allow(time.Now(), policy, key)
+	return allowed, retryAfter, nil
+}
+
+func (m *memoryLimiter) allow(now time.Time, policy Policy, key string) (bool, time.Duration) {
+	start := windowStart(now, policy.Window)
+	id := policy.Name + ":" + key
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+	if m.calls%1000 == 0 {
+		m.sweep(now)
+	}
+
+	counter, ok := m.counters[id]
+	switch {
+	case !ok:
+		counter = &memoryCounter{start: start, window: policy.Window}
+		m.counters[id] = counter
+	case counter.start.Equal(start.Add(-policy.Window)):
+		counter.previous, counter.current, counter.start = counter.current, 0, start
+	case !counter.start.Equal(start):
+		counter.previous, counter.current, counter.start = 0, 0, start
+	}
+
+	counter.current++
+
+	return decide(policy, now.Sub(start), counter.current, counter.previous)
+}
+
+// sweep drops counters that are too old to affect their own policy's window,
+// counters of longer policies outlive a sweep made by a shorter one.
+func (m *memoryLimiter) sweep(now time.Time) {
+	for id, counter := range m.counters {
+		if now.Sub(counter.start) > 2*counter.window {
+			delete(m.counters, id)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestMemoryLimiterSweepKeepsLongerPolicies(t *testing.T) {
+	var (
+		limiter = NewMemoryLimiter()
+		short   = Policy{Name: "user", Limit: 120, Window: time.Minute}
+		long    = Policy{Name: "sms_ip", Limit: 3, Window: time.Hour}
+		now     = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	)
+
+	for i := 0; i < 3; i++ {
+		if allowed, _ := limiter.allow(now, long, "ip"); !allowed {
+			t.Fatalf("request %d of the long policy is denied", i+1)
+		}
+	}
+
+	// enough short policy traffic minutes later to run a sweep
+	later := now.Add(5 * time.Minute)
+	for i := 0; i < 1000; i++ {
+		limiter.allow(later, short, strconv.Itoa(i))
+	}
+
+	if allowed, retryAfter := limiter.allow(later, long, "ip"); allowed || retryAfter <= 0 {
+		t.Fatalf("long policy counter was swept by the short policy, allowed=%v retryAfter=%v", allowed, retryAfter)
+	}
+
+	// the short policy counters are gone once they can not affect their window
+	limiter.sweep(later.Add(3 * time.Minute))
+	if _, ok := limiter.counters[short.Name+":0"]; ok {
+		t.Fatal("expired short policy counter was not swept")
+	}
+	if _, ok := limiter.counters[long.Name+":ip"]; !ok {
+		t.Fatal("long policy counter was swept before its window passed")
+	}
+}
+
+func TestMemoryLimiterSlidingWindow(t *testing.T) {
+	var (
+		limiter = NewMemoryLimiter()
+		policy  = Policy{Name: "login_account", Limit: 2, Window: time.Minute}
+		now     = time.Date(2024, 1, 1, 10, 0, 30, 0, time.UTC)
+	)
+
+	limiter.allow(now, policy, "a")
+	limiter.allow(now, policy, "a")
+	if allowed, _ := limiter.allow(now, policy, "a"); allowed {
+		t.Fatal("third request in the window is allowed")
+	}
+
+	// half of the previous window still overlaps, its three counted requests
+	// weigh 1.5 and the new one tips it over the limit
+	if allowed, _ := limiter.allow(now.Add(time.Minute), policy, "a"); allowed {
+		t.Fatal("request right after the window boundary ignores the previous window")
+	}
+	if allowed, _ := limiter.allow(now.Add(2*time.Minute), policy, "b"); !allowed {
+		t.Fatal("request of another key is denied")
+	}
+}
@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Policy allows Limit requests per Window for a single key.
+type Policy struct {
+	Name   string
+	Limit  int64
+	Window time.Duration
+}
+
+// ParsePolicy parses a policy written as "<limit>/<window>", e.g. "5/10m".
+func ParsePolicy(name, value string) (Policy, error) {
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return Policy{}, fmt.Errorf("rate limit %s: expected <limit>/<window>, got %q", name, value)
+	}
+
+	limit, err := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+	if err != nil || limit <= 0 {
+		return Policy{}, fmt.Errorf("rate limit %s: invalid limit %q", name, parts[0])
+	}
+
+	window, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil || window < time.Second {
+		return Policy{}, fmt.Errorf("rate limit %s: invalid window %q", name, parts[1])
+	}
+
+	return Policy{
+		Name:   name,
+		Limit:  limit,
+		Window: window,
+	}, nil
+}
+
+// Limiter counts requests per key with a sliding window: the count of the
+// current fixed window is added to the previous window's count weighted by
+// how much of it still overlaps the sliding window.
+type Limiter interface {
+	// Allow counts the request and reports whether it fits the policy,
+	// when it does not retryAfter tells how long the caller should wait.
+	Allow(ctx context.Context, policy Policy, key string) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// decide applies the sliding window to the counts of the current and
+// previous fixed windows.
+func decide(policy Policy, elapsed time.Duration, current, previous int64) (bool, time.Duration) {
+	remaining := policy.Window - elapsed
+	weighted := float64(previous)*float64(remaining)/float64(policy.Window) + float64(current)
+
+	if weighted <= float64(policy.Limit) {
+		return true, 0
+	}
+
+	// the current window alone is over the limit, wait until it slides out
+	if current >= policy.Limit || previous == 0 {
+		return false, roundUp(remaining)
+	}
+
+	// wait until enough of the previous window slides out
+	wait := remaining - time.Duration(float64(policy.Limit-current)*float64(policy.Window)/float64(previous))
+	return false, roundUp(wait)
+}
+
+func windowStart(now time.Time, window time.Duration) time.Time {
+	return now.Truncate(window)
+}
+
+func roundUp(d time.Duration) time.Duration {
+	if d < time.Second {
+		return time.Second
+	}
+	return d.Round(time.Second)
+}
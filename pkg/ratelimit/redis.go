@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"e-commerce/pkg/logger"
+	"e-commerce/storage"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/cast"
+)
+
+// redisLimiter shares counters between instances through redis. When redis
+// fails the request is counted by the in-memory fallback instead.
+type redisLimiter struct {
+	redis    storage.RedisI
+	log      logger.LoggerI
+	fallback *memoryLimiter
+}
+
+func NewRedisLimiter(redis storage.RedisI, log logger.LoggerI) *redisLimiter {
+	return &redisLimiter{
+		redis:    redis,
+		log:      log,
+		fallback: NewMemoryLimiter(),
+	}
+}
+
+func (r *redisLimiter) Allow(ctx context.Context, policy Policy, key string) (bool, time.Duration, error) {
+	now := time.Now()
+	start := windowStart(now, policy.Window)
+
+	current, previous, err := r.counts(ctx, policy, key, start)
+	if err != nil {
+		r.log.Error("error while counting rate limit in redis, using memory", logger.Error(err))
+		return r.fallback.Allow(ctx, policy, key)
+	}
+
+	allowed, retryAfter := decide(policy, now.Sub(start), current, previous)
+	return allowed, retryAfter, nil
+}
+
+func (r *redisLimiter) counts(ctx context.Context, policy Policy, key string, start time.Time) (int64, int64, error) {
+	current, err := r.redis.Incr(ctx, rateLimitKey(policy, key, start), 2*policy.Window)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	previous, err := r.redis.Get(ctx, rateLimitKey(policy, key, start.Add(-policy.Window)))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return current, 0, nil
+		}
+		return 0, 0, err
+	}
+
+	return current, cast.ToInt64(previous), nil
+}
+
+func rateLimitKey(policy Policy, key string, start time.Time) string {
+	return fmt.Sprintf("rate_limit:%s:%s:%d", policy.Name, key, start.Unix())
+}
@@ -0,0 +1,57 @@
+package slug
+
+import (
+	"strings"
+	"unicode"
+)
+
+// cyrillic maps Uzbek and Russian Cyrillic letters to the Uzbek Latin
+// alphabet without its apostrophes
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "j", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "x", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "sh", 'ъ': "",
+	'ы': "i", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya", 'ў': "o", 'қ': "q",
+	'ғ': "g", 'ҳ': "h",
+}
+
+// apostrophes are dropped so o‘zbek and o'zbek give the same slug
+var apostrophes = "'`‘’ʻʼ"
+
+// Make turns a name into a lowercase slug of latin letters, digits and
+// single dashes, "Oʻzbekiston Телефон 15" becomes "ozbekiston-telefon-15".
+// Letters it can not transliterate are treated as separators.
+func Make(name string) string {
+	var (
+		b    strings.Builder
+		dash bool
+	)
+
+	for _, r := range strings.ToLower(name) {
+		if strings.ContainsRune(apostrophes, r) {
+			continue
+		}
+
+		latin, ok := cyrillic[r]
+		switch {
+		case ok:
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			latin = string(r)
+		default:
+			dash = b.Len() > 0
+			continue
+		}
+
+		if latin == "" {
+			continue
+		}
+		if dash {
+			b.WriteByte('-')
+			dash = false
+		}
+		b.WriteString(latin)
+	}
+
+	return b.String()
+}
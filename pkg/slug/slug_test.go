@@ -0,0 +1,28 @@
+package slug
+
+import "testing"
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"iPhone 15 Pro", "iphone-15-pro"},
+		{"Oʻzbekiston Телефон 15", "ozbekiston-telefon-15"},
+		{"o‘zbek o'zbek", "ozbek-ozbek"},
+		{"Щётка для зубов", "shyotka-dlya-zubov"},
+		{"Қўл соати ҳақида", "qol-soati-haqida"},
+		{"Объявление", "obyavlenie"},
+		{"  --Samsung,  Galaxy!! ", "samsung-galaxy"},
+		{"Café 50%", "caf-50"},
+		{"AirPods 2", "airpods-2"},
+		{"", ""},
+		{"!!!", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Make(tt.name); got != tt.want {
+			t.Errorf("Make(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const eskizBaseURL = "https://notify.eskiz.uz/api"
+
+var errEskizUnauthorized = errors.New("eskiz: unauthorized")
+
+// eskizSender sends messages through notify.eskiz.uz. The API token is
+// fetched on first use and kept in memory, it is refreshed once when
+// eskiz answers 401.
+type eskizSender struct {
+	email    string
+	password string
+	from     string
+	client   *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+func NewEskizSender(email, password, from string) *eskizSender {
+	return &eskizSender{
+		email:    email,
+		password: password,
+		from:     from,
+		client:   &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (e *eskizSender) Send(ctx context.Context, phone, message string) error {
+	token, err := e.getToken(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	err = e.send(ctx, token, phone, message)
+	if !errors.Is(err, errEskizUnauthorized) {
+		return err
+	}
+
+	token, err = e.getToken(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	return e.send(ctx, token, phone, message)
+}
+
+func (e *eskizSender) send(ctx context.Context, token, phone, message string) error {
+	formData := url.Values{}
+	formData.Add("mobile_phone", strings.TrimPrefix(phone, "+"))
+	formData.Add("message", message)
+	formData.Add("from", e.from)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, eskizBaseURL+"/message/sms/send", strings.NewReader(formData.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return errEskizUnauthorized
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("eskiz: unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// getToken returns the cached token, or logs in again when there is none or refresh is set.
+func (e *eskizSender) getToken(ctx context.Context, refresh bool) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.token != "" && !refresh {
+		return e.token, nil
+	}
+
+	formData := url.Values{}
+	formData.Set("email", e.email)
+	formData.Set("password", e.password)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, eskizBaseURL+"/auth/login", strings.NewReader(formData.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("eskiz: login failed with status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Token string `json:"token"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.Data.Token == "" {
+		return "", errors.New("eskiz: empty token in login response")
+	}
+
+	e.token = body.Data.Token
+
+	return e.token, nil
+}
@@ -0,0 +1,47 @@
+package sms
+
+import (
+	"context"
+	"e-commerce/pkg/logger"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// logSender does not send anything, it writes messages to the logger and,
+// when path is set, appends them to a file. Used in local and test runs.
+type logSender struct {
+	log  logger.LoggerI
+	path string
+
+	mu sync.Mutex
+}
+
+func NewLogSender(log logger.LoggerI, path string) *logSender {
+	return &logSender{
+		log:  log,
+		path: path,
+	}
+}
+
+func (l *logSender) Send(ctx context.Context, phone, message string) error {
+	l.log.Info("sms", logger.String("phone", phone), logger.String("message", message))
+
+	if l.path == "" {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "%s\t%s\t%s\n", time.Now().Format(time.RFC3339), phone, message)
+
+	return err
+}
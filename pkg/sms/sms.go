@@ -0,0 +1,41 @@
+package sms
+
+import (
+	"context"
+	"e-commerce/config"
+	"e-commerce/pkg/logger"
+	"fmt"
+)
+
+const (
+	ProviderEskiz  = "eskiz"
+	ProviderTwilio = "twilio"
+	ProviderLog    = "log"
+)
+
+// SmsSender delivers a text message to a phone number.
+type SmsSender interface {
+	Send(ctx context.Context, phone, message string) error
+}
+
+// New returns the sender for the provider set in cfg.SmsProvider. A real
+// provider without its credentials is an error, so a misconfigured service
+// fails at startup instead of at the first login.
+func New(cfg config.Config, log logger.LoggerI) (SmsSender, error) {
+	switch cfg.SmsProvider {
+	case ProviderEskiz:
+		if cfg.EskizEmail == "" || cfg.EskizPassword == "" || cfg.EskizFrom == "" {
+			return nil, fmt.Errorf("sms provider %q needs ESKIZ_EMAIL, ESKIZ_PASSWORD and ESKIZ_FROM", cfg.SmsProvider)
+		}
+		return NewEskizSender(cfg.EskizEmail, cfg.EskizPassword, cfg.EskizFrom), nil
+	case ProviderTwilio:
+		if cfg.TwilioAccountSID == "" || cfg.TwilioAuthToken == "" || cfg.TwilioPhoneNumber == "" {
+			return nil, fmt.Errorf("sms provider %q needs TWILIO_ACCOUNT_SID, TWILIO_AUTH_TOKEN and TWILIO_PHONE_NUMBER", cfg.SmsProvider)
+		}
+		return NewTwilioSender(cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.TwilioPhoneNumber), nil
+	case ProviderLog:
+		return NewLogSender(log, cfg.SmsLogFile), nil
+	}
+
+	return nil, fmt.Errorf("unknown sms provider %q", cfg.SmsProvider)
+}
@@ -0,0 +1,61 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const twilioBaseURL = "https://api.twilio.com/2010-04-01"
+
+// twilioSender sends messages through the Twilio Messages API.
+type twilioSender struct {
+	accountSID string
+	authToken  string
+	from       string
+	client     *http.Client
+}
+
+func NewTwilioSender(accountSID, authToken, from string) *twilioSender {
+	return &twilioSender{
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		client:     &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (t *twilioSender) Send(ctx context.Context, phone, message string) error {
+	if !strings.HasPrefix(phone, "+") {
+		phone = "+" + phone
+	}
+
+	formData := url.Values{}
+	formData.Set("To", phone)
+	formData.Set("From", t.from)
+	formData.Set("Body", message)
+
+	endpoint := fmt.Sprintf("%s/Accounts/%s/Messages.json", twilioBaseURL, t.accountSID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(formData.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(t.accountSID, t.authToken)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("twilio: unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+// Package spreadsheet reads and writes the first sheet of CSV and XLSX files
+// as rows of text cells.
+package spreadsheet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// ErrUnsupportedFormat is returned for formats other than csv and xlsx
+var ErrUnsupportedFormat = errors.New("only csv and xlsx files are supported")
+
+// ErrTooManyRows is returned by Read when the file has more filled rows than
+// it was asked to read
+var ErrTooManyRows = errors.New("file has too many rows")
+
+// ErrTooManyCells is returned by Read when the filled rows together have more
+// than maxCells cells
+var ErrTooManyCells = errors.New("file has too many cells")
+
+// maxCells limits the cells of the rows kept in memory, the width of an xlsx
+// row comes from the cell references in the file
+const maxCells = 1 << 20
+
+// utf8BOM is written at the start of CSV files so Excel reads them as UTF-8
+const utf8BOM = "\uFEFF"
+
+// Row holds the cells of a row and the line of the file it starts on,
+// counting from 1
+type Row struct {
+	Line  int
+	Cells []string
+}
+
+// Writer writes rows of cells to a file in one of the formats
+type Writer interface {
+	WriteRow(cells []string) error
+	// Close flushes the rows, it does not close the underlying writer
+	Close() error
+}
+
+// Read returns the rows of the file that have a cell with text in it. It
+// stops with ErrTooManyRows at the first filled row past maxRows.
+func Read(format string, r io.ReaderAt, size int64, maxRows int) ([]Row, error) {
+	var (
+		rows = &rowSet{maxRows: maxRows}
+		err  error
+	)
+
+	switch format {
+	case FormatCSV:
+		err = readCSV(io.NewSectionReader(r, 0, size), rows)
+	case FormatXLSX:
+		err = readXLSX(r, size, rows)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rows.rows, nil
+}
+
+// NewWriter returns a writer of the format that writes to w
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w)
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// ContentType is the MIME type of the format
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// rowSet keeps the filled rows as they are read and stops the reader once
+// they pass the limits
+type rowSet struct {
+	rows    []Row
+	maxRows int
+	cells   int
+}
+
+func (s *rowSet) add(line int, cells []string) error {
+	if emptyRow(cells) {
+		return nil
+	}
+	if len(s.rows) >= s.maxRows {
+		return ErrTooManyRows
+	}
+	if s.cells += len(cells); s.cells > maxCells {
+		return ErrTooManyCells
+	}
+
+	s.rows = append(s.rows, Row{Line: line, Cells: cells})
+	return nil
+}
+
+func emptyRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// readCSV reads comma or semicolon separated files, Excel saves the latter
+// in locales that use the comma as decimal separator
+func readCSV(r io.Reader, rows *rowSet) error {
+	br := bufio.NewReader(r)
+
+	first, err := br.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return err
+	}
+	if bytes.HasPrefix(first, []byte(utf8BOM)) {
+		br.Discard(len(utf8BOM))
+		first = first[len(utf8BOM):]
+	}
+
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	if line, _, _ := bytes.Cut(first, []byte("\n")); bytes.Count(line, []byte(";")) > bytes.Count(line, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	for {
+		cells, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		line, _ := reader.FieldPos(0)
+		if err = rows.add(line, cells); err != nil {
+			return err
+		}
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) WriteRow(cells []string) error {
+	return c.w.Write(cells)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
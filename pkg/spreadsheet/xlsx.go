@@ -0,0 +1,329 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// maxXLSXPartSize limits how much of a part of the archive is unpacked, so a
+// small crafted file can not fill the memory
+const maxXLSXPartSize = 64 << 20
+
+// maxXLSXColumns is the last column a sheet can have, "XFD". Cell references
+// come from the file, one past it would make a row as wide as it says.
+const maxXLSXColumns = 16384
+
+var errXLSXNoSheet = errors.New("xlsx file has no sheet")
+
+const (
+	xlsxMainNamespace  = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xlsxRelsNamespace  = "http://schemas.openxmlformats.org/package/2006/relationships"
+	xlsxDocNamespace   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	xlsxSheetPartName  = "xl/worksheets/sheet1.xml"
+	xlsxWorkbookPart   = "xl/workbook.xml"
+	xlsxWorkbookRels   = "xl/_rels/workbook.xml.rels"
+	xlsxSharedStrings  = "xl/sharedStrings.xml"
+	xlsxDefaultSheetID = "rId1"
+)
+
+// xlsxText is the text of a shared or inline string, rich text is split in runs
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+type xlsxRow struct {
+	Ref   int `xml:"r,attr"`
+	Cells []struct {
+		Ref    string   `xml:"r,attr"`
+		Type   string   `xml:"t,attr"`
+		Value  string   `xml:"v"`
+		Inline xlsxText `xml:"is"`
+	} `xml:"c"`
+}
+
+func readXLSX(r io.ReaderAt, size int64, rows *rowSet) error {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+
+	files := map[string]*zip.File{}
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	sheet := files[xlsxSheetName(files)]
+	if sheet == nil {
+		return errXLSXNoSheet
+	}
+
+	var shared []string
+	if file := files[xlsxSharedStrings]; file != nil {
+		var sst struct {
+			Items []xlsxText `xml:"si"`
+		}
+		if err = decodeXLSXPart(file, &sst); err != nil {
+			return err
+		}
+		for _, item := range sst.Items {
+			shared = append(shared, item.String())
+		}
+	}
+
+	part, err := sheet.Open()
+	if err != nil {
+		return err
+	}
+	defer part.Close()
+
+	var (
+		line    int
+		decoder = xml.NewDecoder(io.LimitReader(part, maxXLSXPartSize))
+	)
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+
+		var row xlsxRow
+		if err = decoder.DecodeElement(&row, &start); err != nil {
+			return err
+		}
+
+		line++
+		if row.Ref > 0 {
+			line = row.Ref
+		}
+
+		var cells []string
+		for _, cell := range row.Cells {
+			column := len(cells)
+			if cell.Ref != "" {
+				var valid bool
+				if column, valid = xlsxColumn(cell.Ref); !valid {
+					return errors.New("xlsx row " + strconv.Itoa(line) + ": " + cell.Ref + " is not a valid cell reference")
+				}
+			}
+			if column >= maxXLSXColumns {
+				return errors.New("xlsx row " + strconv.Itoa(line) + " has more than " + strconv.Itoa(maxXLSXColumns) + " columns")
+			}
+
+			var value string
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(shared) {
+					return errors.New("xlsx cell " + cell.Ref + " refers to a missing shared string")
+				}
+				value = shared[index]
+			case "inlineStr":
+				value = cell.Inline.String()
+			default:
+				value = cell.Value
+			}
+
+			// empty cells are not padded up to, a styled cell far to the
+			// right would make the row as wide as it is
+			if value == "" {
+				continue
+			}
+			for len(cells) <= column {
+				cells = append(cells, "")
+			}
+			cells[column] = value
+		}
+
+		if err = rows.add(line, cells); err != nil {
+			return err
+		}
+	}
+}
+
+// xlsxSheetName finds the part of the first sheet of the workbook
+func xlsxSheetName(files map[string]*zip.File) string {
+	var workbook struct {
+		Sheets []struct {
+			Id string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	var rels struct {
+		Relationships []struct {
+			Id     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+
+	if files[xlsxWorkbookPart] == nil || files[xlsxWorkbookRels] == nil ||
+		decodeXLSXPart(files[xlsxWorkbookPart], &workbook) != nil ||
+		decodeXLSXPart(files[xlsxWorkbookRels], &rels) != nil ||
+		len(workbook.Sheets) == 0 {
+		return xlsxSheetPartName
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.Id != workbook.Sheets[0].Id {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/")
+		}
+		return path.Join("xl", rel.Target)
+	}
+
+	return xlsxSheetPartName
+}
+
+func decodeXLSXPart(file *zip.File, v interface{}) error {
+	part, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer part.Close()
+
+	return xml.NewDecoder(io.LimitReader(part, maxXLSXPartSize)).Decode(v)
+}
+
+// xlsxColumn turns the letters of a cell reference like "AB12" into the
+// zero based column index. A reference without letters or past the last
+// column of a sheet is not valid.
+func xlsxColumn(ref string) (int, bool) {
+	column := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A') + 1
+		if column > maxXLSXColumns {
+			return 0, false
+		}
+	}
+	return column - 1, column > 0
+}
+
+// xlsxColumnName is the inverse of xlsxColumn
+func xlsxColumnName(column int) string {
+	name := ""
+	for column++; column > 0; column = (column - 1) / 26 {
+		name = string(rune('A'+(column-1)%26)) + name
+	}
+	return name
+}
+
+// xlsxWriter streams the rows into the single sheet of a workbook, the other
+// parts of the archive are written up front
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	row     int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	archive := zip.NewWriter(w)
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/` + xlsxWorkbookPart + `" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/` + xlsxSheetPartName + `" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="` + xlsxRelsNamespace + `">` +
+			`<Relationship Id="rId1" Type="` + xlsxDocNamespace + `/officeDocument" Target="` + xlsxWorkbookPart + `"/>` +
+			`</Relationships>`},
+		{xlsxWorkbookPart, `<workbook xmlns="` + xlsxMainNamespace + `" xmlns:r="` + xlsxDocNamespace + `">` +
+			`<sheets><sheet name="Sheet1" sheetId="1" r:id="` + xlsxDefaultSheetID + `"/></sheets>` +
+			`</workbook>`},
+		{xlsxWorkbookRels, `<Relationships xmlns="` + xlsxRelsNamespace + `">` +
+			`<Relationship Id="` + xlsxDefaultSheetID + `" Type="` + xlsxDocNamespace + `/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`},
+	}
+
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = io.WriteString(file, xml.Header+part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := archive.Create(xlsxSheetPartName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.WriteString(sheet, xml.Header+`<worksheet xmlns="`+xlsxMainNamespace+`"><sheetData>`); err != nil {
+		return nil, err
+	}
+
+	return &xlsxWriter{archive: archive, sheet: sheet}, nil
+}
+
+// WriteRow writes numbers as number cells and everything else as text
+func (x *xlsxWriter) WriteRow(cells []string) error {
+	x.row++
+	line := strconv.Itoa(x.row)
+
+	var b strings.Builder
+	b.WriteString(`<row r="` + line + `">`)
+
+	for i, cell := range cells {
+		if cell == "" {
+			continue
+		}
+
+		ref := xlsxColumnName(i) + line
+		if number, err := strconv.ParseFloat(cell, 64); err == nil && strconv.FormatFloat(number, 'f', -1, 64) == cell {
+			b.WriteString(`<c r="` + ref + `"><v>` + cell + `</v></c>`)
+			continue
+		}
+
+		b.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(&b, []byte(cell)); err != nil {
+			return err
+		}
+		b.WriteString(`</t></is></c>`)
+	}
+
+	b.WriteString(`</row>`)
+
+	_, err := io.WriteString(x.sheet, b.String())
+	return err
+}
+
+func (x *xlsxWriter) Close() error {
+	if _, err := io.WriteString(x.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return x.archive.Close()
+}
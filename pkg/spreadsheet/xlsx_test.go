@@ -0,0 +1,144 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// testXLSX zips the rows as the only sheet of a workbook
+func testXLSX(t *testing.T, rows ...string) *bytes.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	sheet, err := archive.Create(xlsxSheetPartName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheet.Write([]byte(`<worksheet xmlns="` + xlsxMainNamespace + `"><sheetData>` + strings.Join(rows, "") + `</sheetData></worksheet>`))
+	if err = archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestXLSXColumn(t *testing.T) {
+	tests := []struct {
+		ref    string
+		column int
+		valid  bool
+	}{
+		{"A1", 0, true},
+		{"Z9", 25, true},
+		{"AA10", 26, true},
+		{"AB12", 27, true},
+		{"XFD1", maxXLSXColumns - 1, true},
+		{"XFE1", 0, false},
+		{"ZZZZZZZZ1", 0, false},
+		{"12", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		column, valid := xlsxColumn(tt.ref)
+		if valid != tt.valid || valid && column != tt.column {
+			t.Errorf("xlsxColumn(%q) = %d, %v, want %d, %v", tt.ref, column, valid, tt.column, tt.valid)
+		}
+		if tt.valid && xlsxColumnName(column)+strings.TrimLeft(tt.ref, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != tt.ref {
+			t.Errorf("xlsxColumnName(%d) does not give back %q", column, tt.ref)
+		}
+	}
+}
+
+func TestReadXLSXLimits(t *testing.T) {
+	wide := func(count int) []string {
+		rows := make([]string, count)
+		for i := range rows {
+			rows[i] = `<row><c r="XFD` + strconv.Itoa(i+1) + `"><v>1</v></c></row>`
+		}
+		return rows
+	}
+	many := func(count int) []string {
+		rows := make([]string, count)
+		for i := range rows {
+			rows[i] = `<row><c r="A` + strconv.Itoa(i+1) + `" t="inlineStr"><is><t>x</t></is></c></row>`
+		}
+		return rows
+	}
+
+	tests := []struct {
+		name    string
+		rows    []string
+		maxRows int
+		want    int
+		err     error
+		errText string
+	}{
+		{
+			name:    "rows up to the limit",
+			rows:    many(10),
+			maxRows: 10,
+			want:    10,
+		},
+		{
+			name:    "too many rows",
+			rows:    many(11),
+			maxRows: 10,
+			err:     ErrTooManyRows,
+		},
+		{
+			name:    "empty rows do not count",
+			rows:    append(many(2), `<row r="3"><c r="XFD3"><v></v></c></row>`, `<row r="4"/>`),
+			maxRows: 2,
+			want:    2,
+		},
+		{
+			name:    "wide rows past the cell limit",
+			rows:    wide(maxCells/maxXLSXColumns + 1),
+			maxRows: 5000,
+			err:     ErrTooManyCells,
+		},
+		{
+			name:    "cell past the last column",
+			rows:    []string{`<row><c r="XFE1"><v>1</v></c></row>`},
+			maxRows: 5000,
+			errText: "XFE1 is not a valid cell reference",
+		},
+	}
+
+	for _, tt := range tests {
+		file := testXLSX(t, tt.rows...)
+		rows, err := Read(FormatXLSX, file, file.Size(), tt.maxRows)
+
+		switch {
+		case tt.err != nil:
+			if err != tt.err {
+				t.Errorf("%s: err = %v, want %v", tt.name, err, tt.err)
+			}
+		case tt.errText != "":
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("%s: err = %v, want %q", tt.name, err, tt.errText)
+			}
+		case err != nil:
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		case len(rows) != tt.want:
+			t.Errorf("%s: read %d rows, want %d", tt.name, len(rows), tt.want)
+		}
+	}
+}
+
+func TestReadXLSXSkipsEmptyCells(t *testing.T) {
+	file := testXLSX(t, `<row r="2"><c r="B2" t="inlineStr"><is><t>name</t></is></c><c r="XFD2"><v></v></c></row>`)
+
+	rows, err := Read(FormatXLSX, file, file.Size(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Line != 2 || len(rows[0].Cells) != 2 || rows[0].Cells[1] != "name" {
+		t.Fatalf("rows = %+v, want line 2 with cells [\"\" \"name\"]", rows)
+	}
+}
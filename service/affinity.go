@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"e-commerce/pkg/logger"
+	"e-commerce/storage"
+	"time"
+)
+
+// affinityJob recomputes which products are ordered together, the bought
+// together recommendations read what it leaves behind
+type affinityJob struct {
+	storage  storage.StorageI
+	log      logger.LoggerI
+	interval time.Duration
+}
+
+func NewAffinityJob(storage storage.StorageI, log logger.LoggerI, interval time.Duration) affinityJob {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	return affinityJob{
+		storage:  storage,
+		log:      log,
+		interval: interval,
+	}
+}
+
+// Run refreshes the affinities right away and then every interval until the
+// context is done
+func (a affinityJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		pairs, err := a.storage.Product().RefreshAffinity(ctx)
+		if err != nil {
+			a.log.Error("error while refreshing product affinities", logger.Error(err))
+		} else if pairs > 0 {
+			a.log.Info("product affinities refreshed", logger.Int("pairs", int(pairs)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,31 +2,40 @@ package service
 
 import (
 	"context"
-	nmagap "e-commerce"
 	"e-commerce/config"
 	"e-commerce/models"
-	"e-commerce/pkg"
-	"e-commerce/pkg/jwt"
+	"e-commerce/pkg/helper"
 	"e-commerce/pkg/logger"
+	"e-commerce/pkg/password"
 	"e-commerce/storage"
 	"errors"
 	"fmt"
-	"time"
 
-	"github.com/redis/go-redis/v9"
+	"github.com/jackc/pgx/v4"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid login or password")
+	ErrInvalidPhone       = errors.New("telefon raqami noto'g'ri formatda")
+	ErrPhoneRegistered    = errors.New("bu telefon raqami allaqachon ro'yxatdan o'tgan")
+	ErrPhoneNotRegistered = errors.New("bu telefon raqami ro'yxatdan o'tmagan")
 )
 
 type authService struct {
 	storage storage.StorageI
 	log     logger.LoggerI
 	redis   storage.RedisI
+	token   tokenService
+	otp     otpService
 }
 
-func NewAuthService(storage storage.StorageI, log logger.LoggerI, redis storage.RedisI) authService {
+func NewAuthService(storage storage.StorageI, log logger.LoggerI, redis storage.RedisI, token tokenService, otp otpService) authService {
 	return authService{
 		storage: storage,
 		log:     log,
 		redis:   redis,
+		token:   token,
+		otp:     otp,
 	}
 }
 
@@ -38,17 +47,25 @@ func (a authService) UserLogin(ctx context.Context, loginRequest models.UserLogi
 		return models.UserLoginResponse{}, err
 	}
 
-	// Note: Password comparison is commented out. You may want to uncomment and implement this.
-	// if err = password.CompareHashAndPassword(user.Password, loginRequest.Password); err != nil {
-	//  a.log.Error("error while comparing password", logger.Error(err))
-	//  return models.UserLoginResponse{}, err
-	// }
+	if err = password.CompareHashAndPassword(user.Password, loginRequest.Password); err != nil {
+		a.log.Error("error while comparing password", logger.Error(err))
+		return models.UserLoginResponse{}, ErrInvalidCredentials
+	}
 
-	m := make(map[interface{}]interface{})
-	m["user_id"] = user.Id
-	m["user_role"] = config.CUSTOMER_ROLE
+	// legacy rows keep the password as plain text until the first successful login
+	if !password.IsHashed(user.Password) {
+		hash, err := password.HashPassword(loginRequest.Password)
+		if err != nil {
+			a.log.Error("error while hashing legacy user password", logger.Error(err))
+			return models.UserLoginResponse{}, err
+		}
+		if err = a.storage.Customer().UpdatePasswordHash(ctx, user.Id, hash); err != nil {
+			a.log.Error("error while re-hashing legacy user password", logger.Error(err))
+			return models.UserLoginResponse{}, err
+		}
+	}
 
-	accessToken, refreshToken, err := jwt.GenJWT(m)
+	accessToken, refreshToken, err := a.token.Issue(ctx, user.Id, config.CUSTOMER_ROLE)
 	if err != nil {
 		a.log.Error("error while generating tokens for user login", logger.Error(err))
 		return models.UserLoginResponse{}, err
@@ -63,16 +80,21 @@ func (a authService) UserLogin(ctx context.Context, loginRequest models.UserLogi
 func (a authService) UserRegister(ctx context.Context, loginRequest models.UserRegisterRequest) error {
 	fmt.Println(" loginRequest.Login: ", loginRequest.MobilePhone)
 
-	otpCode := pkg.GenerateOTP()
-
-	msg := fmt.Sprintf("iBron ilovasi ro`yxatdan o`tish uchun tasdiqlash kodi: %v", otpCode)
+	if !helper.IsValidPhone(loginRequest.MobilePhone) {
+		return ErrInvalidPhone
+	}
 
-	err := a.redis.SetX(ctx, loginRequest.MobilePhone, otpCode, time.Minute*5)
+	registered, err := a.phoneRegistered(ctx, loginRequest.MobilePhone)
 	if err != nil {
-		a.log.Error("error while setting smsCode to redis user register", logger.Error(err))
 		return err
 	}
-	err = nmagap.SendSms(loginRequest.MobilePhone, msg)
+	if registered {
+		return ErrPhoneRegistered
+	}
+
+	err = a.otp.Send(ctx, loginRequest.MobilePhone, func(code int) string {
+		return fmt.Sprintf("iBron ilovasi ro`yxatdan o`tish uchun tasdiqlash kodi: %v", code)
+	})
 	if err != nil {
 		a.log.Error("error while sending sms code to user register", logger.Error(err))
 		return err
@@ -83,79 +105,107 @@ func (a authService) UserRegister(ctx context.Context, loginRequest models.UserR
 func (a authService) UserRegisterConfirm(ctx context.Context, req models.UserRegisterConfRequest) (models.UserLoginResponse, error) {
 	resp := models.UserLoginResponse{}
 
-	// OTP tekshiruvi hozircha o'chirilgan
+	if req.Customer == nil {
+		return resp, ErrInvalidPhone
+	}
 
-	customer, err := a.storage.Customer().Create(ctx, req.Customer)
+	err := a.otp.Verify(ctx, req.Customer.Phone_number, req.OtpCode)
 	if err != nil {
-		a.log.Error("error while creating user", logger.Error(err))
+		a.log.Error("error while verifying otp code for user register confirm", logger.Error(err))
 		return resp, err
 	}
-	var m = make(map[interface{}]interface{})
 
-	m["user_id"] = customer.Id // Faraz qilamizki, Customer strukturasida ID maydoni bor
-	m["user_role"] = config.CUSTOMER_ROLE
+	registered, err := a.phoneRegistered(ctx, req.Customer.Phone_number)
+	if err != nil {
+		return resp, err
+	}
+	if registered {
+		return resp, ErrPhoneRegistered
+	}
 
-	accessToken, refreshToken, err := jwt.GenJWT(m)
+	customer, err := a.storage.Customer().Create(ctx, req.Customer)
+	if err != nil {
+		a.log.Error("error while creating user", logger.Error(err))
+		return resp, err
+	}
+	accessToken, refreshToken, err := a.token.Issue(ctx, customer.Id, config.CUSTOMER_ROLE)
 	if err != nil {
 		a.log.Error("error while generating tokens for user register confirm", logger.Error(err))
 		return resp, err
 	}
 	resp.ID = customer.Id // ID ni javobga qo'shamiz
+	resp.PhoneNumber = customer.Phone_number
 	resp.AccessToken = accessToken
 	resp.RefreshToken = refreshToken
 
 	return resp, nil
 }
 
-// func (a authService) UserLoginByPhone(ctx context.Context, req models.UserLoginByPhoneRequest) error {
-// 	otpCode := pkg.GenerateOTP()
-// 	msg := fmt.Sprintf("iBron ilovasi ro'yxatdan o'tish uchun tasdiqlash kodi: %v", otpCode)
+func (a authService) UserLoginByPhone(ctx context.Context, req models.UserLoginByPhoneRequest) error {
+	if !helper.IsValidPhone(req.PhoneNumber) {
+		return ErrInvalidPhone
+	}
 
-// 	err := a.redis.SetX(ctx, req.PhoneNumber, otpCode, time.Minute*5)
-// 	if err != nil {
-// 		a.log.Error("error while setting smsCode to redis for user login", logger.Error(err))
-// 		return err
-// 	}
+	registered, err := a.phoneRegistered(ctx, req.PhoneNumber)
+	if err != nil {
+		return err
+	}
+	if !registered {
+		return ErrPhoneNotRegistered
+	}
 
-// 	err = nmagap.SendSms(req.PhoneNumber, msg)
-// 	if err != nil {
-// 		a.log.Error("error while sending sms code for user login", logger.Error(err))
-// 		return err
-// 	}
-// 	return nil
-// }
+	err = a.otp.Send(ctx, req.PhoneNumber, func(code int) string {
+		return fmt.Sprintf("iBron ilovasiga kirish uchun tasdiqlash kodi: %v", code)
+	})
+	if err != nil {
+		a.log.Error("error while sending sms code for user login", logger.Error(err))
+		return err
+	}
+	return nil
+}
 
 func (a authService) UserLoginByPhoneConfirm(ctx context.Context, req models.UserLoginByPhoneConfirmRequest) (models.UserLoginResponse, error) {
 	resp := models.UserLoginResponse{}
 
-	storedOTP, err := a.redis.Get(ctx, req.PhoneNumber)
+	err := a.otp.Verify(ctx, req.PhoneNumber, req.OtpCode)
 	if err != nil {
-		if errors.Is(err, redis.Nil) {
-			a.log.Error("OTP code not found or expired", logger.Error(err))
-			return resp, errors.New("OTP kod topilmadi yoki muddati tugagan")
-		}
-		a.log.Error("error while getting OTP code from redis", logger.Error(err))
-		return resp, errors.New("tizim xatosi yuz berdi")
-	}
-
-	if req.OtpCode != storedOTP {
-		a.log.Error("incorrect OTP code", logger.Error(errors.New("OTP code mismatch")))
-		return resp, errors.New("noto'g'ri OTP kod")
+		a.log.Error("error while verifying otp code for user login", logger.Error(err))
+		return resp, err
 	}
 
-	err = a.redis.Del(ctx, req.PhoneNumber)
+	user, err := a.storage.Customer().GetByPhoneNumber(ctx, req.PhoneNumber)
 	if err != nil {
-		a.log.Error("error while deleting OTP from redis", logger.Error(err))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return resp, ErrPhoneNotRegistered
+		}
+		a.log.Error("error while getting user by phone number", logger.Error(err))
 		return resp, err
 	}
-	user, err := a.storage.Customer().GetByPhoneNumber(ctx, req.PhoneNumber)
+
+	accessToken, refreshToken, err := a.token.Issue(ctx, user.Id, config.CUSTOMER_ROLE)
 	if err != nil {
-		a.log.Error("error while getting user by phone number", logger.Error(err))
+		a.log.Error("error while generating tokens for user login by phone", logger.Error(err))
 		return resp, err
 	}
 
 	resp.PhoneNumber = req.PhoneNumber
 	resp.ID = user.Id
+	resp.AccessToken = accessToken
+	resp.RefreshToken = refreshToken
 
 	return resp, nil
 }
+
+// phoneRegistered reports whether a customer with the phone number already exists.
+func (a authService) phoneRegistered(ctx context.Context, phone string) (bool, error) {
+	_, err := a.storage.Customer().GetByPhoneNumber(ctx, phone)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		a.log.Error("error while getting user by phone number", logger.Error(err))
+		return false, err
+	}
+
+	return true, nil
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"e-commerce/config"
 	"e-commerce/models"
-	"e-commerce/pkg/jwt"
 	"e-commerce/pkg/logger"
+	"e-commerce/pkg/password"
 	"e-commerce/storage"
 	"fmt"
 )
@@ -14,13 +14,15 @@ type authadminService struct {
 	storage storage.StorageI
 	log     logger.LoggerI
 	redis   storage.RedisI
+	token   tokenService
 }
 
-func NewAuthAdminService(storage storage.StorageI, log logger.LoggerI, redis storage.RedisI) authadminService {
+func NewAuthAdminService(storage storage.StorageI, log logger.LoggerI, redis storage.RedisI, token tokenService) authadminService {
 	return authadminService{
 		storage: storage,
 		log:     log,
 		redis:   redis,
+		token:   token,
 	}
 }
 
@@ -32,17 +34,25 @@ func (a authadminService) AdminLogin(ctx context.Context, loginRequest models.Ad
 		return models.AdminLoginResponse{}, err
 	}
 
-	// Note: Password comparison is commented out. You may want to uncomment and implement this.
-	// if err = password.CompareHashAndPassword(user.Password, loginRequest.Password); err != nil {
-	//  a.log.Error("error while comparing password", logger.Error(err))
-	//  return models.UserLoginResponse{}, err
-	// }
+	if err = password.CompareHashAndPassword(admin.Password, loginRequest.Password); err != nil {
+		a.log.Error("error while comparing password", logger.Error(err))
+		return models.AdminLoginResponse{}, ErrInvalidCredentials
+	}
 
-	m := make(map[interface{}]interface{})
-	m["user_id"] = admin.Id
-	m["user_role"] = config.ADMIN_ROLE
+	// legacy rows keep the password as plain text until the first successful login
+	if !password.IsHashed(admin.Password) {
+		hash, err := password.HashPassword(loginRequest.Password)
+		if err != nil {
+			a.log.Error("error while hashing legacy admin password", logger.Error(err))
+			return models.AdminLoginResponse{}, err
+		}
+		if err = a.storage.Admin().UpdatePasswordHash(ctx, admin.Id, hash); err != nil {
+			a.log.Error("error while re-hashing legacy admin password", logger.Error(err))
+			return models.AdminLoginResponse{}, err
+		}
+	}
 
-	accessToken, refreshToken, err := jwt.GenJWT(m)
+	accessToken, refreshToken, err := a.token.Issue(ctx, admin.Id, config.ADMIN_ROLE)
 	if err != nil {
 		a.log.Error("error while generating tokens for user login", logger.Error(err))
 		return models.AdminLoginResponse{}, err
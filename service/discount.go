@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"e-commerce/pkg/logger"
+	"e-commerce/storage"
+	"time"
+)
+
+// discountScheduler activates scheduled product discounts when they start and
+// expires them when they end, so every read and the order prices see the
+// same discount
+type discountScheduler struct {
+	storage  storage.StorageI
+	log      logger.LoggerI
+	interval time.Duration
+}
+
+func NewDiscountScheduler(storage storage.StorageI, log logger.LoggerI, interval time.Duration) discountScheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	return discountScheduler{
+		storage:  storage,
+		log:      log,
+		interval: interval,
+	}
+}
+
+// Run applies the due discount transitions right away and then every
+// interval until the context is done
+func (d discountScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		transitions, err := d.storage.Product().ApplyDiscounts(ctx)
+		if err != nil {
+			d.log.Error("error while applying product discounts", logger.Error(err))
+		} else if transitions > 0 {
+			d.log.Info("product discounts applied", logger.Int("transitions", int(transitions)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"e-commerce/pkg"
+	"e-commerce/pkg/logger"
+	"e-commerce/pkg/sms"
+	"e-commerce/storage"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/cast"
+)
+
+const (
+	otpTTL            = 5 * time.Minute
+	otpResendCooldown = time.Minute
+	otpSendWindow     = time.Hour
+	otpMaxSends       = 5
+	otpMaxAttempts    = 5
+	otpLockDuration   = 30 * time.Minute
+)
+
+// verifyOtpScript compares the code with KEYS[1] and deletes it with the
+// attempts in KEYS[2] on a match, in one step so a code is accepted once.
+// A wrong code is counted in the same step and the code is deleted once
+// the attempts run out. It returns 0 on a match, -1 when there is no code
+// and the attempts made otherwise.
+const verifyOtpScript = `
+local stored = redis.call('GET', KEYS[1])
+if not stored then
+	return -1
+end
+if stored == ARGV[1] then
+	redis.call('DEL', KEYS[1], KEYS[2])
+	return 0
+end
+local attempts = redis.call('INCR', KEYS[2])
+if attempts == 1 then
+	redis.call('PEXPIRE', KEYS[2], ARGV[2])
+end
+if attempts >= tonumber(ARGV[3]) then
+	redis.call('DEL', KEYS[1])
+end
+return attempts
+`
+
+var (
+	ErrOtpNotFound = errors.New("OTP kod topilmadi yoki muddati tugagan")
+	ErrOtpInvalid  = errors.New("noto'g'ri OTP kod")
+	ErrOtpCooldown = errors.New("kodni qayta yuborishdan oldin biroz kuting")
+	ErrOtpLocked   = errors.New("urinishlar soni oshib ketdi, keyinroq qayta urinib ko'ring")
+)
+
+// otpService sends one-time codes by SMS and verifies them. Resends are
+// throttled per phone and too many resends or wrong guesses lock the phone.
+type otpService struct {
+	log   logger.LoggerI
+	redis storage.RedisI
+	sms   sms.SmsSender
+}
+
+func NewOtpService(log logger.LoggerI, redis storage.RedisI, sms sms.SmsSender) otpService {
+	return otpService{
+		log:   log,
+		redis: redis,
+		sms:   sms,
+	}
+}
+
+// Send generates a new code for the phone and sends it in the message built by msg.
+func (o otpService) Send(ctx context.Context, phone string, msg func(code int) string) error {
+	if err := o.checkLocked(ctx, phone); err != nil {
+		return err
+	}
+
+	ok, err := o.redis.SetNX(ctx, otpCooldownKey(phone), 1, otpResendCooldown)
+	if err != nil {
+		o.log.Error("error while setting otp cooldown to redis", logger.Error(err))
+		return err
+	}
+	if !ok {
+		return ErrOtpCooldown
+	}
+
+	sends, err := o.redis.Incr(ctx, otpSendsKey(phone), otpSendWindow)
+	if err != nil {
+		o.log.Error("error while counting otp sends in redis", logger.Error(err))
+		return err
+	}
+	if sends > otpMaxSends {
+		return o.lock(ctx, phone)
+	}
+
+	otpCode := pkg.GenerateOTP()
+
+	err = o.redis.SetX(ctx, otpKey(phone), otpCode, otpTTL)
+	if err != nil {
+		o.log.Error("error while setting smsCode to redis", logger.Error(err))
+		return err
+	}
+
+	// a new code gives a fresh set of guesses
+	err = o.redis.Del(ctx, otpAttemptsKey(phone))
+	if err != nil {
+		o.log.Error("error while resetting otp attempts in redis", logger.Error(err))
+		return err
+	}
+
+	err = o.sms.Send(ctx, phone, msg(otpCode))
+	if err != nil {
+		o.log.Error("error while sending sms code", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// Verify checks the code sent to the phone and deletes it on success. The
+// check runs in redis in one step, so concurrent guesses of the same code
+// are accepted once and all count against the attempts.
+func (o otpService) Verify(ctx context.Context, phone string, code string) error {
+	if err := o.checkLocked(ctx, phone); err != nil {
+		return err
+	}
+
+	result, err := o.redis.Eval(ctx, verifyOtpScript, []string{otpKey(phone), otpAttemptsKey(phone)},
+		code, otpTTL.Milliseconds(), otpMaxAttempts)
+	if err != nil {
+		o.log.Error("error while verifying OTP code in redis", logger.Error(err))
+		return err
+	}
+
+	switch attempts := cast.ToInt64(result); {
+	case attempts == 0:
+		return nil
+	case attempts < 0:
+		return ErrOtpNotFound
+	case attempts >= otpMaxAttempts:
+		return o.lock(ctx, phone)
+	}
+
+	return ErrOtpInvalid
+}
+
+func (o otpService) checkLocked(ctx context.Context, phone string) error {
+	_, err := o.redis.Get(ctx, otpLockKey(phone))
+	if err == nil {
+		return ErrOtpLocked
+	}
+	if !errors.Is(err, redis.Nil) {
+		o.log.Error("error while getting otp lock from redis", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (o otpService) lock(ctx context.Context, phone string) error {
+	o.log.Warn("otp limits exceeded, locking phone", logger.String("phone", phone))
+
+	err := o.redis.SetX(ctx, otpLockKey(phone), 1, otpLockDuration)
+	if err != nil {
+		o.log.Error("error while setting otp lock to redis", logger.Error(err))
+		return err
+	}
+
+	return ErrOtpLocked
+}
+
+func otpKey(phone string) string {
+	return fmt.Sprintf("otp:%s", phone)
+}
+
+func otpCooldownKey(phone string) string {
+	return fmt.Sprintf("otp_cooldown:%s", phone)
+}
+
+func otpSendsKey(phone string) string {
+	return fmt.Sprintf("otp_sends:%s", phone)
+}
+
+func otpAttemptsKey(phone string) string {
+	return fmt.Sprintf("otp_attempts:%s", phone)
+}
+
+func otpLockKey(phone string) string {
+	return fmt.Sprintf("otp_lock:%s", phone)
+}
@@ -2,24 +2,31 @@ package service
 
 import (
 	"e-commerce/pkg/logger"
+	"e-commerce/pkg/sms"
 	"e-commerce/storage"
 )
 
 type IServiceManager interface {
 	Auth() authService
 	AuthAdmin() authadminService
+	Token() tokenService
 }
 
 type Service struct {
 	auth      authService
 	authAdmin authadminService
+	token     tokenService
 	logger    logger.LoggerI
 }
 
-func New(storage storage.StorageI, log logger.LoggerI, redis storage.RedisI) Service {
+func New(storage storage.StorageI, log logger.LoggerI, redis storage.RedisI, smsSender sms.SmsSender) Service {
+	token := NewTokenService(storage, log, redis)
+	otp := NewOtpService(log, redis, smsSender)
+
 	return Service{
-		auth:      NewAuthService(storage, log, redis),
-		authAdmin: NewAuthAdminService(storage, log, redis),
+		auth:      NewAuthService(storage, log, redis, token, otp),
+		authAdmin: NewAuthAdminService(storage, log, redis, token),
+		token:     token,
 		logger:    log,
 	}
 }
@@ -32,4 +39,6 @@ func (s Service) AuthAdmin() authadminService {
 	return s.authAdmin
 }
 
-
+func (s Service) Token() tokenService {
+	return s.token
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"e-commerce/config"
+	"e-commerce/models"
+	"e-commerce/pkg/jwt"
+	"e-commerce/pkg/logger"
+	"e-commerce/storage"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/cast"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+)
+
+// tokenService issues token pairs and keeps refresh token families in redis.
+// Every login starts a new family, each refresh rotates the family's current
+// token, and presenting an already rotated token revokes the whole family.
+// Admin tokens carry the permissions of the admin's role, they are reloaded
+// from the database on every refresh.
+type tokenService struct {
+	storage storage.StorageI
+	log     logger.LoggerI
+	redis   storage.RedisI
+}
+
+func NewTokenService(storage storage.StorageI, log logger.LoggerI, redis storage.RedisI) tokenService {
+	return tokenService{
+		storage: storage,
+		log:     log,
+		redis:   redis,
+	}
+}
+
+// Issue starts a new refresh token family for the user.
+func (t tokenService) Issue(ctx context.Context, userID, role string) (string, string, error) {
+	return t.issue(ctx, userID, role, uuid.New().String())
+}
+
+func (t tokenService) issue(ctx context.Context, userID, role, familyID string) (string, string, error) {
+	jti := uuid.New().String()
+
+	m := make(map[interface{}]interface{})
+	m["user_id"] = userID
+	m["user_role"] = role
+	m["family_id"] = familyID
+	m["jti"] = jti
+
+	if role == config.ADMIN_ROLE {
+		permissions, err := t.storage.Admin().GetPermissions(ctx, userID)
+		if err != nil {
+			t.log.Error("error while getting admin permissions", logger.Error(err))
+			return "", "", err
+		}
+		m["permissions"] = permissions
+	}
+
+	accessToken, refreshToken, err := jwt.GenJWT(m)
+	if err != nil {
+		t.log.Error("error while generating tokens", logger.Error(err))
+		return "", "", err
+	}
+
+	err = t.redis.SetX(ctx, refreshFamilyKey(familyID), jti, jwt.RefreshTokenTTL)
+	if err != nil {
+		t.log.Error("error while saving refresh token family to redis", logger.Error(err))
+		return "", "", err
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// Refresh rotates the refresh token and returns a new token pair.
+func (t tokenService) Refresh(ctx context.Context, req models.RefreshTokenRequest) (models.UserLoginResponse, error) {
+	claims, err := t.parseRefreshToken(req.RefreshToken)
+	if err != nil {
+		return models.UserLoginResponse{}, err
+	}
+
+	var (
+		jti      = cast.ToString(claims["jti"])
+		familyID = cast.ToString(claims["family_id"])
+		userID   = cast.ToString(claims["user_id"])
+		role     = cast.ToString(claims["user_role"])
+	)
+
+	// a refresh token can be exchanged only once, the marker lives as long as the token itself
+	firstUse, err := t.redis.SetNX(ctx, refreshUsedKey(jti), familyID, jwt.RefreshTokenTTL)
+	if err != nil {
+		t.log.Error("error while marking refresh token as used", logger.Error(err))
+		return models.UserLoginResponse{}, err
+	}
+
+	current, err := t.redis.Get(ctx, refreshFamilyKey(familyID))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return models.UserLoginResponse{}, ErrInvalidRefreshToken
+		}
+		t.log.Error("error while getting refresh token family from redis", logger.Error(err))
+		return models.UserLoginResponse{}, err
+	}
+
+	if !firstUse || cast.ToString(current) != jti {
+		t.log.Warn("refresh token reuse detected, revoking family", logger.String("family_id", familyID), logger.String("user_id", userID))
+		if err = t.redis.Del(ctx, refreshFamilyKey(familyID)); err != nil {
+			t.log.Error("error while revoking refresh token family", logger.Error(err))
+		}
+		return models.UserLoginResponse{}, ErrRefreshTokenReused
+	}
+
+	// a deleted customer's sessions end with their next refresh
+	if role == config.CUSTOMER_ROLE {
+		customer, err := t.storage.Customer().GetByID(ctx, &models.CustomerPrimaryKey{Id: userID})
+		if err != nil {
+			t.log.Error("error while getting refreshing customer", logger.Error(err))
+			return models.UserLoginResponse{}, err
+		}
+		if customer.Id == "" {
+			if err = t.redis.Del(ctx, refreshFamilyKey(familyID)); err != nil {
+				t.log.Error("error while revoking refresh token family", logger.Error(err))
+			}
+			return models.UserLoginResponse{}, ErrInvalidRefreshToken
+		}
+	}
+
+	accessToken, refreshToken, err := t.issue(ctx, userID, role, familyID)
+	if err != nil {
+		return models.UserLoginResponse{}, err
+	}
+
+	return models.UserLoginResponse{
+		ID:           userID,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// Revoke ends the session the refresh token belongs to.
+func (t tokenService) Revoke(ctx context.Context, req models.RefreshTokenRequest) error {
+	claims, err := t.parseRefreshToken(req.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	err = t.redis.Del(ctx, refreshFamilyKey(cast.ToString(claims["family_id"])))
+	if err != nil {
+		t.log.Error("error while revoking refresh token family", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (t tokenService) parseRefreshToken(token string) (map[string]interface{}, error) {
+	claims, err := jwt.ExtractClaims(token)
+	if err != nil {
+		t.log.Error("error while parsing refresh token", logger.Error(err))
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if cast.ToString(claims["token_type"]) != jwt.RefreshTokenType ||
+		cast.ToString(claims["jti"]) == "" ||
+		cast.ToString(claims["family_id"]) == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return claims, nil
+}
+
+func refreshFamilyKey(familyID string) string {
+	return fmt.Sprintf("refresh_family:%s", familyID)
+}
+
+func refreshUsedKey(jti string) string {
+	return fmt.Sprintf("refresh_used:%s", jti)
+}
@@ -0,0 +1,30 @@
+package storage
+
+import "errors"
+
+var (
+	ErrCategoryNotFound       = errors.New("category not found")
+	ErrCategoryCycle          = errors.New("category can not be moved under itself or its subcategory")
+	ErrCategoryHasChildren    = errors.New("category has subcategories")
+	ErrCategoryHasProducts    = errors.New("category has products")
+	ErrCategoryParentNotFound = errors.New("parent category not found")
+	ErrProductNotFound        = errors.New("product not found")
+	ErrSlugNotFound           = errors.New("slug not found")
+	ErrVariantExists          = errors.New("product already has a variant with these options")
+	ErrVariantSkuTaken        = errors.New("sku is used by another variant")
+	ErrVariantInUse           = errors.New("variant is used by orders")
+	ErrMediaNotFound          = errors.New("media not found")
+	ErrMediaLinkInvalid       = errors.New("variant or color does not belong to the product")
+	ErrMediaOrderMismatch     = errors.New("media_ids must list every image of the product once")
+	ErrDiscountInvalid        = errors.New("discount_percent must be below 100 and discount_end_time after discount_start_time, times in RFC3339")
+	ErrReviewNotFound         = errors.New("review not found")
+	ErrReviewNotAllowed       = errors.New("only customers with a delivered order of the product can review it")
+	ErrReviewExists           = errors.New("product is already reviewed by the customer")
+	ErrNotDeleted             = errors.New("not found among the deleted")
+	ErrParentDeleted          = errors.New("what it belongs to is deleted, restore that first")
+	ErrPhoneRegistered        = errors.New("phone number is registered by another customer")
+	ErrColorNotFound          = errors.New("color not found")
+	ErrVariantNotFound        = errors.New("variant not found")
+	ErrStockNegative          = errors.New("stock can not go below zero")
+	ErrReturnExceedsOrder     = errors.New("return exceeds what the order bought and has not returned yet")
+)
@@ -6,10 +6,12 @@ import (
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
 	"e-commerce/pkg/logger"
+	"e-commerce/pkg/password"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/lib/pq"
 )
 
 type adminRepo struct {
@@ -139,6 +141,17 @@ func (u *adminRepo) Create(ctx context.Context, req *models.AdminCreate) (*model
 		return nil, fmt.Errorf("invalid email format")
 	}
 
+	if req.Password == "" {
+		u.log.Error("Empty admin password")
+		return nil, fmt.Errorf("password is required")
+	}
+
+	passwordHash, err := password.HashPassword(req.Password)
+	if err != nil {
+		u.log.Error("Error while hashing admin password: " + err.Error())
+		return nil, err
+	}
+
 	id := uuid.New().String()
 	query := `
 		INSERT INTO "admin" (
@@ -148,10 +161,11 @@ func (u *adminRepo) Create(ctx context.Context, req *models.AdminCreate) (*model
 			email,
 			password,
 			address,
+			role_id,
 			created_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP)
-		RETURNING id, name, phone_number, email, password, address, created_at, updated_at
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::UUID, CURRENT_TIMESTAMP)
+		RETURNING id, name, phone_number, email, address, role_id, created_at, updated_at
 	`
 
 	var (
@@ -159,19 +173,19 @@ func (u *adminRepo) Create(ctx context.Context, req *models.AdminCreate) (*model
 		name         sql.NullString
 		phone_number sql.NullString
 		email        sql.NullString
-		password     sql.NullString
 		address      sql.NullString
+		role_id      sql.NullString
 		created_at   sql.NullString
 		updated_at   sql.NullString
 	)
 
-	err := u.db.QueryRow(ctx, query, id, req.Name, req.Phone_number, req.Email, req.Password, req.Address).Scan(
+	err = u.db.QueryRow(ctx, query, id, req.Name, req.Phone_number, req.Email, passwordHash, req.Address, req.RoleId).Scan(
 		&idd,
 		&name,
 		&phone_number,
 		&email,
-		&password,
 		&address,
+		&role_id,
 		&created_at,
 		&updated_at,
 	)
@@ -185,8 +199,8 @@ func (u *adminRepo) Create(ctx context.Context, req *models.AdminCreate) (*model
 		Name:         name.String,
 		Phone_number: phone_number.String,
 		Email:        email.String,
-		Password:     password.String,
 		Address:      address.String,
+		RoleId:       role_id.String,
 		CreatedAt:    created_at.String,
 		UpdatedAt:    updated_at.String,
 	}, nil
@@ -199,22 +213,25 @@ func (u *adminRepo) GetByID(ctx context.Context, req *models.AdminPrimaryKey) (*
 		name         sql.NullString
 		phone_number sql.NullString
 		email        sql.NullString
-		password     sql.NullString
 		address      sql.NullString
+		role_id      sql.NullString
+		role_name    sql.NullString
 		created_at   sql.NullString
 	)
 
 	query = `
 		SELECT 
-			id,
-			name,
-			phone_number,
-			email,
-			password,
-			address,
-			created_at
-		FROM "admin" 
-		WHERE id = $1
+			a.id,
+			a.name,
+			a.phone_number,
+			a.email,
+			a.address,
+			a.role_id,
+			r.name,
+			a.created_at
+		FROM "admin" AS a
+		LEFT JOIN "admin_role" AS r ON r.id = a.role_id
+		WHERE a.id = $1
 
 	`
 
@@ -223,8 +240,9 @@ func (u *adminRepo) GetByID(ctx context.Context, req *models.AdminPrimaryKey) (*
 		&name,
 		&phone_number,
 		&email,
-		&password,
 		&address,
+		&role_id,
+		&role_name,
 		&created_at,
 	)
 
@@ -238,8 +256,9 @@ func (u *adminRepo) GetByID(ctx context.Context, req *models.AdminPrimaryKey) (*
 		Name:         name.String,
 		Phone_number: phone_number.String,
 		Email:        email.String,
-		Password:     password.String,
 		Address:      address.String,
+		RoleId:       role_id.String,
+		RoleName:     role_name.String,
 		CreatedAt:    created_at.String,
 	}, nil
 }
@@ -255,14 +274,16 @@ func (u *adminRepo) GetList(ctx context.Context, req *models.AdminGetListRequest
 	query = `
 		SELECT
 			COUNT(*) OVER(),
-			id,
-			name,
-			phone_number,
-			email,
-			password,
-			address,
-			created_at
-		FROM "admin" 
+			a.id,
+			a.name,
+			a.phone_number,
+			a.email,
+			a.address,
+			a.role_id,
+			r.name,
+			a.created_at
+		FROM "admin" AS a
+		LEFT JOIN "admin_role" AS r ON r.id = a.role_id
 		
 	`
 
@@ -287,8 +308,9 @@ func (u *adminRepo) GetList(ctx context.Context, req *models.AdminGetListRequest
 			name         sql.NullString
 			phone_number sql.NullString
 			email        sql.NullString
-			password     sql.NullString
 			address      sql.NullString
+			role_id      sql.NullString
+			role_name    sql.NullString
 			created_at   sql.NullString
 		)
 
@@ -298,8 +320,9 @@ func (u *adminRepo) GetList(ctx context.Context, req *models.AdminGetListRequest
 			&name,
 			&phone_number,
 			&email,
-			&password,
 			&address,
+			&role_id,
+			&role_name,
 			&created_at,
 		)
 		if err != nil {
@@ -312,8 +335,9 @@ func (u *adminRepo) GetList(ctx context.Context, req *models.AdminGetListRequest
 			Name:         name.String,
 			Phone_number: phone_number.String,
 			Email:        email.String,
-			Password:     password.String,
 			Address:      address.String,
+			RoleId:       role_id.String,
+			RoleName:     role_name.String,
 			CreatedAt:    created_at.String,
 		})
 	}
@@ -347,6 +371,17 @@ func (u *adminRepo) Update(ctx context.Context, req *models.AdminUpdate) (int64,
 		return 0, fmt.Errorf("invalid email format")
 	}
 
+	// an empty password keeps the current one
+	var passwordHash string
+	if req.Password != "" {
+		hash, err := password.HashPassword(req.Password)
+		if err != nil {
+			u.log.Error("error is while hashing admin password", logger.Error(err))
+			return 0, err
+		}
+		passwordHash = hash
+	}
+
 	query = `
 		UPDATE
 			"admin"
@@ -354,7 +389,7 @@ func (u *adminRepo) Update(ctx context.Context, req *models.AdminUpdate) (int64,
 			name = :name,
 			phone_number = :phone_number,
 			email = :email,
-			password = :password,
+			password = COALESCE(NULLIF(:password, ''), password),
 			address = :address,
 			updated_at = NOW()
 		WHERE id = :id
@@ -365,7 +400,7 @@ func (u *adminRepo) Update(ctx context.Context, req *models.AdminUpdate) (int64,
 		"name":         req.Name,
 		"phone_number": req.Phone_number,
 		"email":        req.Email,
-		"password":     req.Password,
+		"password":     passwordHash,
 		"address":      req.Address,
 	}
 
@@ -378,3 +413,46 @@ func (u *adminRepo) Update(ctx context.Context, req *models.AdminUpdate) (int64,
 
 	return result.RowsAffected(), nil
 }
+
+// UpdatePasswordHash stores an already hashed password, used to upgrade legacy plaintext rows
+func (u *adminRepo) UpdatePasswordHash(ctx context.Context, id string, passwordHash string) error {
+	_, err := u.db.Exec(ctx, `UPDATE "admin" SET password = $1, updated_at = NOW() WHERE id = $2`, passwordHash, id)
+	if err != nil {
+		u.log.Error("error is while updating admin password", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// UpdateRole assigns the role to the admin, an empty role id removes it
+func (u *adminRepo) UpdateRole(ctx context.Context, req *models.AdminRoleUpdate) (int64, error) {
+	result, err := u.db.Exec(ctx, `UPDATE "admin" SET role_id = NULLIF($1, '')::UUID, updated_at = NOW() WHERE id = $2`, req.RoleId, req.AdminId)
+	if err != nil {
+		u.log.Error("error is while updating admin role", logger.Error(err))
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// GetPermissions returns the permissions granted to the admin through its role
+func (u *adminRepo) GetPermissions(ctx context.Context, id string) ([]string, error) {
+	var permissions pq.StringArray
+
+	query := `
+		SELECT
+			COALESCE(array_agg(p.permission ORDER BY p.permission), '{}')
+		FROM "admin" AS a
+		JOIN "admin_role_permission" AS p ON p.role_id = a.role_id
+		WHERE a.id = $1
+	`
+
+	err := u.db.QueryRow(ctx, query, id).Scan(&permissions)
+	if err != nil {
+		u.log.Error("error is while getting admin permissions", logger.Error(err))
+		return nil, err
+	}
+
+	return permissions, nil
+}
@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/models"
+	"e-commerce/pkg/logger"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type auditRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewAuditRepo initializes a new instance of auditRepo
+func NewAuditRepo(db *pgxpool.Pool, log logger.LoggerI) *auditRepo {
+	return &auditRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create appends an entry to the audit log, entries are never updated or deleted
+func (a *auditRepo) Create(ctx context.Context, req *models.AuditLogCreate) error {
+	before, err := marshalAuditState(req.Before)
+	if err != nil {
+		return err
+	}
+
+	after, err := marshalAuditState(req.After)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO "audit_log" (
+			id,
+			actor_id,
+			actor_role,
+			action,
+			entity_type,
+			entity_id,
+			before,
+			after,
+			created_at
+		)
+		VALUES ($1, NULLIF($2, '')::UUID, $3, $4, $5, $6, $7, $8, CURRENT_TIMESTAMP)
+	`
+
+	_, err = a.db.Exec(ctx, query,
+		uuid.New().String(),
+		req.ActorId,
+		req.ActorRole,
+		req.Action,
+		req.EntityType,
+		req.EntityId,
+		before,
+		after,
+	)
+	if err != nil {
+		a.log.Error("error while creating audit log", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (a *auditRepo) GetList(ctx context.Context, req *models.AuditGetListRequest) (*models.AuditGetListResponse, error) {
+	var (
+		resp  = &models.AuditGetListResponse{}
+		args  = []interface{}{}
+		argId = 1
+	)
+
+	query := `
+		SELECT
+			COUNT(*) OVER(),
+			id,
+			actor_id,
+			actor_role,
+			action,
+			entity_type,
+			entity_id,
+			before,
+			after,
+			created_at
+		FROM "audit_log"
+		WHERE 1=1
+	`
+
+	filters := []struct {
+		column string
+		value  string
+	}{
+		{"actor_id::TEXT = ", req.ActorId},
+		{"action = ", req.Action},
+		{"entity_type = ", req.EntityType},
+		{"entity_id = ", req.EntityId},
+		{"created_at >= ", req.From},
+		{"created_at < ", req.To},
+	}
+
+	for _, filter := range filters {
+		if filter.value == "" {
+			continue
+		}
+		query += fmt.Sprintf(" AND %s$%d", filter.column, argId)
+		args = append(args, filter.value)
+		argId++
+	}
+
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", argId, argId+1)
+	args = append(args, req.Limit, req.Offset)
+
+	rows, err := a.db.Query(ctx, query, args...)
+	if err != nil {
+		a.log.Error("error while getting audit log list", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id          sql.NullString
+			actor_id    sql.NullString
+			actor_role  sql.NullString
+			action      sql.NullString
+			entity_type sql.NullString
+			entity_id   sql.NullString
+			before      []byte
+			after       []byte
+			created_at  sql.NullString
+		)
+
+		err = rows.Scan(
+			&resp.Count,
+			&id,
+			&actor_id,
+			&actor_role,
+			&action,
+			&entity_type,
+			&entity_id,
+			&before,
+			&after,
+			&created_at,
+		)
+		if err != nil {
+			a.log.Error("error while scanning audit log list", logger.Error(err))
+			return nil, err
+		}
+
+		entry := &models.AuditLog{
+			Id:         id.String,
+			ActorId:    actor_id.String,
+			ActorRole:  actor_role.String,
+			Action:     action.String,
+			EntityType: entity_type.String,
+			EntityId:   entity_id.String,
+			CreatedAt:  created_at.String,
+		}
+
+		if len(before) > 0 {
+			if err = json.Unmarshal(before, &entry.Before); err != nil {
+				return nil, err
+			}
+		}
+		if len(after) > 0 {
+			if err = json.Unmarshal(after, &entry.After); err != nil {
+				return nil, err
+			}
+		}
+
+		resp.Audit = append(resp.Audit, entry)
+	}
+
+	return resp, rows.Err()
+}
+
+func marshalAuditState(state map[string]interface{}) ([]byte, error) {
+	if state == nil {
+		return nil, nil
+	}
+	return json.Marshal(state)
+}
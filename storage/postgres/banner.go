@@ -6,6 +6,7 @@ import (
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
 	"e-commerce/pkg/logger"
+	"e-commerce/storage"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -73,21 +74,24 @@ func (u *bannerRepo) GetByID(ctx context.Context, req *models.BannerPrimaryKey)
 		SELECT 
 			id,
 			banner_image,
-			created_at
+			created_at,
+			deleted_at
 		FROM "banner" 
-		WHERE id = $1
+		WHERE id = $1 AND ($2 OR deleted_at IS NULL)
 	`
 
 	var (
 		id           sql.NullString
 		banner_image sql.NullString
 		created_at   sql.NullString
+		deleted_at   sql.NullString
 	)
 
-	err := u.db.QueryRow(ctx, query, req.Id).Scan(
+	err := u.db.QueryRow(ctx, query, req.Id, req.IncludeDeleted).Scan(
 		&id,
 		&banner_image,
 		&created_at,
+		&deleted_at,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -102,6 +106,7 @@ func (u *bannerRepo) GetByID(ctx context.Context, req *models.BannerPrimaryKey)
 		Id:           id.String,
 		Banner_image: banner_image.String,
 		CreatedAt:    created_at.String,
+		DeletedAt:    deleted_at.String,
 	}, nil
 }
 
@@ -116,10 +121,15 @@ func (u *bannerRepo) GetList(ctx context.Context, req *models.BannerGetListReque
 			COUNT(*) OVER(),
 			id,
 			banner_image,
-			created_at
+			created_at,
+			deleted_at
 		FROM "banner" 
 	`
 
+	if !req.IncludeDeleted {
+		query += " WHERE deleted_at IS NULL"
+	}
+
 	if req.Offset > 0 {
 		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
 	}
@@ -141,6 +151,7 @@ func (u *bannerRepo) GetList(ctx context.Context, req *models.BannerGetListReque
 			id           sql.NullString
 			banner_image sql.NullString
 			created_at   sql.NullString
+			deleted_at   sql.NullString
 		)
 
 		err = rows.Scan(
@@ -148,6 +159,7 @@ func (u *bannerRepo) GetList(ctx context.Context, req *models.BannerGetListReque
 			&id,
 			&banner_image,
 			&created_at,
+			&deleted_at,
 		)
 		if err != nil {
 			u.log.Error("Error while scanning banner list data: " + err.Error())
@@ -158,15 +170,16 @@ func (u *bannerRepo) GetList(ctx context.Context, req *models.BannerGetListReque
 			Id:           id.String,
 			Banner_image: banner_image.String,
 			CreatedAt:    created_at.String,
+			DeletedAt:    deleted_at.String,
 		})
 	}
 
 	return resp, nil
 }
 
-// Delete removes a banner from the database by ID
+// Delete soft deletes a banner by ID
 func (u *bannerRepo) Delete(ctx context.Context, req *models.BannerPrimaryKey) error {
-	_, err := u.db.Exec(ctx, `DELETE FROM "banner" WHERE id = $1`, req.Id)
+	_, err := u.db.Exec(ctx, `UPDATE "banner" SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, req.Id)
 	if err != nil {
 		u.log.Error("Error while deleting banner: " + err.Error())
 		return err
@@ -175,6 +188,21 @@ func (u *bannerRepo) Delete(ctx context.Context, req *models.BannerPrimaryKey) e
 	return nil
 }
 
+// Restore brings back a soft deleted banner
+func (u *bannerRepo) Restore(ctx context.Context, req *models.BannerPrimaryKey) error {
+	result, err := u.db.Exec(ctx, `UPDATE "banner" SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL`, req.Id)
+	if err != nil {
+		u.log.Error("Error while restoring banner: " + err.Error())
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return storage.ErrNotDeleted
+	}
+
+	return nil
+}
+
 // Update modifies a banner's data in the database
 func (u *bannerRepo) Update(ctx context.Context, req *models.BannerUpdate) (int64, error) {
 	query := `
@@ -182,7 +210,7 @@ func (u *bannerRepo) Update(ctx context.Context, req *models.BannerUpdate) (int6
 		SET
 			banner_image = :banner_image,
 			updated_at = NOW()
-		WHERE id = :id
+		WHERE id = :id AND deleted_at IS NULL
 	`
 
 	params := map[string]interface{}{
@@ -6,7 +6,9 @@ import (
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
 	"e-commerce/pkg/logger"
+	"e-commerce/storage"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -28,30 +30,43 @@ func NewBrandRepo(db *pgxpool.Pool, log logger.LoggerI) *brandRepo {
 // Create inserts a new admin into the database
 func (u *brandRepo) Create(ctx context.Context, req *models.BrandCreate) (*models.Brand, error) {
 	id := uuid.New().String()
+
+	slug, err := uniqueSlug(ctx, u.db, models.SlugEntityBrand, req.Name, id)
+	if err != nil {
+		u.log.Error("Error while making brand slug: " + err.Error())
+		return nil, err
+	}
+
 	query := `
 		INSERT INTO "brand" (
 			id,
 			name,
+			slug,
 			brand_image,
+			name_i18n,
 			created_at
 		)
-		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
-		RETURNING id, name, brand_image, created_at, updated_at, deleted_at
+		VALUES ($1, $2, $3, $4, COALESCE($5::JSONB, '{}'), CURRENT_TIMESTAMP)
+		RETURNING id, name, slug, brand_image, name_i18n::TEXT, created_at, updated_at, deleted_at
 	`
 
 	var (
 		idd         sql.NullString
 		name        sql.NullString
+		slugDB      sql.NullString
 		brand_image sql.NullString
+		name_i18n   sql.NullString
 		created_at  sql.NullString
 		updated_at  sql.NullString
 		delete_at   sql.NullString
 	)
 
-	err := u.db.QueryRow(ctx, query, id, req.Name, req.Brand_image).Scan(
+	err = u.db.QueryRow(ctx, query, id, req.Name, slug, req.Brand_image, translationsParam(req.NameI18n)).Scan(
 		&idd,
 		&name,
+		&slugDB,
 		&brand_image,
+		&name_i18n,
 		&created_at,
 		&updated_at,
 		&delete_at,
@@ -64,7 +79,9 @@ func (u *brandRepo) Create(ctx context.Context, req *models.BrandCreate) (*model
 	return &models.Brand{
 		Id:          idd.String,
 		Name:        name.String,
+		Slug:        slugDB.String,
 		Brand_image: brand_image.String,
+		NameI18n:    scanTranslations(name_i18n),
 		CreatedAt:   created_at.String,
 		UpdatedAt:   updated_at.String,
 		DeletedAt:   delete_at.String,
@@ -76,26 +93,41 @@ func (u *brandRepo) GetByID(ctx context.Context, req *models.BrandPrimaryKey) (*
 		query       string
 		id          sql.NullString
 		name        sql.NullString
+		slug        sql.NullString
 		brand_image sql.NullString
+		name_i18n   sql.NullString
 		created_at  sql.NullString
+		deleted_at  sql.NullString
 	)
 
 	query = `
 		SELECT 
 			id,
 			name,
+			slug,
 			brand_image,
-			created_at
+			name_i18n::TEXT,
+			created_at,
+			deleted_at
 		FROM "brand" 
-		WHERE id = $1
+		WHERE id = $1 AND ($2 OR deleted_at IS NULL)
 
 	`
 
-	err := u.db.QueryRow(ctx, query, req.Id).Scan(
+	key := req.Id
+	if req.Slug != "" {
+		query = strings.Replace(query, "WHERE id = $1", "WHERE slug = $1", 1)
+		key = req.Slug
+	}
+
+	err := u.db.QueryRow(ctx, query, key, req.IncludeDeleted).Scan(
 		&id,
 		&name,
+		&slug,
 		&brand_image,
+		&name_i18n,
 		&created_at,
+		&deleted_at,
 	)
 
 	if err != nil && err.Error() != "no rows in result set" {
@@ -103,11 +135,15 @@ func (u *brandRepo) GetByID(ctx context.Context, req *models.BrandPrimaryKey) (*
 		return nil, err
 	}
 
+	translations := scanTranslations(name_i18n)
 	return &models.Brand{
 		Id:          id.String,
-		Name:        name.String,
+		Name:        translations.Localize(name.String, req.Lang),
+		Slug:        slug.String,
 		Brand_image: brand_image.String,
+		NameI18n:    translations,
 		CreatedAt:   created_at.String,
+		DeletedAt:   deleted_at.String,
 	}, nil
 }
 
@@ -124,12 +160,19 @@ func (u *brandRepo) GetList(ctx context.Context, req *models.BrandGetListRequest
 			COUNT(*) OVER(),
 			id,
 			name,
+			slug,
 			brand_image,
-			created_at
+			name_i18n::TEXT,
+			created_at,
+			deleted_at
 		FROM "brand" 
 		
 	`
 
+	if !req.IncludeDeleted {
+		query += " WHERE deleted_at IS NULL"
+	}
+
 	if req.Offset > 0 {
 		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
 	}
@@ -150,35 +193,46 @@ func (u *brandRepo) GetList(ctx context.Context, req *models.BrandGetListRequest
 		var (
 			id          sql.NullString
 			name        sql.NullString
+			slug        sql.NullString
 			brand_image sql.NullString
+			name_i18n   sql.NullString
 			created_at  sql.NullString
+			deleted_at  sql.NullString
 		)
 
 		err = rows.Scan(
 			&resp.Count,
 			&id,
 			&name,
+			&slug,
 			&brand_image,
+			&name_i18n,
 			&created_at,
+			&deleted_at,
 		)
 		if err != nil {
 			u.log.Error("error is while getting user list (scanning data)", logger.Error(err))
 			return nil, err
 		}
 
+		translations := scanTranslations(name_i18n)
 		resp.Brand = append(resp.Brand, &models.Brand{
 			Id:          id.String,
-			Name:        name.String,
+			Name:        translations.Localize(name.String, req.Lang),
+			Slug:        slug.String,
 			Brand_image: brand_image.String,
+			NameI18n:    translations,
 			CreatedAt:   created_at.String,
+			DeletedAt:   deleted_at.String,
 		})
 	}
 	return resp, nil
 }
 
+// Delete soft deletes the brand, its products keep it
 func (u *brandRepo) Delete(ctx context.Context, req *models.BrandPrimaryKey) error {
 
-	_, err := u.db.Exec(ctx, `DELETE from brand WHERE id = $1`, req.Id)
+	_, err := u.db.Exec(ctx, `UPDATE "brand" SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, req.Id)
 	if err != nil {
 		u.log.Error("error is while deleting brand", logger.Error(err))
 		return err
@@ -187,6 +241,21 @@ func (u *brandRepo) Delete(ctx context.Context, req *models.BrandPrimaryKey) err
 	return nil
 }
 
+// Restore brings back a soft deleted brand
+func (u *brandRepo) Restore(ctx context.Context, req *models.BrandPrimaryKey) error {
+	result, err := u.db.Exec(ctx, `UPDATE "brand" SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL`, req.Id)
+	if err != nil {
+		u.log.Error("error is while restoring brand", logger.Error(err))
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return storage.ErrNotDeleted
+	}
+
+	return nil
+}
+
 func (u *brandRepo) Update(ctx context.Context, req *models.BrandUpdate) (int64, error) {
 	var (
 		query  string
@@ -198,23 +267,42 @@ func (u *brandRepo) Update(ctx context.Context, req *models.BrandUpdate) (int64,
 			"brand"
 		SET
 			name = :name,
+			name_i18n = COALESCE(CAST(:i18n_name AS JSONB), name_i18n),
 			brand_image=:brand_image,
 			updated_at = NOW()
-		WHERE id = :id
+		WHERE id = :id AND deleted_at IS NULL
 	`
 
 	params = map[string]interface{}{
 		"id":          req.Id,
 		"name":        req.Name,
 		"brand_image": req.Brand_image,
+		"i18n_name":   translationsParam(req.NameI18n),
 	}
 
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		u.log.Error("error is while starting brand update transaction", logger.Error(err))
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
 	query, args := helper.ReplaceQueryParams(query, params)
-	result, err := u.db.Exec(ctx, query, args...)
+	result, err := tx.Exec(ctx, query, args...)
 	if err != nil {
 		u.log.Error("error is while updating brand data", logger.Error(err))
 		return 0, err
 	}
 
+	if err = renameSlug(ctx, tx, models.SlugEntityBrand, req.Id, req.Name); err != nil {
+		u.log.Error("error is while updating brand slug", logger.Error(err))
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		u.log.Error("error is while committing brand update", logger.Error(err))
+		return 0, err
+	}
+
 	return result.RowsAffected(), nil
 }
@@ -6,11 +6,15 @@ import (
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
 	"e-commerce/pkg/logger"
+	"e-commerce/storage"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -42,32 +46,44 @@ func (u *categoryRepo) Create(ctx context.Context, req *models.CategoryCreate) (
 		parentId = sql.NullString{Valid: false}
 	}
 
+	slug, err := uniqueSlug(ctx, u.db, models.SlugEntityCategory, req.Name, id.String())
+	if err != nil {
+		u.log.Error("Error while making category slug: " + err.Error())
+		return nil, err
+	}
+
 	query := `
 		INSERT INTO "category" (
 			id,
 			name,
+			slug,
 			url,
 			parent_id,
+			name_i18n,
 			created_at
 		)
-		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
-		RETURNING id, name, url, parent_id, created_at, updated_at
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6::JSONB, '{}'), CURRENT_TIMESTAMP)
+		RETURNING id, name, slug, url, parent_id, name_i18n::TEXT, created_at, updated_at
 	`
 
 	var (
 		idd        sql.NullString
 		name       sql.NullString
+		slugDB     sql.NullString
 		url        sql.NullString
 		parent     sql.NullString
+		name_i18n  sql.NullString
 		created_at sql.NullString
 		updated_at sql.NullString
 	)
 
-	err := u.db.QueryRow(ctx, query, id, req.Name, req.Url, parentId).Scan(
+	err = u.db.QueryRow(ctx, query, id, req.Name, slug, req.Url, parentId, translationsParam(req.NameI18n)).Scan(
 		&idd,
 		&name,
+		&slugDB,
 		&url,
 		&parent,
+		&name_i18n,
 		&created_at,
 		&updated_at,
 	)
@@ -79,8 +95,10 @@ func (u *categoryRepo) Create(ctx context.Context, req *models.CategoryCreate) (
 	return &models.Category{
 		Id:        idd.String,
 		Name:      name.String,
+		Slug:      slugDB.String,
 		Url:       url.String,
 		ParentId:  parent.String,
+		NameI18n:  scanTranslations(name_i18n),
 		CreatedAt: created_at.String,
 		UpdatedAt: updated_at.String,
 	}, nil
@@ -101,9 +119,12 @@ func (u *categoryRepo) GetList(ctx context.Context, req *models.CategoryGetListR
 			COUNT(*) OVER(),
 			id,
 			name,
+			slug,
 			url,
 			parent_id,
-			created_at
+			name_i18n::TEXT,
+			created_at,
+			deleted_at
 		FROM "category"
 	`
 
@@ -115,6 +136,10 @@ func (u *categoryRepo) GetList(ctx context.Context, req *models.CategoryGetListR
 		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
 	}
 
+	if !req.IncludeDeleted {
+		filter += " AND deleted_at IS NULL"
+	}
+
 	// Add filtering by name if the query is provided
 	// if req.Name != "" {
 	// 	// filter += fmt.Sprintf(" AND name ILIKE '%%s%'", req.Name) // Use ILIKE for case-insensitive search
@@ -135,30 +160,40 @@ func (u *categoryRepo) GetList(ctx context.Context, req *models.CategoryGetListR
 		var (
 			id         sql.NullString
 			name       sql.NullString
+			slug       sql.NullString
 			url        sql.NullString
 			parent_id  sql.NullString
+			name_i18n  sql.NullString
 			created_at sql.NullString
+			deleted_at sql.NullString
 		)
 
 		err = rows.Scan(
 			&resp.Count,
 			&id,
 			&name,
+			&slug,
 			&url,
 			&parent_id,
+			&name_i18n,
 			&created_at,
+			&deleted_at,
 		)
 		if err != nil {
 			u.log.Error("Error while getting category list (scanning data): " + err.Error())
 			return nil, err
 		}
 
+		translations := scanTranslations(name_i18n)
 		category := &models.Category{
 			Id:        id.String,
-			Name:      name.String,
+			Name:      translations.Localize(name.String, req.Lang),
+			Slug:      slug.String,
 			Url:       url.String,
 			ParentId:  parent_id.String,
+			NameI18n:  translations,
 			CreatedAt: created_at.String,
+			DeletedAt: deleted_at.String,
 		}
 		categories = append(categories, category)
 	}
@@ -205,30 +240,46 @@ func (u *categoryRepo) GetByID(ctx context.Context, req *models.CategoryPrimaryK
 		query      string
 		id         sql.NullString
 		name       sql.NullString
+		slug       sql.NullString
 		url        sql.NullString
 		parent_id  sql.NullString
+		name_i18n  sql.NullString
 		created_at sql.NullString
 		updated_at sql.NullString
+		deleted_at sql.NullString
 	)
 
 	query = `
 		SELECT 
 			id,
 			name,
+			slug,
 			url,
 			parent_id,
-			created_at
+			name_i18n::TEXT,
+			created_at,
+			updated_at,
+			deleted_at
 		FROM "category" 
-		WHERE id = $1
+		WHERE id = $1 AND ($2 OR deleted_at IS NULL)
 	`
 
-	err := u.db.QueryRow(ctx, query, req.Id).Scan(
+	key := req.Id
+	if req.Slug != "" {
+		query = strings.Replace(query, "WHERE id = $1", "WHERE slug = $1", 1)
+		key = req.Slug
+	}
+
+	err := u.db.QueryRow(ctx, query, key, req.IncludeDeleted).Scan(
 		&id,
 		&name,
+		&slug,
 		&url,
 		&parent_id,
+		&name_i18n,
 		&created_at,
 		&updated_at,
+		&deleted_at,
 	)
 
 	if err != nil && err.Error() != "no rows in result set" {
@@ -236,18 +287,43 @@ func (u *categoryRepo) GetByID(ctx context.Context, req *models.CategoryPrimaryK
 		return nil, err
 	}
 
+	translations := scanTranslations(name_i18n)
 	return &models.Category{
 		Id:        id.String,
-		Name:      name.String,
+		Name:      translations.Localize(name.String, req.Lang),
+		Slug:      slug.String,
 		Url:       url.String,
 		ParentId:  parent_id.String,
+		NameI18n:  translations,
 		CreatedAt: created_at.String,
 		UpdatedAt: updated_at.String,
+		DeletedAt: deleted_at.String,
 	}, nil
 }
 
+// Delete soft deletes a category that has no subcategories and no products,
+// deleted ones do not count
 func (u *categoryRepo) Delete(ctx context.Context, req *models.CategoryPrimaryKey) error {
-	_, err := u.db.Exec(ctx, `DELETE FROM "category" WHERE id = $1`, req.Id)
+	var hasChildren, hasProducts bool
+
+	err := u.db.QueryRow(ctx, `
+		SELECT
+			EXISTS (SELECT 1 FROM "category" WHERE parent_id = $1 AND deleted_at IS NULL),
+			EXISTS (SELECT 1 FROM "product" WHERE category_id = $1 AND deleted_at IS NULL)
+	`, req.Id).Scan(&hasChildren, &hasProducts)
+	if err != nil {
+		u.log.Error("Error while checking category usage: " + err.Error())
+		return err
+	}
+
+	if hasChildren {
+		return storage.ErrCategoryHasChildren
+	}
+	if hasProducts {
+		return storage.ErrCategoryHasProducts
+	}
+
+	_, err = u.db.Exec(ctx, `UPDATE "category" SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, req.Id)
 	if err != nil {
 		u.log.Error("Error while deleting category: " + err.Error())
 		return err
@@ -256,34 +332,296 @@ func (u *categoryRepo) Delete(ctx context.Context, req *models.CategoryPrimaryKe
 	return nil
 }
 
+// Restore brings back a soft deleted category, its parent must not be deleted
+func (u *categoryRepo) Restore(ctx context.Context, req *models.CategoryPrimaryKey) error {
+	var parentDeleted sql.NullBool
+
+	err := u.db.QueryRow(ctx, `
+		SELECT p.deleted_at IS NOT NULL
+		FROM "category" c
+		LEFT JOIN "category" p ON p.id = c.parent_id
+		WHERE c.id = $1 AND c.deleted_at IS NOT NULL
+	`, req.Id).Scan(&parentDeleted)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrNotDeleted
+	}
+	if err != nil {
+		u.log.Error("Error while checking category to restore: " + err.Error())
+		return err
+	}
+	if parentDeleted.Bool {
+		return storage.ErrParentDeleted
+	}
+
+	_, err = u.db.Exec(ctx, `UPDATE "category" SET deleted_at = NULL, updated_at = NOW() WHERE id = $1`, req.Id)
+	if err != nil {
+		u.log.Error("Error while restoring category: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Update renames the category and, when ParentId is given, moves it with its
+// subtree. Moves are serialized so two concurrent moves can not build a cycle.
 func (u *categoryRepo) Update(ctx context.Context, req *models.CategoryUpdate) (int64, error) {
-	var (
-		query  string
-		params map[string]interface{}
-	)
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		u.log.Error("Error while starting category update transaction: " + err.Error())
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
 
-	query = `
+	var parentId sql.NullString
+	if req.ParentId != nil && *req.ParentId != "" {
+		parentId = sql.NullString{String: *req.ParentId, Valid: true}
+
+		_, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('category_move'))`)
+		if err != nil {
+			u.log.Error("Error while locking category tree: " + err.Error())
+			return 0, err
+		}
+
+		var exists, cycle bool
+		err = tx.QueryRow(ctx, `
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM "category" WHERE id = $1
+				UNION ALL
+				SELECT c.id FROM "category" c
+				INNER JOIN subtree s ON c.parent_id = s.id
+			)
+			SELECT
+				EXISTS (SELECT 1 FROM "category" WHERE id = $2 AND deleted_at IS NULL),
+				EXISTS (SELECT 1 FROM subtree WHERE id = $2)
+		`, req.Id, parentId.String).Scan(&exists, &cycle)
+		if err != nil {
+			u.log.Error("Error while checking category parent: " + err.Error())
+			return 0, err
+		}
+
+		if !exists {
+			return 0, storage.ErrCategoryParentNotFound
+		}
+		if cycle {
+			return 0, storage.ErrCategoryCycle
+		}
+	}
+
+	// the translations key must not start with another key, the keys are
+	// replaced one by one
+	params := map[string]interface{}{
+		"id":        req.Id,
+		"name":      req.Name,
+		"url":       req.Url,
+		"i18n_name": translationsParam(req.NameI18n),
+	}
+
+	move := ""
+	if req.ParentId != nil {
+		move = "parent_id = :parent_id,"
+		params["parent_id"] = parentId
+	}
+
+	query := `
 		UPDATE
 			"category"
 		SET
-			name = :name,
-			url = :url,
-			parent_id = :parent_id,
+			name = COALESCE(NULLIF(:name, ''), name),
+			name_i18n = COALESCE(CAST(:i18n_name AS JSONB), name_i18n),
+			url = COALESCE(NULLIF(:url, ''), url),
+			` + move + `
 			updated_at = NOW()
-		WHERE id = :id
+		WHERE id = :id AND deleted_at IS NULL
 	`
 
-	params = map[string]interface{}{
-		"id":   req.Id,
-		"name": req.Name,
-	}
-
 	query, args := helper.ReplaceQueryParams(query, params)
-	result, err := u.db.Exec(ctx, query, args...)
+	result, err := tx.Exec(ctx, query, args...)
 	if err != nil {
 		u.log.Error("Error while updating category data: " + err.Error())
 		return 0, err
 	}
 
+	// a move alone keeps the name and so the slug
+	if req.Name != "" {
+		if err = renameSlug(ctx, tx, models.SlugEntityCategory, req.Id, req.Name); err != nil {
+			u.log.Error("Error while updating category slug: " + err.Error())
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		u.log.Error("Error while committing category update: " + err.Error())
+		return 0, err
+	}
+
 	return result.RowsAffected(), nil
 }
+
+// GetTree returns the categories nested under their parents, starting from
+// the root categories or from RootId when given
+func (u *categoryRepo) GetTree(ctx context.Context, req *models.CategoryTreeRequest) (*models.CategoryTreeResponse, error) {
+	var (
+		args  []interface{}
+		start = "parent_id IS NULL AND deleted_at IS NULL"
+	)
+
+	if req.RootId != "" {
+		start = "id = $1 AND deleted_at IS NULL"
+		args = append(args, req.RootId)
+	}
+
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT id, name, slug, url, parent_id, name_i18n, created_at FROM "category" WHERE ` + start + `
+			UNION ALL
+			SELECT c.id, c.name, c.slug, c.url, c.parent_id, c.name_i18n, c.created_at FROM "category" c
+			INNER JOIN tree t ON c.parent_id = t.id
+			WHERE c.deleted_at IS NULL
+		)
+		SELECT id, name, slug, url, parent_id, name_i18n::TEXT FROM tree
+		ORDER BY created_at
+	`
+
+	rows, err := u.db.Query(ctx, query, args...)
+	if err != nil {
+		u.log.Error("Error while getting category tree: " + err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		resp  = &models.CategoryTreeResponse{Category: []*models.CategoryTree{}}
+		nodes = map[string]*models.CategoryTree{}
+		order []*models.CategoryTree
+	)
+
+	for rows.Next() {
+		var (
+			id        sql.NullString
+			name      sql.NullString
+			slug      sql.NullString
+			url       sql.NullString
+			parent_id sql.NullString
+			name_i18n sql.NullString
+		)
+
+		if err = rows.Scan(&id, &name, &slug, &url, &parent_id, &name_i18n); err != nil {
+			u.log.Error("Error while scanning category tree: " + err.Error())
+			return nil, err
+		}
+
+		translations := scanTranslations(name_i18n)
+		node := &models.CategoryTree{
+			Id:       id.String,
+			Name:     translations.Localize(name.String, req.Lang),
+			Slug:     slug.String,
+			Url:      url.String,
+			ParentId: parent_id.String,
+			NameI18n: translations,
+			Children: []*models.CategoryTree{},
+		}
+		nodes[node.Id] = node
+		order = append(order, node)
+	}
+	if err = rows.Err(); err != nil {
+		u.log.Error("Error while iterating category tree: " + err.Error())
+		return nil, err
+	}
+
+	if req.RootId != "" && len(order) == 0 {
+		return nil, storage.ErrCategoryNotFound
+	}
+
+	for _, node := range order {
+		if parent, ok := nodes[node.ParentId]; ok && node.Id != req.RootId {
+			parent.Children = append(parent.Children, node)
+			continue
+		}
+		resp.Category = append(resp.Category, node)
+	}
+
+	return resp, nil
+}
+
+// GetBreadcrumbs returns the category with its ancestors, the root first
+func (u *categoryRepo) GetBreadcrumbs(ctx context.Context, req *models.CategoryPrimaryKey) (*models.CategoryBreadcrumbsResponse, error) {
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT id, name, slug, url, parent_id, name_i18n, created_at, 0 AS depth FROM "category" WHERE id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT c.id, c.name, c.slug, c.url, c.parent_id, c.name_i18n, c.created_at, a.depth + 1 FROM "category" c
+			INNER JOIN ancestors a ON c.id = a.parent_id
+		)
+		SELECT id, name, slug, url, parent_id, name_i18n::TEXT, created_at FROM ancestors
+		ORDER BY depth DESC
+	`
+
+	key := req.Id
+	if req.Slug != "" {
+		query = strings.Replace(query, "WHERE id = $1", "WHERE slug = $1", 1)
+		key = req.Slug
+	}
+
+	rows, err := u.db.Query(ctx, query, key)
+	if err != nil {
+		u.log.Error("Error while getting category breadcrumbs: " + err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	resp := &models.CategoryBreadcrumbsResponse{Category: []*models.Category{}}
+	for rows.Next() {
+		var (
+			id         sql.NullString
+			name       sql.NullString
+			slug       sql.NullString
+			url        sql.NullString
+			parent_id  sql.NullString
+			name_i18n  sql.NullString
+			created_at sql.NullString
+		)
+
+		if err = rows.Scan(&id, &name, &slug, &url, &parent_id, &name_i18n, &created_at); err != nil {
+			u.log.Error("Error while scanning category breadcrumbs: " + err.Error())
+			return nil, err
+		}
+
+		translations := scanTranslations(name_i18n)
+		resp.Category = append(resp.Category, &models.Category{
+			Id:        id.String,
+			Name:      translations.Localize(name.String, req.Lang),
+			Slug:      slug.String,
+			Url:       url.String,
+			ParentId:  parent_id.String,
+			NameI18n:  translations,
+			CreatedAt: created_at.String,
+		})
+	}
+	if err = rows.Err(); err != nil {
+		u.log.Error("Error while iterating category breadcrumbs: " + err.Error())
+		return nil, err
+	}
+
+	if len(resp.Category) == 0 {
+		return nil, storage.ErrCategoryNotFound
+	}
+
+	return resp, nil
+}
+
+func filterStrings(stringsList []string, query string) []string {
+	var results []string
+	query = strings.ToLower(query)
+
+	for _, str := range stringsList {
+		words := strings.Fields(str)
+		for _, word := range words {
+			if strings.HasPrefix(strings.ToLower(word), query) {
+				results = append(results, str)
+				break
+			}
+		}
+	}
+
+	return results
+}
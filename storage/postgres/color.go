@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	"e-commerce/models"
 	"e-commerce/pkg/logger"
+	"e-commerce/storage"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/lib/pq"
 )
@@ -52,7 +55,18 @@ func (u *colorRepo) Create(ctx context.Context, req *models.ColorCreate) (*model
 		created_at sql.NullTime
 	)
 
-	err := u.db.QueryRow(ctx, query, id, req.ProductId, req.Name, req.Url, req.Count).Scan(
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = setStockMovement(ctx, tx, models.InventoryMovementInitial, "", req.CreatedBy); err != nil {
+		u.log.Error("error while setting color stock movement", logger.Error(err))
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, query, id, req.ProductId, req.Name, req.Url, req.Count).Scan(
 		&idd,
 		&product_id,
 		&name,
@@ -65,6 +79,10 @@ func (u *colorRepo) Create(ctx context.Context, req *models.ColorCreate) (*model
 		return nil, err
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
 	return &models.Color{
 		Id:        idd.String,
 		ProductId: req.ProductId,
@@ -75,6 +93,55 @@ func (u *colorRepo) Create(ctx context.Context, req *models.ColorCreate) (*model
 	}, nil
 }
 
+func (u *colorRepo) GetByID(ctx context.Context, req *models.ColorPrimaryKey) (*models.Color, error) {
+	var (
+		id         sql.NullString
+		product_id sql.NullString
+		color_name sql.NullString
+		color_url  pq.StringArray
+		count      sql.NullInt32
+		created_at sql.NullString
+		deleted_at sql.NullString
+	)
+
+	query := `
+		SELECT
+			id,
+			product_id,
+			color_name,
+			color_url,
+			count,
+			created_at,
+			deleted_at
+		FROM "color"
+		WHERE id = $1 AND ($2 OR deleted_at IS NULL)
+	`
+
+	err := u.db.QueryRow(ctx, query, req.Id, req.IncludeDeleted).Scan(
+		&id,
+		&product_id,
+		&color_name,
+		&color_url,
+		&count,
+		&created_at,
+		&deleted_at,
+	)
+	if err != nil {
+		u.log.Error("Error while getting color by id: " + err.Error())
+		return nil, err
+	}
+
+	return &models.Color{
+		Id:        id.String,
+		ProductId: product_id.String,
+		Name:      color_name.String,
+		Url:       color_url,
+		Count:     int(count.Int32),
+		CreatedAt: created_at.String,
+		DeletedAt: deleted_at.String,
+	}, nil
+}
+
 func (u *colorRepo) GetList(ctx context.Context, req *models.ColorGetListRequest) (*models.ColorGetListResponse, error) {
 	resp := &models.ColorGetListResponse{}
 	offset := " OFFSET 0"
@@ -88,10 +155,15 @@ func (u *colorRepo) GetList(ctx context.Context, req *models.ColorGetListRequest
 			color_name,
 			color_url,
 			count,
-			created_at
+			created_at,
+			deleted_at
 		FROM "color"
 	`
 
+	if !req.IncludeDeleted {
+		query += " WHERE deleted_at IS NULL"
+	}
+
 	if req.Offset > 0 {
 		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
 	}
@@ -115,6 +187,7 @@ func (u *colorRepo) GetList(ctx context.Context, req *models.ColorGetListRequest
 			color_url  pq.StringArray
 			count      sql.NullInt32
 			created_at sql.NullString
+			deleted_at sql.NullString
 		)
 
 		err = rows.Scan(
@@ -125,6 +198,7 @@ func (u *colorRepo) GetList(ctx context.Context, req *models.ColorGetListRequest
 			&color_url,
 			&count,
 			&created_at,
+			&deleted_at,
 		)
 		if err != nil {
 			u.log.Error("Error while scanning color list data: " + err.Error())
@@ -138,13 +212,41 @@ func (u *colorRepo) GetList(ctx context.Context, req *models.ColorGetListRequest
 			Url:       color_url,
 			Count:     int(count.Int32),
 			CreatedAt: created_at.String,
+			DeletedAt: deleted_at.String,
 		})
 	}
 	return resp, nil
 }
 
+// Update renames the color and replaces its images, empty ones are kept. The
+// stock is left to AdjustStock.
+func (u *colorRepo) Update(ctx context.Context, req *models.ColorUpdate) (int64, error) {
+	query := `
+		UPDATE "color"
+		SET
+			color_name = COALESCE(NULLIF($1, ''), color_name),
+			color_url = COALESCE($2, color_url),
+			updated_at = NOW()
+		WHERE id = $3 AND deleted_at IS NULL
+	`
+
+	result, err := u.db.Exec(ctx, query, req.Name, pq.StringArray(req.Url), req.Id)
+	if err != nil {
+		u.log.Error("Error while updating color: " + err.Error())
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// AdjustStock adds the delta to the stock of a color that is not deleted
+func (u *colorRepo) AdjustStock(ctx context.Context, req *models.StockAdjustment) (*models.StockAdjustmentResponse, error) {
+	return adjustStock(ctx, u.db, "color", "deleted_at IS NULL", "color_id = $2 AND variant_id IS NULL", req, storage.ErrColorNotFound)
+}
+
+// Delete soft deletes the color, the order items referencing it keep it
 func (u *colorRepo) Delete(ctx context.Context, req *models.ColorPrimaryKey) error {
-	_, err := u.db.Exec(ctx, `DELETE FROM "color" WHERE id = $1`, req.Id)
+	_, err := u.db.Exec(ctx, `UPDATE "color" SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, req.Id)
 	if err != nil {
 		u.log.Error("Error while deleting color: " + err.Error())
 		return err
@@ -152,3 +254,33 @@ func (u *colorRepo) Delete(ctx context.Context, req *models.ColorPrimaryKey) err
 
 	return nil
 }
+
+// Restore brings back a soft deleted color, its product must not be deleted
+func (u *colorRepo) Restore(ctx context.Context, req *models.ColorPrimaryKey) error {
+	var productDeleted sql.NullBool
+
+	err := u.db.QueryRow(ctx, `
+		SELECT p.deleted_at IS NOT NULL
+		FROM "color" c
+		LEFT JOIN "product" p ON p.id = c.product_id
+		WHERE c.id = $1 AND c.deleted_at IS NOT NULL
+	`, req.Id).Scan(&productDeleted)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrNotDeleted
+	}
+	if err != nil {
+		u.log.Error("Error while checking color to restore: " + err.Error())
+		return err
+	}
+	if productDeleted.Bool {
+		return storage.ErrParentDeleted
+	}
+
+	_, err = u.db.Exec(ctx, `UPDATE "color" SET deleted_at = NULL, updated_at = NOW() WHERE id = $1`, req.Id)
+	if err != nil {
+		u.log.Error("Error while restoring color: " + err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -6,9 +6,13 @@ import (
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
 	"e-commerce/pkg/logger"
+	"e-commerce/pkg/password"
+	"e-commerce/storage"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -31,6 +35,7 @@ func (c *customerRepo) GetByLogin(ctx context.Context, login string) (models.Cus
 		phone     sql.NullString
 		birthday  sql.NullString
 		gender    sql.NullString
+		password  sql.NullString
 		createdat sql.NullString
 		updatedat sql.NullString
 	)
@@ -40,11 +45,12 @@ func (c *customerRepo) GetByLogin(ctx context.Context, login string) (models.Cus
 	 name, 
 	 surname, 
 	 phone_number,
-	 birthday,
+	 birthday::TEXT,
 	 gender,
+	 password,
 	 created_at, 
-	 updated_at,
-	 FROM "customer" WHERE phone_number= $1 `
+	 updated_at
+	 FROM "customer" WHERE phone_number= $1 AND deleted_at IS NULL `
 
 	row := c.db.QueryRow(ctx, query, login)
 
@@ -57,6 +63,7 @@ func (c *customerRepo) GetByLogin(ctx context.Context, login string) (models.Cus
 		&phone,
 		&birthday,
 		&gender,
+		&password,
 		&createdat,
 		&updatedat,
 	)
@@ -71,6 +78,7 @@ func (c *customerRepo) GetByLogin(ctx context.Context, login string) (models.Cus
 	user.Phone_number = phone.String
 	user.Birthday = birthday.String // Yangi qo'shilgan ustun
 	user.Gender = gender.String     // Yangi qo'shilgan ustun
+	user.Password = password.String
 	user.CreatedAt = createdat.String
 	user.UpdatedAt = updatedat.String
 
@@ -85,7 +93,7 @@ func (c *customerRepo) GetByPhoneNumber(ctx context.Context, req string) (models
 		SELECT 
 			id
 		FROM "customer"
-		WHERE phone_number = $1
+		WHERE phone_number = $1 AND deleted_at IS NULL
 	`
 
 	err := c.db.QueryRow(ctx, query, req).Scan(&resp.Id)
@@ -136,6 +144,17 @@ func (u *customerRepo) Create(ctx context.Context, req *models.CustomerCreate) (
 	// 	return nil, fmt.Errorf("invalid email format")
 	// }
 
+	// customers registered by OTP have no password
+	var passwordHash sql.NullString
+	if req.Password != "" {
+		hash, err := password.HashPassword(req.Password)
+		if err != nil {
+			u.log.Error("Error while hashing customer password: " + err.Error())
+			return nil, err
+		}
+		passwordHash = sql.NullString{String: hash, Valid: true}
+	}
+
 	id := uuid.New().String()
 	query := `
 	INSERT INTO "customer"(
@@ -145,9 +164,10 @@ func (u *customerRepo) Create(ctx context.Context, req *models.CustomerCreate) (
 		phone_number,
 		birthday,
 		gender,
+		password,
 		created_at
 )
-	VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
 	RETURNING id, name, surname, phone_number, birthday, gender, created_at, updated_at
 	`
 	var (
@@ -161,7 +181,7 @@ func (u *customerRepo) Create(ctx context.Context, req *models.CustomerCreate) (
 		updated_at   sql.NullString
 	)
 
-	err := u.db.QueryRow(ctx, query, id, req.Name, req.Surname, req.Phone_number, req.Birthday, req.Gender).Scan(
+	err := u.db.QueryRow(ctx, query, id, req.Name, req.Surname, req.Phone_number, req.Birthday, req.Gender, passwordHash).Scan(
 		&idd,
 		&name,
 		&surname,
@@ -198,6 +218,7 @@ func (u *customerRepo) GetByID(ctx context.Context, req *models.CustomerPrimaryK
 		birthday     sql.NullString
 		gender       sql.NullString
 		created_at   sql.NullString
+		deleted_at   sql.NullString
 	)
 
 	query = `
@@ -208,13 +229,14 @@ func (u *customerRepo) GetByID(ctx context.Context, req *models.CustomerPrimaryK
 			phone_number,
 			birthday,
 			gender,
-			created_at
+			created_at,
+			deleted_at
 		FROM "customer" 
-		WHERE id = $1
+		WHERE id = $1 AND ($2 OR deleted_at IS NULL)
 
 	`
 
-	err := u.db.QueryRow(ctx, query, req.Id).Scan(
+	err := u.db.QueryRow(ctx, query, req.Id, req.IncludeDeleted).Scan(
 		&id,
 		&name,
 		&surname,
@@ -222,6 +244,7 @@ func (u *customerRepo) GetByID(ctx context.Context, req *models.CustomerPrimaryK
 		&birthday,
 		&gender,
 		&created_at,
+		&deleted_at,
 	)
 
 	if err != nil && err.Error() != "no rows in result set" {
@@ -237,6 +260,7 @@ func (u *customerRepo) GetByID(ctx context.Context, req *models.CustomerPrimaryK
 		Birthday:     birthday.String,
 		Gender:       gender.String,
 		CreatedAt:    created_at.String,
+		DeletedAt:    deleted_at.String,
 	}, nil
 }
 
@@ -257,10 +281,15 @@ func (u *customerRepo) GetList(ctx context.Context, req *models.CustomerGetListR
 			phone_number,
 			birthday,
 			gender,
-			created_at
+			created_at,
+			deleted_at
 		FROM "customer" 
 	`
 
+	if !req.IncludeDeleted {
+		query += " WHERE deleted_at IS NULL"
+	}
+
 	if req.Offset > 0 {
 		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
 	}
@@ -285,6 +314,7 @@ func (u *customerRepo) GetList(ctx context.Context, req *models.CustomerGetListR
 			birthday     sql.NullString
 			gender       sql.NullString
 			created_at   sql.NullString
+			deleted_at   sql.NullString
 		)
 
 		err = rows.Scan(
@@ -296,6 +326,7 @@ func (u *customerRepo) GetList(ctx context.Context, req *models.CustomerGetListR
 			&birthday,
 			&gender,
 			&created_at,
+			&deleted_at,
 		)
 		if err != nil {
 			u.log.Error("error is while getting user list (scanning data)", logger.Error(err))
@@ -310,14 +341,17 @@ func (u *customerRepo) GetList(ctx context.Context, req *models.CustomerGetListR
 			Birthday:     birthday.String,
 			Gender:       gender.String,
 			CreatedAt:    created_at.String,
+			DeletedAt:    deleted_at.String,
 		})
 	}
 	return resp, nil
 }
 
+// Delete soft deletes the customer, their orders and reviews stay and the
+// phone number can be registered again
 func (u *customerRepo) Delete(ctx context.Context, req *models.CustomerPrimaryKey) error {
 
-	_, err := u.db.Exec(ctx, `DELETE from customer WHERE id = $1`, req.Id)
+	_, err := u.db.Exec(ctx, `UPDATE "customer" SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, req.Id)
 	if err != nil {
 		u.log.Error("error is while deleting customer", logger.Error(err))
 		return err
@@ -326,6 +360,39 @@ func (u *customerRepo) Delete(ctx context.Context, req *models.CustomerPrimaryKe
 	return nil
 }
 
+// Restore brings back a soft deleted customer unless their phone number has
+// been registered again meanwhile
+func (u *customerRepo) Restore(ctx context.Context, req *models.CustomerPrimaryKey) error {
+	var registered sql.NullBool
+
+	err := u.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM "customer" o
+			WHERE o.phone_number = c.phone_number AND o.deleted_at IS NULL
+		)
+		FROM "customer" c
+		WHERE c.id = $1 AND c.deleted_at IS NOT NULL
+	`, req.Id).Scan(&registered)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrNotDeleted
+	}
+	if err != nil {
+		u.log.Error("error is while checking customer to restore", logger.Error(err))
+		return err
+	}
+	if registered.Bool {
+		return storage.ErrPhoneRegistered
+	}
+
+	_, err = u.db.Exec(ctx, `UPDATE "customer" SET deleted_at = NULL, updated_at = NOW() WHERE id = $1`, req.Id)
+	if err != nil {
+		u.log.Error("error is while restoring customer", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
 func (u *customerRepo) Update(ctx context.Context, req *models.CustomerUpdate) (int64, error) {
 
 	if !helper.IsValidPhone(req.Phone_number) {
@@ -338,6 +405,17 @@ func (u *customerRepo) Update(ctx context.Context, req *models.CustomerUpdate) (
 		params map[string]interface{}
 	)
 
+	// an empty password keeps the current one
+	var passwordHash string
+	if req.Password != "" {
+		hash, err := password.HashPassword(req.Password)
+		if err != nil {
+			u.log.Error("error is while hashing customer password", logger.Error(err))
+			return 0, err
+		}
+		passwordHash = hash
+	}
+
 	query = `
 		UPDATE
 			"customer"
@@ -347,8 +425,9 @@ func (u *customerRepo) Update(ctx context.Context, req *models.CustomerUpdate) (
 			phone_number = :phone_number,
 			birthday = :birthday,
 			gender = :gender,
+			password = COALESCE(NULLIF(:password, ''), password),
 			updated_at = NOW()
-		WHERE id = :id
+		WHERE id = :id AND deleted_at IS NULL
 	`
 
 	params = map[string]interface{}{
@@ -358,6 +437,7 @@ func (u *customerRepo) Update(ctx context.Context, req *models.CustomerUpdate) (
 		"phone_number": req.Phone_number,
 		"birthday":     req.Birthday,
 		"gender":       req.Gender,
+		"password":     passwordHash,
 	}
 
 	query, args := helper.ReplaceQueryParams(query, params)
@@ -369,3 +449,14 @@ func (u *customerRepo) Update(ctx context.Context, req *models.CustomerUpdate) (
 
 	return result.RowsAffected(), nil
 }
+
+// UpdatePasswordHash stores an already hashed password, used to upgrade legacy plaintext rows
+func (u *customerRepo) UpdatePasswordHash(ctx context.Context, id string, passwordHash string) error {
+	_, err := u.db.Exec(ctx, `UPDATE "customer" SET password = $1, updated_at = NOW() WHERE id = $2`, passwordHash, id)
+	if err != nil {
+		u.log.Error("error is while updating customer password", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
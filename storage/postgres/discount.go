@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"e-commerce/models"
+	"e-commerce/storage"
+	"time"
+)
+
+// expireDiscountsQuery zeroes the discount of products whose end time has
+// passed and gives them back the status they had before it
+const expireDiscountsQuery = `
+	WITH due AS (
+		SELECT
+			id,
+			status,
+			with_discount,
+			discount_percent,
+			discount_start_time,
+			discount_end_time
+		FROM "product"
+		WHERE discount_end_time <= $1
+			AND (COALESCE(discount_percent, 0) > 0 OR COALESCE(with_discount, 0) > 0 OR status = 'vremennaya_skidka')
+			AND ($2 = '' OR id = NULLIF($2, '')::UUID)
+		FOR UPDATE SKIP LOCKED
+	), expired AS (
+		UPDATE "product" p
+		SET
+			status = CASE WHEN p.status = 'vremennaya_skidka' THEN COALESCE(p.status_before_discount, '') ELSE p.status END,
+			with_discount = 0,
+			discount_percent = 0,
+			discount_start_time = NULL,
+			discount_end_time = NULL,
+			status_before_discount = NULL,
+			updated_at = NOW()
+		FROM due
+		WHERE p.id = due.id
+		RETURNING
+			p.id,
+			due.status AS status_before,
+			p.status AS status_after,
+			p.price,
+			due.with_discount AS with_discount_before,
+			p.with_discount AS with_discount_after,
+			due.discount_percent,
+			due.discount_start_time,
+			due.discount_end_time
+	), recorded AS (
+		INSERT INTO "product_discount_transition" (
+			id,
+			product_id,
+			transition,
+			status_before,
+			status_after,
+			price,
+			with_discount_before,
+			with_discount_after,
+			discount_percent,
+			discount_start_time,
+			discount_end_time
+		)
+		SELECT gen_random_uuid(), id, 'expired', status_before, status_after, price,
+			with_discount_before, with_discount_after, discount_percent, discount_start_time, discount_end_time
+		FROM expired
+		RETURNING 1
+	)
+	SELECT COUNT(*) FROM recorded
+`
+
+// activateDiscountsQuery gives products whose discount has started the
+// vremennaya_skidka status and the discounted price, remembering the status
+// to go back to
+const activateDiscountsQuery = `
+	WITH due AS (
+		SELECT
+			id,
+			status,
+			with_discount
+		FROM "product"
+		WHERE discount_percent > 0
+			AND discount_start_time <= $1
+			AND (discount_end_time IS NULL OR discount_end_time > $1)
+			AND (status IS DISTINCT FROM 'vremennaya_skidka' OR COALESCE(with_discount, 0) <= 0)
+			AND ($2 = '' OR id = NULLIF($2, '')::UUID)
+		FOR UPDATE SKIP LOCKED
+	), activated AS (
+		UPDATE "product" p
+		SET
+			status = 'vremennaya_skidka',
+			status_before_discount = CASE WHEN due.status = 'vremennaya_skidka' THEN p.status_before_discount ELSE COALESCE(due.status, '') END,
+			with_discount = ROUND(p.price * (100 - p.discount_percent) / 100, 2),
+			updated_at = NOW()
+		FROM due
+		WHERE p.id = due.id
+		RETURNING
+			p.id,
+			due.status AS status_before,
+			p.status AS status_after,
+			p.price,
+			due.with_discount AS with_discount_before,
+			p.with_discount AS with_discount_after,
+			p.discount_percent,
+			p.discount_start_time,
+			p.discount_end_time
+	), recorded AS (
+		INSERT INTO "product_discount_transition" (
+			id,
+			product_id,
+			transition,
+			status_before,
+			status_after,
+			price,
+			with_discount_before,
+			with_discount_after,
+			discount_percent,
+			discount_start_time,
+			discount_end_time
+		)
+		SELECT gen_random_uuid(), id, 'activated', status_before, status_after, price,
+			with_discount_before, with_discount_after, discount_percent, discount_start_time, discount_end_time
+		FROM activated
+		RETURNING 1
+	)
+	SELECT COUNT(*) FROM recorded
+`
+
+// ApplyDiscounts activates the discounts whose start time has come and
+// expires the ones whose end time has passed. It returns the number of
+// transitions it made.
+func (u *productRepo) ApplyDiscounts(ctx context.Context) (int64, error) {
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	transitions, err := applyDiscounts(ctx, tx, "")
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return transitions, nil
+}
+
+// applyDiscounts makes the due discount transitions of one product, or of all
+// of them when productId is empty, and records each one. Products locked by
+// another transaction are left for the next run. It must run in a
+// transaction for the price history to get the reason of the change.
+func applyDiscounts(ctx context.Context, db rowQuerier, productId string) (int64, error) {
+	// discount times are stored as UTC wall clock
+	now := time.Now().UTC()
+
+	var expired, activated int64
+
+	if err := setPriceChangeReason(ctx, db, models.PriceChangeReasonDiscountExpired); err != nil {
+		return 0, err
+	}
+
+	err := db.QueryRow(ctx, expireDiscountsQuery, now, productId).Scan(&expired)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = setPriceChangeReason(ctx, db, models.PriceChangeReasonDiscountActivated); err != nil {
+		return 0, err
+	}
+
+	err = db.QueryRow(ctx, activateDiscountsQuery, now, productId).Scan(&activated)
+	if err != nil {
+		return 0, err
+	}
+
+	return expired + activated, nil
+}
+
+// discountPeriod parses the discount of a product with the vremennaya_skidka
+// status and a positive percent. The discount starts at the start time, now
+// when it is empty, and runs until the end time or without an end.
+func discountPeriod(status string, percent float64, startTime, endTime string) (discounted bool, start, end interface{}, err error) {
+	if status != models.ProductStatusDiscount || percent <= 0 {
+		return false, nil, nil, nil
+	}
+	if percent >= 100 {
+		return false, nil, nil, storage.ErrDiscountInvalid
+	}
+
+	startAt := time.Now().UTC()
+	if startTime != "" {
+		parsed, err := time.Parse(time.RFC3339, startTime)
+		if err != nil {
+			return false, nil, nil, storage.ErrDiscountInvalid
+		}
+		startAt = parsed.UTC()
+	}
+	start = startAt
+
+	if endTime != "" {
+		parsed, err := time.Parse(time.RFC3339, endTime)
+		if err != nil || !parsed.After(startAt) {
+			return false, nil, nil, storage.ErrDiscountInvalid
+		}
+		end = parsed.UTC()
+	}
+
+	return true, start, end, nil
+}
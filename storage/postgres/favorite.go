@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"e-commerce/models"
+	"e-commerce/pkg/logger"
+	"e-commerce/storage"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type favoriteRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewFavoriteRepo initializes a new instance of favoriteRepo
+func NewFavoriteRepo(db *pgxpool.Pool, log logger.LoggerI) *favoriteRepo {
+	return &favoriteRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create adds the product to the wishlist of the customer, adding it again
+// keeps the time it was first added
+func (r *favoriteRepo) Create(ctx context.Context, req *models.FavoritePrimaryKey) (*models.Favorite, error) {
+	var createdAt string
+
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO "customer_favorite" (customer_id, product_id)
+		SELECT $1, id FROM "product" WHERE id = $2 AND deleted_at IS NULL
+		ON CONFLICT (customer_id, product_id) DO UPDATE SET customer_id = EXCLUDED.customer_id
+		RETURNING created_at::TEXT
+	`, req.CustomerId, req.ProductId).Scan(&createdAt)
+	if err == pgx.ErrNoRows {
+		return nil, storage.ErrProductNotFound
+	}
+	if err != nil {
+		r.log.Error("error while adding favorite", logger.Error(err))
+		return nil, err
+	}
+
+	return &models.Favorite{
+		CustomerId: req.CustomerId,
+		ProductId:  req.ProductId,
+		CreatedAt:  createdAt,
+	}, nil
+}
+
+// Delete removes the product from the wishlist, removing a product that is
+// not in it is not an error
+func (r *favoriteRepo) Delete(ctx context.Context, req *models.FavoritePrimaryKey) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM "customer_favorite" WHERE customer_id = $1 AND product_id = $2`, req.CustomerId, req.ProductId)
+	if err != nil {
+		r.log.Error("error while deleting favorite", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
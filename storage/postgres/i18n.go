@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"database/sql"
+	"e-commerce/models"
+	"encoding/json"
+)
+
+// translationsParam encodes the translations for a JSONB column, nil stays
+// NULL so an update can keep the stored ones
+func translationsParam(translations models.Translations) interface{} {
+	if translations == nil {
+		return nil
+	}
+
+	encoded, err := json.Marshal(translations)
+	if err != nil {
+		return nil
+	}
+	return string(encoded)
+}
+
+// scanTranslations decodes a translations column selected as TEXT
+func scanTranslations(value sql.NullString) models.Translations {
+	translations := models.Translations{}
+	if value.Valid {
+		json.Unmarshal([]byte(value.String), &translations)
+	}
+	return translations
+}
@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/models"
+	"e-commerce/pkg/logger"
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type inventoryRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewInventoryRepo initializes a new instance of inventoryRepo
+func NewInventoryRepo(db *pgxpool.Pool, log logger.LoggerI) *inventoryRepo {
+	return &inventoryRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// GetList returns the stock movements written by the inventory triggers
+func (i *inventoryRepo) GetList(ctx context.Context, req *models.InventoryMovementGetListRequest) (*models.InventoryMovementGetListResponse, error) {
+	var (
+		resp  = &models.InventoryMovementGetListResponse{Movements: []*models.InventoryMovement{}}
+		args  = []interface{}{}
+		argId = 1
+	)
+
+	query := `
+		SELECT
+			COUNT(*) OVER(),
+			id,
+			product_id::TEXT,
+			color_id::TEXT,
+			variant_id::TEXT,
+			kind,
+			quantity,
+			count_after,
+			order_id::TEXT,
+			changed_by::TEXT,
+			created_at
+		FROM "inventory_movement"
+		WHERE TRUE
+	`
+
+	filters := []struct {
+		column string
+		value  string
+	}{
+		{"product_id = ", req.ProductId},
+		{"color_id = ", req.ColorId},
+		{"variant_id = ", req.VariantId},
+		{"order_id = ", req.OrderId},
+		{"kind = ", req.Kind},
+	}
+
+	for _, filter := range filters {
+		if filter.value == "" {
+			continue
+		}
+		query += fmt.Sprintf(" AND %s$%d", filter.column, argId)
+		args = append(args, filter.value)
+		argId++
+	}
+
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id LIMIT $%d OFFSET $%d", argId, argId+1)
+	args = append(args, req.Limit, req.Offset)
+
+	rows, err := i.db.Query(ctx, query, args...)
+	if err != nil {
+		i.log.Error("error while getting inventory movements", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id          sql.NullString
+			product_id  sql.NullString
+			color_id    sql.NullString
+			variant_id  sql.NullString
+			kind        sql.NullString
+			quantity    int
+			count_after int
+			order_id    sql.NullString
+			changed_by  sql.NullString
+			created_at  sql.NullString
+		)
+
+		err = rows.Scan(
+			&resp.Count,
+			&id,
+			&product_id,
+			&color_id,
+			&variant_id,
+			&kind,
+			&quantity,
+			&count_after,
+			&order_id,
+			&changed_by,
+			&created_at,
+		)
+		if err != nil {
+			i.log.Error("error while scanning inventory movement", logger.Error(err))
+			return nil, err
+		}
+
+		resp.Movements = append(resp.Movements, &models.InventoryMovement{
+			Id:         id.String,
+			ProductId:  product_id.String,
+			ColorId:    color_id.String,
+			VariantId:  variant_id.String,
+			Kind:       kind.String,
+			Quantity:   quantity,
+			CountAfter: count_after,
+			OrderId:    order_id.String,
+			ChangedBy:  changed_by.String,
+			CreatedAt:  created_at.String,
+		})
+	}
+
+	return resp, rows.Err()
+}
+
+// Reconcile sums the ledger of every color and variant, of the product when
+// one is given, and reports the ones whose stock differs from the sum.
+// Deleted colors are checked as well, their stock is kept.
+func (i *inventoryRepo) Reconcile(ctx context.Context, req *models.InventoryReconcileRequest) (*models.InventoryReconcileResponse, error) {
+	resp := &models.InventoryReconcileResponse{Mismatches: []*models.InventoryMismatch{}}
+
+	rows, err := i.db.Query(ctx, `
+		SELECT
+			COALESCE(c.product_id::TEXT, ''),
+			c.id::TEXT,
+			'',
+			COALESCE((SELECT SUM(m.quantity) FROM "inventory_movement" m WHERE m.color_id = c.id), 0)::INT,
+			COALESCE(c.count, 0)
+		FROM "color" c
+		WHERE $1 = '' OR c.product_id::TEXT = $1
+		UNION ALL
+		SELECT
+			v.product_id::TEXT,
+			'',
+			v.id::TEXT,
+			COALESCE((SELECT SUM(m.quantity) FROM "inventory_movement" m WHERE m.variant_id = v.id), 0)::INT,
+			v.count
+		FROM "product_variant" v
+		WHERE $1 = '' OR v.product_id::TEXT = $1
+	`, req.ProductId)
+	if err != nil {
+		i.log.Error("error while reconciling inventory", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var mismatch models.InventoryMismatch
+
+		err = rows.Scan(
+			&mismatch.ProductId,
+			&mismatch.ColorId,
+			&mismatch.VariantId,
+			&mismatch.LedgerCount,
+			&mismatch.Count,
+		)
+		if err != nil {
+			i.log.Error("error while scanning inventory reconciliation", logger.Error(err))
+			return nil, err
+		}
+
+		resp.Checked++
+		if mismatch.Count != mismatch.LedgerCount {
+			mismatch.Difference = mismatch.Count - mismatch.LedgerCount
+			resp.Mismatches = append(resp.Mismatches, &mismatch)
+		}
+	}
+
+	return resp, rows.Err()
+}
+
+// setStockMovement tells the inventory triggers what the stock changes that
+// follow in the transaction are and who makes them. Like setPriceChange it
+// only lasts until the end of the transaction.
+func setStockMovement(ctx context.Context, db rowQuerier, kind, orderId, changedBy string) error {
+	settings := []struct {
+		name  string
+		value string
+	}{
+		{"app.stock_movement", kind},
+		{"app.stock_order_id", orderId},
+		{"app.stock_changed_by", changedBy},
+	}
+
+	for _, setting := range settings {
+		var ignored string
+		err := db.QueryRow(ctx, `SELECT set_config($1, $2, true)`, setting.name, setting.value).Scan(&ignored)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"e-commerce/models"
 	"e-commerce/pkg/helper"
 	"e-commerce/pkg/logger"
+	"e-commerce/storage"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -38,10 +39,12 @@ func (u *locationRepo) Create(ctx context.Context, req *models.LocationCreate) (
 			image,
 			opens_at,
 			closes_at,
+			name_i18n,
+			info_i18n,
 			created_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CURRENT_TIMESTAMP)
-		RETURNING id, name, info, latitude, longitude, image, opens_at, closes_at, created_at, updated_at
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9::JSONB, '{}'), COALESCE($10::JSONB, '{}'), CURRENT_TIMESTAMP)
+		RETURNING id, name, info, latitude, longitude, image, opens_at, closes_at, name_i18n::TEXT, info_i18n::TEXT, created_at, updated_at
 	`
 
 	var (
@@ -53,11 +56,14 @@ func (u *locationRepo) Create(ctx context.Context, req *models.LocationCreate) (
 		image      sql.NullString
 		opens_at   sql.NullString
 		closes_at  sql.NullString
+		name_i18n  sql.NullString
+		info_i18n  sql.NullString
 		created_at sql.NullString
 		updated_at sql.NullString
 	)
 
-	err := u.db.QueryRow(ctx, query, id, req.Name, req.Info, req.Latitude, req.Longitude, req.Image, req.OpensAt, req.ClosesAt).Scan(
+	err := u.db.QueryRow(ctx, query, id, req.Name, req.Info, req.Latitude, req.Longitude, req.Image, req.OpensAt, req.ClosesAt,
+		translationsParam(req.NameI18n), translationsParam(req.InfoI18n)).Scan(
 		&idd,
 		&name,
 		&info,
@@ -66,6 +72,8 @@ func (u *locationRepo) Create(ctx context.Context, req *models.LocationCreate) (
 		&image,
 		&opens_at,
 		&closes_at,
+		&name_i18n,
+		&info_i18n,
 		&created_at,
 		&updated_at,
 	)
@@ -83,6 +91,8 @@ func (u *locationRepo) Create(ctx context.Context, req *models.LocationCreate) (
 		Image:     image.String,
 		OpensAt:   opens_at.String,
 		ClosesAt:  closes_at.String,
+		NameI18n:  scanTranslations(name_i18n),
+		InfoI18n:  scanTranslations(info_i18n),
 		CreatedAt: created_at.String,
 		UpdatedAt: updated_at.String,
 	}, nil
@@ -99,7 +109,10 @@ func (u *locationRepo) GetByID(ctx context.Context, req *models.LacationPrimaryK
 		image      sql.NullString
 		opens_at   sql.NullString
 		closes_at  sql.NullString
+		name_i18n  sql.NullString
+		info_i18n  sql.NullString
 		created_at sql.NullString
+		deleted_at sql.NullString
 	)
 
 	query = `
@@ -112,13 +125,16 @@ func (u *locationRepo) GetByID(ctx context.Context, req *models.LacationPrimaryK
 			image,
 			opens_at,
 			closes_at,
-			created_at
+			name_i18n::TEXT,
+			info_i18n::TEXT,
+			created_at,
+			deleted_at
 		FROM "location" 
-		WHERE id = $1
+		WHERE id = $1 AND ($2 OR deleted_at IS NULL)
 
 	`
 
-	err := u.db.QueryRow(ctx, query, req.Id).Scan(
+	err := u.db.QueryRow(ctx, query, req.Id, req.IncludeDeleted).Scan(
 		&id,
 		&name,
 		&info,
@@ -127,7 +143,10 @@ func (u *locationRepo) GetByID(ctx context.Context, req *models.LacationPrimaryK
 		&image,
 		&opens_at,
 		&closes_at,
+		&name_i18n,
+		&info_i18n,
 		&created_at,
+		&deleted_at,
 	)
 
 	if err != nil && err.Error() != "no rows in result set" {
@@ -135,16 +154,20 @@ func (u *locationRepo) GetByID(ctx context.Context, req *models.LacationPrimaryK
 		return nil, err
 	}
 
+	nameI18n, infoI18n := scanTranslations(name_i18n), scanTranslations(info_i18n)
 	return &models.Location{
 		Id:        id.String,
-		Name:      name.String,
-		Info:      info.String,
+		Name:      nameI18n.Localize(name.String, req.Lang),
+		Info:      infoI18n.Localize(info.String, req.Lang),
 		Latitude:  latitude.Float64,
 		Longitude: longitude.Float64,
 		Image:     image.String,
 		OpensAt:   opens_at.String,
 		ClosesAt:  closes_at.String,
+		NameI18n:  nameI18n,
+		InfoI18n:  infoI18n,
 		CreatedAt: created_at.String,
+		DeletedAt: deleted_at.String,
 	}, nil
 }
 
@@ -167,11 +190,18 @@ func (u *locationRepo) GetList(ctx context.Context, req *models.LocationGetListR
 			image,
 			opens_at,
 			closes_at,
-			created_at
+			name_i18n::TEXT,
+			info_i18n::TEXT,
+			created_at,
+			deleted_at
 		FROM "location" 
 		
 	`
 
+	if !req.IncludeDeleted {
+		query += " WHERE deleted_at IS NULL"
+	}
+
 	if req.Offset > 0 {
 		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
 	}
@@ -197,7 +227,10 @@ func (u *locationRepo) GetList(ctx context.Context, req *models.LocationGetListR
 			image      sql.NullString
 			opens_at   sql.NullString
 			closes_at  sql.NullString
+			name_i18n  sql.NullString
+			info_i18n  sql.NullString
 			created_at sql.NullString
+			deleted_at sql.NullString
 		)
 
 		err = rows.Scan(
@@ -210,31 +243,39 @@ func (u *locationRepo) GetList(ctx context.Context, req *models.LocationGetListR
 			&image,
 			&opens_at,
 			&closes_at,
+			&name_i18n,
+			&info_i18n,
 			&created_at,
+			&deleted_at,
 		)
 		if err != nil {
 			u.log.Error("error is while getting user list (scanning data)", logger.Error(err))
 			return nil, err
 		}
 
+		nameI18n, infoI18n := scanTranslations(name_i18n), scanTranslations(info_i18n)
 		resp.Location = append(resp.Location, &models.Location{
 			Id:        id.String,
-			Name:      name.String,
-			Info:      info.String,
+			Name:      nameI18n.Localize(name.String, req.Lang),
+			Info:      infoI18n.Localize(info.String, req.Lang),
 			Latitude:  latitude.Float64,
 			Longitude: longitude.Float64,
 			Image:     image.String,
 			OpensAt:   opens_at.String,
 			ClosesAt:  closes_at.String,
+			NameI18n:  nameI18n,
+			InfoI18n:  infoI18n,
 			CreatedAt: created_at.String,
+			DeletedAt: deleted_at.String,
 		})
 	}
 	return resp, nil
 }
 
+// Delete soft deletes the location
 func (u *locationRepo) Delete(ctx context.Context, req *models.LacationPrimaryKey) error {
 
-	_, err := u.db.Exec(ctx, `DELETE from location WHERE id = $1`, req.Id)
+	_, err := u.db.Exec(ctx, `UPDATE "location" SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, req.Id)
 	if err != nil {
 		u.log.Error("error is while deleting location", logger.Error(err))
 		return err
@@ -243,6 +284,21 @@ func (u *locationRepo) Delete(ctx context.Context, req *models.LacationPrimaryKe
 	return nil
 }
 
+// Restore brings back a soft deleted location
+func (u *locationRepo) Restore(ctx context.Context, req *models.LacationPrimaryKey) error {
+	result, err := u.db.Exec(ctx, `UPDATE "location" SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL`, req.Id)
+	if err != nil {
+		u.log.Error("error is while restoring location", logger.Error(err))
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return storage.ErrNotDeleted
+	}
+
+	return nil
+}
+
 func (u *locationRepo) Update(ctx context.Context, req *models.LocationUpdate) (int64, error) {
 	var (
 		query  string
@@ -260,8 +316,10 @@ func (u *locationRepo) Update(ctx context.Context, req *models.LocationUpdate) (
 			image = :image,
 			opens_at = :opens_at,
 			closes_at = :closes_at,
+			name_i18n = COALESCE(CAST(:i18n_name AS JSONB), name_i18n),
+			info_i18n = COALESCE(CAST(:i18n_info AS JSONB), info_i18n),
 			updated_at = NOW()
-		WHERE id = :id
+		WHERE id = :id AND deleted_at IS NULL
 	`
 
 	params = map[string]interface{}{
@@ -273,6 +331,8 @@ func (u *locationRepo) Update(ctx context.Context, req *models.LocationUpdate) (
 		"image":     req.Image,
 		"opens_at":  req.OpensAt,
 		"closes_at": req.ClosesAt,
+		"i18n_name": translationsParam(req.NameI18n),
+		"i18n_info": translationsParam(req.InfoI18n),
 	}
 
 	query, args := helper.ReplaceQueryParams(query, params)
@@ -0,0 +1,454 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/models"
+	"e-commerce/pkg/logger"
+	"e-commerce/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type mediaRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewMediaRepo initializes a new instance of mediaRepo
+func NewMediaRepo(db *pgxpool.Pool, log logger.LoggerI) *mediaRepo {
+	return &mediaRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+const mediaColumns = `
+	id,
+	product_id,
+	file_id,
+	url,
+	alt,
+	position,
+	is_primary,
+	variant_id::TEXT,
+	color_id::TEXT,
+	created_at,
+	updated_at
+`
+
+// Create appends the images to the end of the product gallery. An image
+// marked primary replaces the current primary one, the first image of an
+// empty gallery becomes primary.
+func (m *mediaRepo) Create(ctx context.Context, req *models.ProductMediaCreateRequest) (*models.ProductMediaGetListResponse, error) {
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockMediaProduct(ctx, tx, req.ProductId); err != nil {
+		return nil, err
+	}
+
+	var position int
+	err = tx.QueryRow(ctx, `SELECT COALESCE(MAX(position) + 1, 0) FROM "product_media" WHERE product_id = $1`, req.ProductId).Scan(&position)
+	if err != nil {
+		m.log.Error("error while getting media position", logger.Error(err))
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO "product_media" (
+			id,
+			product_id,
+			file_id,
+			url,
+			alt,
+			position,
+			is_primary,
+			variant_id,
+			color_id,
+			created_at
+		)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, NULLIF($8, '')::UUID, NULLIF($9, '')::UUID, CURRENT_TIMESTAMP)
+	`
+
+	for i, media := range req.Media {
+		if err = checkMediaLink(ctx, tx, req.ProductId, media.VariantId, media.ColorId); err != nil {
+			return nil, err
+		}
+
+		id := uuid.New().String()
+		if media.IsPrimary {
+			if err = clearPrimary(ctx, tx, req.ProductId, id); err != nil {
+				m.log.Error("error while clearing primary media", logger.Error(err))
+				return nil, err
+			}
+		}
+
+		_, err = tx.Exec(ctx, query,
+			id,
+			req.ProductId,
+			media.FileId,
+			media.Url,
+			media.Alt,
+			position+i,
+			media.IsPrimary,
+			media.VariantId,
+			media.ColorId,
+		)
+		if err != nil {
+			m.log.Error("error while creating media", logger.Error(err))
+			return nil, err
+		}
+	}
+
+	if err = syncPrimary(ctx, tx, req.ProductId); err != nil {
+		m.log.Error("error while syncing primary media", logger.Error(err))
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return m.GetList(ctx, &models.ProductMediaGetListRequest{ProductId: req.ProductId})
+}
+
+func (m *mediaRepo) GetByID(ctx context.Context, req *models.ProductMediaPrimaryKey) (*models.ProductMedia, error) {
+	query := `
+		SELECT` + mediaColumns + `
+		FROM "product_media"
+		WHERE id = $1
+	`
+
+	media, err := scanMedia(m.db.QueryRow(ctx, query, req.Id))
+	if err != nil {
+		if err != pgx.ErrNoRows {
+			m.log.Error("error while getting media", logger.Error(err))
+		}
+		return nil, err
+	}
+
+	return media, nil
+}
+
+func (m *mediaRepo) GetList(ctx context.Context, req *models.ProductMediaGetListRequest) (*models.ProductMediaGetListResponse, error) {
+	query := `
+		SELECT` + mediaColumns + `
+		FROM "product_media"
+		WHERE product_id = $1
+		ORDER BY position, created_at
+	`
+
+	rows, err := m.db.Query(ctx, query, req.ProductId)
+	if err != nil {
+		m.log.Error("error while getting media list", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	resp := &models.ProductMediaGetListResponse{Media: []*models.ProductMedia{}}
+	for rows.Next() {
+		media, err := scanMedia(rows)
+		if err != nil {
+			m.log.Error("error while scanning media list", logger.Error(err))
+			return nil, err
+		}
+		resp.Media = append(resp.Media, media)
+	}
+	resp.Count = len(resp.Media)
+
+	return resp, rows.Err()
+}
+
+// Update changes the alt text, the variant or color the image belongs to and
+// whether it is the primary image. Unsetting the primary image makes the
+// first one of the gallery primary.
+func (m *mediaRepo) Update(ctx context.Context, req *models.ProductMediaUpdate) (int64, error) {
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var productId string
+	err = tx.QueryRow(ctx, `SELECT product_id FROM "product_media" WHERE id = $1`, req.Id).Scan(&productId)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		m.log.Error("error while getting media product", logger.Error(err))
+		return 0, err
+	}
+
+	if err = lockMediaProduct(ctx, tx, productId); err != nil {
+		return 0, err
+	}
+
+	if err = checkMediaLink(ctx, tx, productId, req.VariantId, req.ColorId); err != nil {
+		return 0, err
+	}
+
+	if req.IsPrimary {
+		if err = clearPrimary(ctx, tx, productId, req.Id); err != nil {
+			m.log.Error("error while clearing primary media", logger.Error(err))
+			return 0, err
+		}
+	}
+
+	query := `
+		UPDATE
+			"product_media"
+		SET
+			alt = $1,
+			is_primary = $2,
+			variant_id = NULLIF($3, '')::UUID,
+			color_id = NULLIF($4, '')::UUID,
+			updated_at = NOW()
+		WHERE id = $5
+	`
+
+	result, err := tx.Exec(ctx, query,
+		req.Alt,
+		req.IsPrimary,
+		req.VariantId,
+		req.ColorId,
+		req.Id,
+	)
+	if err != nil {
+		m.log.Error("error while updating media", logger.Error(err))
+		return 0, err
+	}
+
+	if err = syncPrimary(ctx, tx, productId); err != nil {
+		m.log.Error("error while syncing primary media", logger.Error(err))
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// Reorder puts the product images in the order of the ids, which must list
+// every image of the product exactly once
+func (m *mediaRepo) Reorder(ctx context.Context, req *models.ProductMediaReorder) error {
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockMediaProduct(ctx, tx, req.ProductId); err != nil {
+		return err
+	}
+
+	var matches bool
+	err = tx.QueryRow(ctx, `
+		SELECT
+			COUNT(*) = cardinality($2::UUID[])
+			AND COUNT(*) = (SELECT COUNT(DISTINCT id) FROM unnest($2::UUID[]) AS ids(id))
+			AND bool_and(id = ANY($2::UUID[])) IS NOT FALSE
+		FROM "product_media"
+		WHERE product_id = $1
+	`, req.ProductId, req.MediaIds).Scan(&matches)
+	if err != nil {
+		m.log.Error("error while checking media order", logger.Error(err))
+		return err
+	}
+
+	if !matches {
+		return storage.ErrMediaOrderMismatch
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE
+			"product_media"
+		SET
+			position = array_position($2::UUID[], id) - 1,
+			updated_at = NOW()
+		WHERE product_id = $1
+	`, req.ProductId, req.MediaIds)
+	if err != nil {
+		m.log.Error("error while reordering media", logger.Error(err))
+		return err
+	}
+
+	if err = syncPrimary(ctx, tx, req.ProductId); err != nil {
+		m.log.Error("error while syncing primary media", logger.Error(err))
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Delete removes the image and closes the gap it leaves in the order, the
+// first remaining image becomes primary when the primary one is deleted.
+// The file itself stays in the file storage.
+func (m *mediaRepo) Delete(ctx context.Context, req *models.ProductMediaPrimaryKey) error {
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var productId string
+	err = tx.QueryRow(ctx, `SELECT product_id FROM "product_media" WHERE id = $1`, req.Id).Scan(&productId)
+	if err == pgx.ErrNoRows {
+		return storage.ErrMediaNotFound
+	}
+	if err != nil {
+		m.log.Error("error while getting media product", logger.Error(err))
+		return err
+	}
+
+	if err = lockMediaProduct(ctx, tx, productId); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM "product_media" WHERE id = $1`, req.Id)
+	if err != nil {
+		m.log.Error("error while deleting media", logger.Error(err))
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE "product_media" pm
+		SET position = o.position
+		FROM (
+			SELECT id, row_number() OVER (ORDER BY position, created_at) - 1 AS position
+			FROM "product_media"
+			WHERE product_id = $1
+		) o
+		WHERE pm.id = o.id AND pm.position <> o.position
+	`, productId)
+	if err != nil {
+		m.log.Error("error while renumbering media", logger.Error(err))
+		return err
+	}
+
+	if err = syncPrimary(ctx, tx, productId); err != nil {
+		m.log.Error("error while syncing primary media", logger.Error(err))
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// lockMediaProduct locks the product row, so concurrent changes of the same
+// gallery can not leave it with two primary images or duplicate positions
+func lockMediaProduct(ctx context.Context, tx pgx.Tx, productId string) error {
+	var id string
+	err := tx.QueryRow(ctx, `SELECT id FROM "product" WHERE id = $1 FOR UPDATE`, productId).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return storage.ErrProductNotFound
+	}
+	return err
+}
+
+// checkMediaLink rejects a variant or color of another product
+func checkMediaLink(ctx context.Context, tx pgx.Tx, productId, variantId, colorId string) error {
+	var valid bool
+	err := tx.QueryRow(ctx, `
+		SELECT
+			($2 = '' OR EXISTS (SELECT 1 FROM "product_variant" WHERE id = NULLIF($2, '')::UUID AND product_id = $1))
+			AND ($3 = '' OR EXISTS (SELECT 1 FROM "color" WHERE id = NULLIF($3, '')::UUID AND product_id = $1))
+	`, productId, variantId, colorId).Scan(&valid)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		return storage.ErrMediaLinkInvalid
+	}
+
+	return nil
+}
+
+func clearPrimary(ctx context.Context, tx pgx.Tx, productId, exceptId string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE "product_media"
+		SET is_primary = FALSE, updated_at = NOW()
+		WHERE product_id = $1 AND is_primary AND id <> $2
+	`, productId, exceptId)
+	return err
+}
+
+// syncPrimary leaves the gallery with exactly one primary image, the first
+// one when none is marked, and makes it the product's image
+func syncPrimary(ctx context.Context, tx pgx.Tx, productId string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE "product_media"
+		SET is_primary = (id = (
+			SELECT id FROM "product_media"
+			WHERE product_id = $1
+			ORDER BY is_primary DESC, position, created_at
+			LIMIT 1
+		))
+		WHERE product_id = $1
+	`, productId)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE "product"
+		SET image = (SELECT url FROM "product_media" WHERE product_id = $1 AND is_primary)
+		WHERE id = $1
+	`, productId)
+
+	return err
+}
+
+func scanMedia(row pgx.Row) (*models.ProductMedia, error) {
+	var (
+		id         sql.NullString
+		product_id sql.NullString
+		file_id    sql.NullString
+		url        sql.NullString
+		alt        sql.NullString
+		position   sql.NullInt64
+		is_primary sql.NullBool
+		variant_id sql.NullString
+		color_id   sql.NullString
+		created_at sql.NullString
+		updated_at sql.NullString
+	)
+
+	err := row.Scan(
+		&id,
+		&product_id,
+		&file_id,
+		&url,
+		&alt,
+		&position,
+		&is_primary,
+		&variant_id,
+		&color_id,
+		&created_at,
+		&updated_at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ProductMedia{
+		Id:        id.String,
+		ProductId: product_id.String,
+		FileId:    file_id.String,
+		Url:       url.String,
+		Alt:       alt.String,
+		Position:  int(position.Int64),
+		IsPrimary: is_primary.Bool,
+		VariantId: variant_id.String,
+		ColorId:   color_id.String,
+		CreatedAt: created_at.String,
+		UpdatedAt: updated_at.String,
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -28,30 +29,71 @@ func (o *orderRepo) CreateOrder(order *models.OrderCreateRequest) (*models.Order
 	if err != nil {
 		return &models.OrderCreateRequest{}, err
 	}
-	defer func() {
-		if err != nil {
-			tx.Rollback(context.Background())
-		}
-	}()
+	// rolls back on every early return, a no-op after Commit
+	defer tx.Rollback(context.Background())
 
 	orderId := uuid.New().String()
 
+	// the stock taken below is a sale of the order to the customer
+	err = setStockMovement(context.Background(), tx, models.InventoryMovementSale, orderId, order.Order.CustomerId)
+	if err != nil {
+		return &models.OrderCreateRequest{}, err
+	}
+
 	var totalSum float64
 	for i, item := range order.Items {
 		if item.Quantity <= 0 {
 			return &models.OrderCreateRequest{}, fmt.Errorf("quantity must be greater than 0 for product %s", item.ProductId)
 		}
 
-		var productPrice float64
-		productQuery := `SELECT price FROM "product" WHERE id = $1`
-		err = tx.QueryRow(context.Background(), productQuery, item.ProductId).Scan(&productPrice)
+		if item.VariantId != "" {
+			// Variant narxi va miqdorini tekshirish va yangilash
+			var (
+				variantPrice           float64
+				currentVariantQuantity int
+			)
+			variantQuery := `SELECT ` + productVariantPrice + `, v.count FROM "product_variant" v
+				INNER JOIN "product" p ON p.id = v.product_id
+				WHERE v.id = $1 AND v.product_id = $2 AND p.deleted_at IS NULL FOR UPDATE OF v`
+			err = tx.QueryRow(context.Background(), variantQuery, item.VariantId, item.ProductId).Scan(&variantPrice, &currentVariantQuantity)
+			if err != nil {
+				return &models.OrderCreateRequest{}, fmt.Errorf("failed to retrieve variant %s of product %s: %w", item.VariantId, item.ProductId, err)
+			}
+
+			if currentVariantQuantity < item.Quantity {
+				return &models.OrderCreateRequest{}, fmt.Errorf("insufficient quantity for variant %s", item.VariantId)
+			}
+
+			updateVariantQuery := `UPDATE "product_variant" SET count = count - $1 WHERE id = $2`
+			_, err = tx.Exec(context.Background(), updateVariantQuery, item.Quantity, item.VariantId)
+			if err != nil {
+				return &models.OrderCreateRequest{}, fmt.Errorf("failed to update quantity for variant %s: %w", item.VariantId, err)
+			}
+
+			order.Items[i].Price = variantPrice
+			order.Items[i].TotalPrice = variantPrice * float64(item.Quantity)
+			totalSum += order.Items[i].TotalPrice
+			continue
+		}
+
+		var (
+			productPrice float64
+			hasVariants  bool
+		)
+		productQuery := `SELECT ` + productEffectivePrice + `, EXISTS (SELECT 1 FROM "product_variant" WHERE product_id = p.id) FROM "product" p WHERE p.id = $1 AND p.deleted_at IS NULL`
+		err = tx.QueryRow(context.Background(), productQuery, item.ProductId).Scan(&productPrice, &hasVariants)
 		if err != nil {
 			return &models.OrderCreateRequest{}, fmt.Errorf("failed to retrieve price for product %s: %w", item.ProductId, err)
 		}
 
+		// variantlari bor mahsulotning zaxirasi variantlarda turadi
+		if hasVariants {
+			return &models.OrderCreateRequest{}, fmt.Errorf("variant_id is required for product %s", item.ProductId)
+		}
+
 		// Color miqdorini tekshirish va yangilash
 		var currentColorQuantity int
-		colorQuery := `SELECT count FROM "color" WHERE id = $1 FOR UPDATE`
+		colorQuery := `SELECT count FROM "color" WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
 		err = tx.QueryRow(context.Background(), colorQuery, item.ColorId).Scan(&currentColorQuantity)
 		if err != nil {
 			return &models.OrderCreateRequest{}, fmt.Errorf("failed to retrieve color quantity for color %s: %w", item.ColorId, err)
@@ -83,12 +125,12 @@ func (o *orderRepo) CreateOrder(order *models.OrderCreateRequest) (*models.Order
 		return &models.OrderCreateRequest{}, err
 	}
 
-	itemQuery := `INSERT INTO "order_items" (id, quantity, order_id, product_id, color_id, price, total, created_at, updated_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+	itemQuery := `INSERT INTO "order_items" (id, quantity, order_id, product_id, color_id, variant_id, price, total, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, NULLIF($5, '')::UUID, NULLIF($6, '')::UUID, $7, $8, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 
 	for _, item := range order.Items {
 		itemId := uuid.New().String()
-		_, err = tx.Exec(context.Background(), itemQuery, itemId, item.Quantity, orderId, item.ProductId, item.ColorId, item.Price, item.TotalPrice)
+		_, err = tx.Exec(context.Background(), itemQuery, itemId, item.Quantity, orderId, item.ProductId, item.ColorId, item.VariantId, item.Price, item.TotalPrice)
 		if err != nil {
 			return &models.OrderCreateRequest{}, err
 		}
@@ -128,7 +170,7 @@ func (o *orderRepo) GetOrder(orderId string) (*models.OrderCreateRequest, error)
 		return nil, err
 	}
 
-	orderItemQuery := `SELECT id, product_id, order_id, quantity, color_id, price, total FROM "order_items" WHERE order_id = $1`
+	orderItemQuery := `SELECT id, product_id, order_id, quantity, COALESCE(color_id::TEXT, ''), COALESCE(variant_id::TEXT, ''), price, total FROM "order_items" WHERE order_id = $1`
 
 	itemRows, err := o.db.Query(context.Background(), orderItemQuery, orderId)
 	if err != nil {
@@ -146,6 +188,7 @@ func (o *orderRepo) GetOrder(orderId string) (*models.OrderCreateRequest, error)
 			&item.OrderId,
 			&item.Quantity,
 			&item.ColorId,
+			&item.VariantId,
 			&item.Price,
 			&item.TotalPrice,
 		)
@@ -215,7 +258,7 @@ func (o *orderRepo) GetAll(ctx context.Context, request *models.OrderGetListRequ
 
 		// Query to retrieve order items for the current order
 		orderItemQuery := `
-		SELECT id, product_id, order_id, quantity, price, total, created_at
+		SELECT id, product_id, order_id, quantity, COALESCE(color_id::TEXT, ''), COALESCE(variant_id::TEXT, ''), price, total, created_at
 		FROM "order_items"
 		WHERE order_id = $1
 		`
@@ -228,7 +271,7 @@ func (o *orderRepo) GetAll(ctx context.Context, request *models.OrderGetListRequ
 		var orderItems []models.OrderItems
 		for itemRows.Next() {
 			var item models.OrderItems
-			err = itemRows.Scan(&item.Id, &item.ProductId, &item.OrderId, &item.Quantity, &item.Price, &item.TotalPrice, &created_at)
+			err = itemRows.Scan(&item.Id, &item.ProductId, &item.OrderId, &item.Quantity, &item.ColorId, &item.VariantId, &item.Price, &item.TotalPrice, &created_at)
 			if err != nil {
 				return nil, fmt.Errorf("failed to scan order item: %w", err)
 			}
@@ -236,6 +279,8 @@ func (o *orderRepo) GetAll(ctx context.Context, request *models.OrderGetListRequ
 				Id:         item.Id,
 				ProductId:  item.ProductId,
 				OrderId:    item.OrderId,
+				ColorId:    item.ColorId,
+				VariantId:  item.VariantId,
 				Quantity:   item.Quantity,
 				Price:      item.Price,
 				TotalPrice: item.TotalPrice,
@@ -273,33 +318,59 @@ func (o *orderRepo) UpdateOrder(order models.Order) error {
 	return err
 }
 
-func (o *orderRepo) DeleteOrder(orderId string) error {
+// DeleteOrder cancels the order, the stock of an order that is not delivered
+// yet goes back to its colors and variants
+func (o *orderRepo) DeleteOrder(orderId, deletedBy string) error {
 	tx, err := o.db.Begin(context.Background())
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback(context.Background())
 
-	// Delete order items
-	itemQuery := `DELETE FROM "order_items" WHERE order_id = $1`
-	_, err = tx.Exec(context.Background(), itemQuery, orderId)
+	var status string
+	err = tx.QueryRow(context.Background(), `SELECT status::TEXT FROM "orders" WHERE id = $1 FOR UPDATE`, orderId).Scan(&status)
+	if err == pgx.ErrNoRows {
+		return nil
+	}
 	if err != nil {
-		tx.Rollback(context.Background())
 		return err
 	}
 
-	// Delete related colors in order_items before deleting color itself
-	colorItemsQuery := `DELETE FROM "order_items" WHERE color_id = $1`
-	_, err = tx.Exec(context.Background(), colorItemsQuery, orderId)
-	if err != nil {
-		tx.Rollback(context.Background())
-		return err
+	if status != orderStatusDelivered {
+		err = setStockMovement(context.Background(), tx, models.InventoryMovementCancellation, orderId, deletedBy)
+		if err != nil {
+			return err
+		}
+
+		colorQuery := `UPDATE "color" c SET count = COALESCE(c.count, 0) + i.quantity, updated_at = NOW()
+			FROM (
+				SELECT color_id, SUM(quantity) AS quantity FROM "order_items"
+				WHERE order_id = $1 AND variant_id IS NULL AND color_id IS NOT NULL
+				GROUP BY color_id
+			) i
+			WHERE c.id = i.color_id`
+		_, err = tx.Exec(context.Background(), colorQuery, orderId)
+		if err != nil {
+			return err
+		}
+
+		variantQuery := `UPDATE "product_variant" v SET count = v.count + i.quantity, updated_at = NOW()
+			FROM (
+				SELECT variant_id, SUM(quantity) AS quantity FROM "order_items"
+				WHERE order_id = $1 AND variant_id IS NOT NULL
+				GROUP BY variant_id
+			) i
+			WHERE v.id = i.variant_id`
+		_, err = tx.Exec(context.Background(), variantQuery, orderId)
+		if err != nil {
+			return err
+		}
 	}
 
-	// Delete the color
-	colorQuery := `DELETE FROM "color" WHERE id = $1`
-	_, err = tx.Exec(context.Background(), colorQuery, orderId)
+	// Delete order items
+	itemQuery := `DELETE FROM "order_items" WHERE order_id = $1`
+	_, err = tx.Exec(context.Background(), itemQuery, orderId)
 	if err != nil {
-		tx.Rollback(context.Background())
 		return err
 	}
 
@@ -307,7 +378,6 @@ func (o *orderRepo) DeleteOrder(orderId string) error {
 	orderQuery := `DELETE FROM "orders" WHERE id = $1`
 	_, err = tx.Exec(context.Background(), orderQuery, orderId)
 	if err != nil {
-		tx.Rollback(context.Background())
 		return err
 	}
 
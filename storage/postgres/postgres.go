@@ -24,6 +24,15 @@ type store struct {
 	banner   *bannerRepo
 	color    *colorRepo
 	location *locationRepo
+	role     *roleRepo
+	audit    *auditRepo
+	slug     *slugRepo
+	variant  *variantRepo
+	media    *mediaRepo
+	price    *priceHistoryRepo
+	review   *reviewRepo
+	favorite *favoriteRepo
+	stock    *inventoryRepo
 	cfg      *config.Config
 	// auth     *authRepo
 }
@@ -158,3 +167,93 @@ func (s *store) Location() storage.LocationI {
 	}
 	return s.location
 }
+
+func (s *store) Role() storage.RoleI {
+	if s.role == nil {
+		s.role = &roleRepo{
+			db:  s.db,
+			log: s.log,
+		}
+	}
+	return s.role
+}
+
+func (s *store) Audit() storage.AuditI {
+	if s.audit == nil {
+		s.audit = &auditRepo{
+			db:  s.db,
+			log: s.log,
+		}
+	}
+	return s.audit
+}
+
+func (s *store) Slug() storage.SlugI {
+	if s.slug == nil {
+		s.slug = &slugRepo{
+			db:  s.db,
+			log: s.log,
+		}
+	}
+	return s.slug
+}
+
+func (s *store) Variant() storage.VariantI {
+	if s.variant == nil {
+		s.variant = &variantRepo{
+			db:  s.db,
+			log: s.log,
+		}
+	}
+	return s.variant
+}
+
+func (s *store) Media() storage.MediaI {
+	if s.media == nil {
+		s.media = &mediaRepo{
+			db:  s.db,
+			log: s.log,
+		}
+	}
+	return s.media
+}
+
+func (s *store) PriceHistory() storage.PriceHistoryI {
+	if s.price == nil {
+		s.price = &priceHistoryRepo{
+			db:  s.db,
+			log: s.log,
+		}
+	}
+	return s.price
+}
+
+func (s *store) Inventory() storage.InventoryI {
+	if s.stock == nil {
+		s.stock = &inventoryRepo{
+			db:  s.db,
+			log: s.log,
+		}
+	}
+	return s.stock
+}
+
+func (s *store) Review() storage.ReviewI {
+	if s.review == nil {
+		s.review = &reviewRepo{
+			db:  s.db,
+			log: s.log,
+		}
+	}
+	return s.review
+}
+
+func (s *store) Favorite() storage.FavoriteI {
+	if s.favorite == nil {
+		s.favorite = &favoriteRepo{
+			db:  s.db,
+			log: s.log,
+		}
+	}
+	return s.favorite
+}
@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/models"
+	"e-commerce/pkg/logger"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type priceHistoryRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewPriceHistoryRepo initializes a new instance of priceHistoryRepo
+func NewPriceHistoryRepo(db *pgxpool.Pool, log logger.LoggerI) *priceHistoryRepo {
+	return &priceHistoryRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// GetList returns the price changes of the product, or of one of its
+// variants, written by the price history triggers
+func (p *priceHistoryRepo) GetList(ctx context.Context, req *models.PriceHistoryGetListRequest) (*models.PriceHistoryGetListResponse, error) {
+	var (
+		resp  = &models.PriceHistoryGetListResponse{History: []*models.PriceHistory{}}
+		args  = []interface{}{req.ProductId, req.VariantId}
+		argId = 3
+	)
+
+	query := `
+		SELECT
+			COUNT(*) OVER(),
+			id,
+			product_id,
+			variant_id::TEXT,
+			price_before,
+			price_after,
+			with_discount_before,
+			with_discount_after,
+			effective_price_before,
+			effective_price_after,
+			reason,
+			changed_by::TEXT,
+			created_at
+		FROM "price_history"
+		WHERE product_id = $1 AND variant_id IS NOT DISTINCT FROM NULLIF($2, '')::UUID
+	`
+
+	filters := []struct {
+		column string
+		value  string
+	}{
+		{"created_at >= ", req.From},
+		{"created_at < ", req.To},
+	}
+
+	for _, filter := range filters {
+		if filter.value == "" {
+			continue
+		}
+		query += fmt.Sprintf(" AND %s$%d", filter.column, argId)
+		args = append(args, filter.value)
+		argId++
+	}
+
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", argId, argId+1)
+	args = append(args, req.Limit, req.Offset)
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		p.log.Error("error while getting price history", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id                     sql.NullString
+			product_id             sql.NullString
+			variant_id             sql.NullString
+			price_before           sql.NullFloat64
+			price_after            sql.NullFloat64
+			with_discount_before   sql.NullFloat64
+			with_discount_after    sql.NullFloat64
+			effective_price_before sql.NullFloat64
+			effective_price_after  sql.NullFloat64
+			reason                 sql.NullString
+			changed_by             sql.NullString
+			created_at             sql.NullString
+		)
+
+		err = rows.Scan(
+			&resp.Count,
+			&id,
+			&product_id,
+			&variant_id,
+			&price_before,
+			&price_after,
+			&with_discount_before,
+			&with_discount_after,
+			&effective_price_before,
+			&effective_price_after,
+			&reason,
+			&changed_by,
+			&created_at,
+		)
+		if err != nil {
+			p.log.Error("error while scanning price history", logger.Error(err))
+			return nil, err
+		}
+
+		resp.History = append(resp.History, &models.PriceHistory{
+			Id:                   id.String,
+			ProductId:            product_id.String,
+			VariantId:            variant_id.String,
+			PriceBefore:          nullFloat(price_before),
+			PriceAfter:           nullFloat(price_after),
+			WithDiscountBefore:   nullFloat(with_discount_before),
+			WithDiscountAfter:    nullFloat(with_discount_after),
+			EffectivePriceBefore: nullFloat(effective_price_before),
+			EffectivePriceAfter:  nullFloat(effective_price_after),
+			Reason:               reason.String,
+			ChangedBy:            changed_by.String,
+			CreatedAt:            created_at.String,
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	resp.LowestPrice30Days, err = p.lowestPrice(ctx, req.ProductId, req.VariantId)
+	if err != nil {
+		p.log.Error("error while getting lowest price", logger.Error(err))
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// lowestPrice is the lowest effective price over the last 30 days: the prices
+// set in that time, the price in effect when it began and the current one
+func (p *priceHistoryRepo) lowestPrice(ctx context.Context, productId, variantId string) (float64, error) {
+	current := `SELECT ` + productEffectivePrice + ` FROM "product" p WHERE p.id = $1`
+	if variantId != "" {
+		current = `SELECT ` + productVariantPrice + ` FROM "product_variant" v
+			INNER JOIN "product" p ON p.id = v.product_id
+			WHERE v.id = NULLIF($2, '')::UUID AND v.product_id = $1`
+	}
+
+	query := `
+		SELECT MIN(price) FROM (
+			SELECT effective_price_after AS price
+			FROM "price_history"
+			WHERE product_id = $1 AND variant_id IS NOT DISTINCT FROM NULLIF($2, '')::UUID
+				AND created_at >= NOW() - INTERVAL '30 days'
+			UNION ALL
+			(
+				SELECT effective_price_after
+				FROM "price_history"
+				WHERE product_id = $1 AND variant_id IS NOT DISTINCT FROM NULLIF($2, '')::UUID
+					AND created_at < NOW() - INTERVAL '30 days'
+				ORDER BY created_at DESC
+				LIMIT 1
+			)
+			UNION ALL
+			(` + current + `)
+		) prices
+	`
+
+	var lowest sql.NullFloat64
+	err := p.db.QueryRow(ctx, query, productId, variantId).Scan(&lowest)
+	if err != nil {
+		return 0, err
+	}
+
+	return lowest.Float64, nil
+}
+
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// setPriceChange tells the price history triggers who changes prices in the
+// transaction and why
+func setPriceChange(ctx context.Context, db rowQuerier, changedBy, reason string) error {
+	var ignored string
+	err := db.QueryRow(ctx, `SELECT set_config('app.price_changed_by', $1, true)`, changedBy).Scan(&ignored)
+	if err != nil {
+		return err
+	}
+
+	return setPriceChangeReason(ctx, db, reason)
+}
+
+func setPriceChangeReason(ctx context.Context, db rowQuerier, reason string) error {
+	var ignored string
+	return db.QueryRow(ctx, `SELECT set_config('app.price_change_reason', $1, true)`, reason).Scan(&ignored)
+}
+
+func nullFloat(value sql.NullFloat64) *float64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Float64
+}
@@ -5,13 +5,13 @@ import (
 	"database/sql"
 	"e-commerce/models"
 	"e-commerce/pkg/logger"
+	"e-commerce/storage"
 	"fmt"
-	"sort"
-	"strconv"
 	"strings"
 	"time"
 
 	uuid "github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/lib/pq"
 )
@@ -34,32 +34,33 @@ func (u *productRepo) Create(ctx context.Context, req *models.ProductCreate) (*m
 	loc, _ := time.LoadLocation("Asia/Tashkent")
 	currentTime := time.Now().In(loc)
 
-	var finalPrice float64
-	var discountEndTime interface{}
-	switch req.Status {
-	case "vremennaya_skidka":
-		if req.DiscountPercent > 0 {
-			finalPrice = req.Price - (req.Price * req.DiscountPercent / 100)
-			// Parse the discount end time
-			if req.DiscountEndTime != "" {
-				parsedTime, err := time.Parse(time.RFC3339, req.DiscountEndTime)
-				if err != nil {
-					u.log.Error("Error parsing discount end time: " + err.Error())
-					return nil, fmt.Errorf("invalid discount end time format")
-				}
-				discountEndTime = parsedTime
-			} else {
-				discountEndTime = nil // Set to NULL if empty string
-			}
-		} else {
-			finalPrice = 0
-			discountEndTime = nil
-			req.DiscountPercent = 0
-		}
-	default:
-		finalPrice = 0
+	// the discount scheduler gives the product the discount status once it starts
+	discounted, discountStartTime, discountEndTime, err := discountPeriod(req.Status, req.DiscountPercent, req.DiscountStartTime, req.DiscountEndTime)
+	if err != nil {
+		return nil, err
+	}
+	if discounted {
+		req.Status = ""
+	} else {
 		req.DiscountPercent = 0
-		discountEndTime = nil
+	}
+
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		u.log.Error("Error while starting product create transaction: " + err.Error())
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = setPriceChange(ctx, tx, req.PriceChangedBy, req.PriceChangeReason); err != nil {
+		u.log.Error("Error while setting product price change: " + err.Error())
+		return nil, err
+	}
+
+	slug, err := uniqueSlug(ctx, tx, models.SlugEntityProduct, req.Name, id)
+	if err != nil {
+		u.log.Error("Error while making product slug: " + err.Error())
+		return nil, err
 	}
 
 	query := `
@@ -68,8 +69,8 @@ func (u *productRepo) Create(ctx context.Context, req *models.ProductCreate) (*m
 		category_id, 
 		brand_id, 
 		image,
-		favorite, 
 		name, 
+		slug,
 		price, 
 		with_discount, 
 		rating,
@@ -77,145 +78,137 @@ func (u *productRepo) Create(ctx context.Context, req *models.ProductCreate) (*m
 		item_count,
 		status, 
 		discount_percent, 
+		discount_start_time,
 		discount_end_time, 
-		created_at
-	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
-	RETURNING id, category_id, brand_id, image,  favorite, name, price, with_discount, rating,
-		description, item_count, status, discount_percent, discount_end_time, created_at
+		created_at,
+		name_i18n,
+		description_i18n
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, 0, 0, $8, $9, $10, $11, $12, $13, $14,
+		COALESCE($15::JSONB, '{}'), COALESCE($16::JSONB, '{}'))
 	`
 
-	var (
-		idd               sql.NullString
-		categoryId        sql.NullString
-		brandId           sql.NullString
-		image             sql.NullString
-		favorite          sql.NullBool
-		name              sql.NullString
-		price             sql.NullFloat64
-		withDiscount      sql.NullFloat64
-		rating            sql.NullFloat64
-		description       sql.NullString
-		itemCount         sql.NullInt64
-		status            sql.NullString
-		discountPercent   sql.NullFloat64
-		discountEndTimeDB sql.NullTime
-		createdAt         sql.NullTime
-	)
-
-	err := u.db.QueryRow(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		id,
 		req.CategoryId,
 		req.BrandId,
 		req.Image,
-		req.Favorite,
 		req.Name,
+		slug,
 		req.Price,
-		finalPrice,
-		req.Rating,
 		req.Description,
 		req.ItemCount,
 		req.Status,
 		req.DiscountPercent,
+		discountStartTime,
 		discountEndTime,
 		currentTime,
-	).Scan(
-		&idd,
-		&categoryId,
-		&brandId,
-		&image,
-		&favorite,
-		&name,
-		&price,
-		&withDiscount,
-		&rating,
-		&description,
-		&itemCount,
-		&status,
-		&discountPercent,
-		&discountEndTimeDB,
-		&createdAt,
+		translationsParam(req.NameI18n),
+		translationsParam(req.DescriptionI18n),
 	)
-
 	if err != nil {
 		u.log.Error("Error while creating product: " + err.Error())
 		return nil, err
 	}
 
-	return &models.Product{
-		Id:              idd.String,
-		CategoryId:      categoryId.String,
-		BrandId:         brandId.String,
-		Image:           image.String,
-		Favorite:        favorite.Bool,
-		Name:            name.String,
-		Price:           price.Float64,
-		WithDiscount:    withDiscount.Float64,
-		Rating:          rating.Float64,
-		Description:     description.String,
-		ItemCount:       int(itemCount.Int64),
-		Status:          status.String,
-		DiscountPercent: discountPercent.Float64,
-		DiscountEndTime: discountEndTimeDB.Time.Format(time.RFC3339),
-		CreatedAt:       createdAt.Time.Format(time.RFC3339),
-	}, nil
+	if _, err = applyDiscounts(ctx, tx, id); err != nil {
+		u.log.Error("Error while applying product discount: " + err.Error())
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		u.log.Error("Error while committing product create: " + err.Error())
+		return nil, err
+	}
+
+	return u.GetByID(ctx, &models.ProductPrimaryKey{Id: id})
 }
 
 func (u *productRepo) GetByID(ctx context.Context, req *models.ProductPrimaryKey) (*models.Product, error) {
 	var (
-		id            sql.NullString
-		category_id   sql.NullString
-		brand_id      sql.NullString
-		image         sql.NullString
-		favorite      sql.NullBool
-		name          sql.NullString
-		price         sql.NullFloat64
-		with_discount sql.NullFloat64
-		rating        sql.NullFloat64
-		description   sql.NullString
-		item_count    sql.NullInt64
-		status        sql.NullString
-		discount      sql.NullFloat64
-		discount_end  sql.NullString
-		created_at    sql.NullString
+		id             sql.NullString
+		category_id    sql.NullString
+		brand_id       sql.NullString
+		image          sql.NullString
+		is_favorite    sql.NullBool
+		name           sql.NullString
+		slug           sql.NullString
+		price          sql.NullFloat64
+		with_discount  sql.NullFloat64
+		min_price      sql.NullFloat64
+		max_price      sql.NullFloat64
+		rating         sql.NullFloat64
+		review_count   sql.NullInt64
+		description    sql.NullString
+		item_count     sql.NullInt64
+		status         sql.NullString
+		discount       sql.NullFloat64
+		discount_start sql.NullString
+		discount_end   sql.NullString
+		created_at     sql.NullString
+		name_i18n      sql.NullString
+		desc_i18n      sql.NullString
+		deleted_at     sql.NullString
 	)
 
 	query := `
 		SELECT 
-			id,
-			category_id,
-			brand_id,
-			image,
-			favorite,
-			name,
-			price,
-			with_discount,
-			rating,
-			description,
-			item_count,
-			status,
-			discount_percent,
-			discount_end_time,
-			created_at
-		FROM "product" 
-		WHERE id = $1
+			p.id,
+			p.category_id,
+			p.brand_id,
+			p.image,
+			` + productIsFavorite("NULLIF($2, '')::UUID") + ` AS is_favorite,
+			p.name,
+			p.slug,
+			p.price,
+			p.with_discount,
+			` + productMinPrice + ` AS min_price,
+			` + productMaxPrice + ` AS max_price,
+			p.rating,
+			p.review_count,
+			p.description,
+			` + productItemCount + ` AS item_count,
+			p.status,
+			p.discount_percent,
+			p.discount_start_time,
+			p.discount_end_time,
+			p.created_at,
+			p.name_i18n::TEXT,
+			p.description_i18n::TEXT,
+			p.deleted_at
+		FROM "product" p
+		WHERE p.id = $1 AND ($3 OR p.deleted_at IS NULL)
 	`
 
-	err := u.db.QueryRow(ctx, query, req.Id).Scan(
+	key := req.Id
+	if req.Slug != "" {
+		query = strings.Replace(query, "WHERE p.id = $1", "WHERE p.slug = $1", 1)
+		key = req.Slug
+	}
+
+	err := u.db.QueryRow(ctx, query, key, req.CustomerId, req.IncludeDeleted).Scan(
 		&id,
 		&category_id,
 		&brand_id,
 		&image,
-		&favorite,
+		&is_favorite,
 		&name,
+		&slug,
 		&price,
 		&with_discount,
+		&min_price,
+		&max_price,
 		&rating,
+		&review_count,
 		&description,
 		&item_count,
 		&status,
 		&discount,
+		&discount_start,
 		&discount_end,
 		&created_at,
+		&name_i18n,
+		&desc_i18n,
+		&deleted_at,
 	)
 
 	if err != nil && err.Error() != "no rows in result set" {
@@ -223,118 +216,80 @@ func (u *productRepo) GetByID(ctx context.Context, req *models.ProductPrimaryKey
 		return nil, err
 	}
 
+	nameI18n, descriptionI18n := scanTranslations(name_i18n), scanTranslations(desc_i18n)
+
 	return &models.Product{
-		Id:              id.String,
-		CategoryId:      category_id.String,
-		BrandId:         brand_id.String,
-		Image:           image.String,
-		Favorite:        favorite.Bool,
-		Name:            name.String,
-		Price:           price.Float64,
-		WithDiscount:    with_discount.Float64,
-		Rating:          rating.Float64,
-		Description:     description.String,
-		ItemCount:       int(item_count.Int64),
-		Status:          status.String,
-		DiscountPercent: discount.Float64,
-		DiscountEndTime: discount_end.String,
-		CreatedAt:       created_at.String,
+		Id:                id.String,
+		CategoryId:        category_id.String,
+		BrandId:           brand_id.String,
+		Image:             image.String,
+		IsFavorite:        is_favorite.Bool,
+		Name:              nameI18n.Localize(name.String, req.Lang),
+		Slug:              slug.String,
+		Price:             price.Float64,
+		WithDiscount:      with_discount.Float64,
+		MinPrice:          min_price.Float64,
+		MaxPrice:          max_price.Float64,
+		Rating:            rating.Float64,
+		ReviewCount:       int(review_count.Int64),
+		Description:       descriptionI18n.Localize(description.String, req.Lang),
+		ItemCount:         int(item_count.Int64),
+		Status:            status.String,
+		DiscountPercent:   discount.Float64,
+		DiscountStartTime: discount_start.String,
+		DiscountEndTime:   discount_end.String,
+		CreatedAt:         created_at.String,
+		NameI18n:          nameI18n,
+		DescriptionI18n:   descriptionI18n,
+		DeletedAt:         deleted_at.String,
 	}, nil
 }
 
 func (u *productRepo) GetList(ctx context.Context, req *models.ProductGetListRequest) (*models.ProductGetListResponse, error) {
 	var (
 		resp   = &models.ProductGetListResponse{}
-		query  string
 		offset = " OFFSET 0"
 		limit  = " LIMIT 10"
-		filter string
-		args   []interface{}
 	)
 
-	if req.CategoryId != "" {
-		query = `
-			WITH RECURSIVE category_hierarchy AS (
-				SELECT id FROM category WHERE id = $1
-				UNION ALL
-				SELECT c.id FROM category c
-				INNER JOIN category_hierarchy ch ON c.parent_id = ch.id
-			),
-			product_count AS (
-				SELECT COUNT(DISTINCT p.id) AS total_count
-				FROM product p
-				WHERE p.category_id IN (SELECT id FROM category_hierarchy)
-			)
-			SELECT 
-				(SELECT total_count FROM product_count),
-				p.id, 
-				p.category_id,
-				p.brand_id,
-				p.image,
-				p.favorite, 
-				p.name, 
-				p.price, 
-				p.with_discount, 
-				p.rating, 
-				p.description, 
-				COALESCE(SUM(c.count), 0) AS item_count,
-				p.status,
-				p.discount_percent,
-				p.discount_end_time,
-				p.created_at,
-				c.id AS color_id,
-				c.color_name,
-				c.color_url AS color_url,
-				c.count
-			FROM product p
-			LEFT JOIN color c ON p.id = c.product_id
-			WHERE p.category_id IN (SELECT id FROM category_hierarchy)
-		`
-		args = append(args, req.CategoryId)
-	} else {
-		query = `
-			WITH product_count AS (
-				SELECT COUNT(DISTINCT p.id) AS total_count
-				FROM product p
-			)
-			SELECT 
-				(SELECT total_count FROM product_count),
-				p.id, 
-				p.category_id,
-				p.brand_id,
-				p.image,
-				p.favorite, 
-				p.name, 
-				p.price, 
-				p.with_discount, 
-				p.rating, 
-				p.description, 
-				COALESCE(SUM(c.count), 0) AS item_count,
-				p.status,
-				p.discount_percent,
-				p.discount_end_time,
-				p.created_at,
-				c.id AS color_id,
-				c.color_name,
-				c.color_url AS color_url,
-				c.count 
-			FROM product p
-			LEFT JOIN color c ON p.id = c.product_id
-			WHERE 1=1
-		`
-	}
-
-	if req.Favorite != nil {
-		filter += " AND p.favorite = $" + strconv.Itoa(len(args)+1)
-		args = append(args, *req.Favorite)
-	}
-
-	if filter != "" {
-		query += filter
-	}
-
-	query += " GROUP BY p.id, c.id"
-	query += " ORDER BY p.created_at DESC"
+	filter := newProductFilter(req)
+
+	isFavorite := "FALSE"
+	if req.CustomerId != "" {
+		isFavorite = productIsFavorite(filter.arg(req.CustomerId))
+	}
+
+	query := filter.with + `
+		SELECT
+			COUNT(*) OVER(),
+			p.id,
+			p.category_id,
+			p.brand_id,
+			p.image,
+			` + isFavorite + ` AS is_favorite,
+			p.name,
+			p.slug,
+			p.price,
+			p.with_discount,
+			` + productMinPrice + ` AS min_price,
+			` + productMaxPrice + ` AS max_price,
+			p.rating,
+			p.review_count,
+			p.description,
+			` + productItemCount + ` AS item_count,
+			p.status,
+			p.discount_percent,
+			p.discount_start_time,
+			p.discount_end_time,
+			p.created_at,
+			p.name_i18n::TEXT,
+			p.description_i18n::TEXT,
+			p.deleted_at
+		FROM product p
+		WHERE 1=1
+	` + filter.where
+
+	query += filter.orderBy(req.Sort)
 
 	if req.Offset > 0 {
 		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
@@ -345,191 +300,304 @@ func (u *productRepo) GetList(ctx context.Context, req *models.ProductGetListReq
 
 	query += offset + limit
 
-	rows, err := u.db.Query(ctx, query, args...)
+	rows, err := u.db.Query(ctx, query, filter.args...)
 	if err != nil {
 		u.log.Error("Error while getting product list: " + err.Error())
 		return nil, err
 	}
 	defer rows.Close()
 
-	productsMap := make(map[string]*models.Product)
-	var totalCount int
-	currentTimeUTC := time.Now().UTC()
+	var ids []string
 
 	for rows.Next() {
 		var (
-			product           models.Product
-			id                sql.NullString
-			category_id       sql.NullString
-			brand_id          sql.NullString
-			image             sql.NullString
-			name              sql.NullString
-			price             sql.NullFloat64
-			with_discount     sql.NullFloat64
-			rating            sql.NullFloat64
-			description       sql.NullString
-			item_count        sql.NullInt64 // This will hold the sum of color counts
-			status            sql.NullString
-			discount_percent  sql.NullFloat64
-			discount_end_time sql.NullString
-			created_at        sql.NullString
-			color_id          sql.NullString
-			color_name        sql.NullString
-			color_url         pq.StringArray
-			color_count       sql.NullInt32
+			id                  sql.NullString
+			category_id         sql.NullString
+			brand_id            sql.NullString
+			image               sql.NullString
+			is_favorite         sql.NullBool
+			name                sql.NullString
+			slug                sql.NullString
+			price               sql.NullFloat64
+			with_discount       sql.NullFloat64
+			min_price           sql.NullFloat64
+			max_price           sql.NullFloat64
+			rating              sql.NullFloat64
+			review_count        sql.NullInt64
+			description         sql.NullString
+			item_count          sql.NullInt64 // sum of variant or color counts
+			status              sql.NullString
+			discount_percent    sql.NullFloat64
+			discount_start_time sql.NullString
+			discount_end_time   sql.NullString
+			created_at          sql.NullString
+			name_i18n           sql.NullString
+			desc_i18n           sql.NullString
+			deleted_at          sql.NullString
 		)
 
 		err = rows.Scan(
-			&totalCount,
+			&resp.Count,
 			&id,
 			&category_id,
 			&brand_id,
 			&image,
-			&product.Favorite,
+			&is_favorite,
 			&name,
+			&slug,
 			&price,
 			&with_discount,
+			&min_price,
+			&max_price,
 			&rating,
+			&review_count,
 			&description,
 			&item_count,
 			&status,
 			&discount_percent,
+			&discount_start_time,
 			&discount_end_time,
 			&created_at,
-			&color_id,
-			&color_name,
-			&color_url,
-			&color_count,
+			&name_i18n,
+			&desc_i18n,
+			&deleted_at,
 		)
 		if err != nil {
 			u.log.Error("Error while scanning product list data: " + err.Error())
 			return nil, err
 		}
 
-		// Assign summed item_count to product
-		product.ItemCount = int(item_count.Int64)
-
-		// Check discount end time
-		if discount_end_time.Valid {
-			discountEndTime, err := time.Parse(time.RFC3339, discount_end_time.String)
-			if err != nil {
-				u.log.Error("Error parsing discount end time: " + err.Error())
-				continue
-			}
-
-			discountEndTimeUTC := discountEndTime.UTC()
-			if currentTimeUTC.After(discountEndTimeUTC) {
-				// Update product status if discount has ended
-				status.String = ""
-				with_discount.Float64 = 0
-				discount_percent.Float64 = 0
-				discount_end_time.String = ""
-			}
-		}
+		nameI18n, descriptionI18n := scanTranslations(name_i18n), scanTranslations(desc_i18n)
+
+		ids = append(ids, id.String)
+		resp.Product = append(resp.Product, models.Product{
+			Id:                id.String,
+			CategoryId:        category_id.String,
+			BrandId:           brand_id.String,
+			Image:             image.String,
+			IsFavorite:        is_favorite.Bool,
+			Name:              nameI18n.Localize(name.String, req.Lang),
+			Slug:              slug.String,
+			Price:             price.Float64,
+			WithDiscount:      with_discount.Float64,
+			MinPrice:          min_price.Float64,
+			MaxPrice:          max_price.Float64,
+			Rating:            rating.Float64,
+			ReviewCount:       int(review_count.Int64),
+			Description:       descriptionI18n.Localize(description.String, req.Lang),
+			ItemCount:         int(item_count.Int64),
+			Status:            status.String,
+			DiscountPercent:   discount_percent.Float64,
+			DiscountStartTime: discount_start_time.String,
+			DiscountEndTime:   discount_end_time.String,
+			CreatedAt:         created_at.String,
+			NameI18n:          nameI18n,
+			DescriptionI18n:   descriptionI18n,
+			DeletedAt:         deleted_at.String,
+			Color:             []models.Color{},
+		})
+	}
+	if err = rows.Err(); err != nil {
+		u.log.Error("Error while iterating product list: " + err.Error())
+		return nil, err
+	}
 
-		if _, ok := productsMap[id.String]; !ok {
-			productsMap[id.String] = &models.Product{
-				Id:              id.String,
-				CategoryId:      category_id.String,
-				BrandId:         brand_id.String,
-				Image:           image.String,
-				Favorite:        product.Favorite,
-				Name:            name.String,
-				Price:           price.Float64,
-				WithDiscount:    with_discount.Float64,
-				Rating:          rating.Float64,
-				Description:     description.String,
-				ItemCount:       product.ItemCount, // Use the summed item_count
-				Status:          status.String,
-				DiscountPercent: discount_percent.Float64,
-				DiscountEndTime: discount_end_time.String,
-				CreatedAt:       created_at.String,
-				Color:           []models.Color{},
-			}
-		}
+	resp.Facets, err = u.getFacets(ctx, req)
+	if err != nil {
+		return nil, err
+	}
 
-		if color_id.Valid {
-			var found bool
-			for _, color := range productsMap[id.String].Color {
-				if color.Id == color_id.String {
-					color.Url = color_url
-					color.Count = int(color_count.Int32)
-					found = true
-					break
-				}
-			}
-			if !found {
-				productsMap[id.String].Color = append(productsMap[id.String].Color, models.Color{
-					Id:    color_id.String,
-					Name:  color_name.String,
-					Url:   color_url,
-					Count: int(color_count.Int32),
-				})
-			}
-		}
+	if len(ids) == 0 {
+		return resp, nil
 	}
 
-	if req.Name != "" {
-		productNames := make([]string, 0, len(productsMap))
-		for _, product := range productsMap {
-			productNames = append(productNames, product.Name)
-		}
+	colors, err := u.getColors(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
 
-		filteredNames := filterStrings(productNames, req.Name)
-		filteredProductsMap := make(map[string]*models.Product)
-		for _, product := range productsMap {
-			if contains(filteredNames, product.Name) {
-				filteredProductsMap[product.Id] = product
-			}
+	for i := range resp.Product {
+		if productColors, ok := colors[resp.Product[i].Id]; ok {
+			resp.Product[i].Color = productColors
 		}
-		productsMap = filteredProductsMap
 	}
 
-	// Mahsulotlarni yaratilish vaqti bo'yicha tartiblash
-	var sortedProducts []*models.Product
-	for _, product := range productsMap {
-		sortedProducts = append(sortedProducts, product)
-	}
-	sort.Slice(sortedProducts, func(i, j int) bool {
-		timeI, _ := time.Parse(time.RFC3339, sortedProducts[i].CreatedAt)
-		timeJ, _ := time.Parse(time.RFC3339, sortedProducts[j].CreatedAt)
-		return timeI.After(timeJ)
-	})
+	return resp, nil
+}
 
-	// Tartiblangan mahsulotlarni javobga qo'shish
-	for _, product := range sortedProducts {
-		resp.Product = append(resp.Product, *product)
+// getColors returns the colors of the products that are not deleted keyed by
+// product id
+func (u *productRepo) getColors(ctx context.Context, productIds []string) (map[string][]models.Color, error) {
+	query := `
+		SELECT
+			id,
+			product_id,
+			color_name,
+			color_url,
+			count
+		FROM color
+		WHERE product_id = ANY($1::UUID[]) AND deleted_at IS NULL
+		ORDER BY created_at
+	`
+
+	rows, err := u.db.Query(ctx, query, productIds)
+	if err != nil {
+		u.log.Error("Error while getting product colors: " + err.Error())
+		return nil, err
 	}
+	defer rows.Close()
 
-	resp.Count = totalCount // Total count of distinct products
+	colors := make(map[string][]models.Color, len(productIds))
+	for rows.Next() {
+		var (
+			id         sql.NullString
+			product_id sql.NullString
+			color_name sql.NullString
+			color_url  pq.StringArray
+			count      sql.NullInt32
+		)
 
-	return resp, nil
+		if err = rows.Scan(&id, &product_id, &color_name, &color_url, &count); err != nil {
+			u.log.Error("Error while scanning product colors: " + err.Error())
+			return nil, err
+		}
+
+		colors[product_id.String] = append(colors[product_id.String], models.Color{
+			Id:    id.String,
+			Name:  color_name.String,
+			Url:   color_url,
+			Count: int(count.Int32),
+		})
+	}
+
+	return colors, rows.Err()
 }
 
-func filterStrings(stringsList []string, query string) []string {
-	var results []string
-	query = strings.ToLower(query)
-
-	for _, str := range stringsList {
-		words := strings.Fields(str)
-		for _, word := range words {
-			if strings.HasPrefix(strings.ToLower(word), query) {
-				results = append(results, str)
-				break
-			}
+// getFacets counts the filtered products per brand, status and price
+// bucket, leaving out the facet's own filter
+func (u *productRepo) getFacets(ctx context.Context, req *models.ProductGetListRequest) (*models.ProductFacets, error) {
+	facets := &models.ProductFacets{
+		Brands:   []models.BrandFacet{},
+		Statuses: []models.StatusFacet{},
+		Prices:   []models.PriceFacet{},
+	}
+
+	brandReq := *req
+	brandReq.BrandIds = nil
+	filter := newProductFilter(&brandReq)
+
+	query := filter.with + `
+		SELECT
+			p.brand_id,
+			b.name,
+			b.name_i18n::TEXT,
+			COUNT(*)
+		FROM product p
+		INNER JOIN brand b ON b.id = p.brand_id
+		WHERE 1=1
+	` + filter.where + `
+		GROUP BY p.brand_id, b.name, b.name_i18n
+		ORDER BY COUNT(*) DESC, b.name
+	`
+
+	rows, err := u.db.Query(ctx, query, filter.args...)
+	if err != nil {
+		u.log.Error("Error while getting product brand facets: " + err.Error())
+		return nil, err
+	}
+	for rows.Next() {
+		var (
+			brand_id  sql.NullString
+			name      sql.NullString
+			name_i18n sql.NullString
+			count     int
+		)
+		if err = rows.Scan(&brand_id, &name, &name_i18n, &count); err != nil {
+			rows.Close()
+			u.log.Error("Error while scanning product brand facets: " + err.Error())
+			return nil, err
 		}
+		facets.Brands = append(facets.Brands, models.BrandFacet{
+			BrandId: brand_id.String,
+			Name:    scanTranslations(name_i18n).Localize(name.String, req.Lang),
+			Count:   count,
+		})
 	}
+	rows.Close()
+
+	statusReq := *req
+	statusReq.Statuses = nil
+	filter = newProductFilter(&statusReq)
+
+	query = filter.with + `
+		SELECT
+			p.status::TEXT,
+			COUNT(*)
+		FROM product p
+		WHERE p.status IS NOT NULL AND p.status <> ''
+	` + filter.where + `
+		GROUP BY p.status
+		ORDER BY COUNT(*) DESC
+	`
 
-	return results
-}
+	rows, err = u.db.Query(ctx, query, filter.args...)
+	if err != nil {
+		u.log.Error("Error while getting product status facets: " + err.Error())
+		return nil, err
+	}
+	for rows.Next() {
+		var facet models.StatusFacet
+		if err = rows.Scan(&facet.Status, &facet.Count); err != nil {
+			rows.Close()
+			u.log.Error("Error while scanning product status facets: " + err.Error())
+			return nil, err
+		}
+		facets.Statuses = append(facets.Statuses, facet)
+	}
+	rows.Close()
+
+	priceReq := *req
+	priceReq.MinPrice, priceReq.MaxPrice = nil, nil
+	filter = newProductFilter(&priceReq)
+
+	// width_bucket numbers the buckets from 1, prices below the first bound get 0
+	query = filter.with + `
+		SELECT
+			width_bucket(` + productMinPrice + `::FLOAT8, ` + filter.arg(productPriceBuckets) + `::FLOAT8[]) AS bucket,
+			COUNT(*)
+		FROM product p
+		WHERE 1=1
+	` + filter.where + `
+		GROUP BY bucket
+		ORDER BY bucket
+	`
+
+	rows, err = u.db.Query(ctx, query, filter.args...)
+	if err != nil {
+		u.log.Error("Error while getting product price facets: " + err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bucket, count int
+		if err = rows.Scan(&bucket, &count); err != nil {
+			u.log.Error("Error while scanning product price facets: " + err.Error())
+			return nil, err
+		}
+		if bucket < 1 {
+			continue
+		}
 
-func contains(slice []string, str string) bool {
-	for _, item := range slice {
-		if item == str {
-			return true
+		facet := models.PriceFacet{From: productPriceBuckets[bucket-1], Count: count}
+		if bucket < len(productPriceBuckets) {
+			to := productPriceBuckets[bucket]
+			facet.To = &to
 		}
+		facets.Prices = append(facets.Prices, facet)
 	}
-	return false
+
+	return facets, rows.Err()
 }
 
 func (u *productRepo) Update(ctx context.Context, req *models.ProductUpdate) (int64, error) {
@@ -538,32 +606,43 @@ func (u *productRepo) Update(ctx context.Context, req *models.ProductUpdate) (in
 	loc, _ := time.LoadLocation("Asia/Tashkent")
 	currentTime := time.Now().In(loc)
 
-	var finalPrice float64
-	var discountEndTime interface{}
-
-	if req.Status == "vremennaya_skidka" {
-		if req.DiscountPercent > 0 {
-			finalPrice = req.Price - (req.Price * req.DiscountPercent / 100)
-
-			if req.DiscountEndTime != "" {
-				parsedTime, err := time.Parse(time.RFC3339, req.DiscountEndTime)
-				if err != nil {
-					u.log.Error("Error parsing discount end time: " + err.Error())
-					return 0, fmt.Errorf("invalid discount end time format")
-				}
-				discountEndTime = parsedTime
-			} else {
-				discountEndTime = nil // Set to NULL if empty string
-			}
-		} else {
-			finalPrice = 0
-			req.DiscountPercent = 0
-			discountEndTime = nil
-		}
-	} else {
-		finalPrice = 0
+	discounted, discountStartTime, discountEndTime, err := discountPeriod(req.Status, req.DiscountPercent, req.DiscountStartTime, req.DiscountEndTime)
+	if err != nil {
+		return 0, err
+	}
+	if !discounted {
 		req.DiscountPercent = 0
-		discountEndTime = nil
+	}
+
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		u.log.Error("Error while starting product update transaction: " + err.Error())
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = setPriceChange(ctx, tx, req.PriceChangedBy, req.PriceChangeReason); err != nil {
+		u.log.Error("Error while setting product price change: " + err.Error())
+		return 0, err
+	}
+
+	var status, statusBeforeDiscount sql.NullString
+	err = tx.QueryRow(ctx, `SELECT status, status_before_discount FROM "product" WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id).Scan(&status, &statusBeforeDiscount)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		u.log.Error("Error while getting product status: " + err.Error())
+		return 0, err
+	}
+
+	// a discounted product keeps the status it has without the discount, the
+	// discount is applied again below with the new price and period
+	if discounted {
+		req.Status = status.String
+		if status.String == models.ProductStatusDiscount {
+			req.Status = statusBeforeDiscount.String
+		}
 	}
 
 	query := `
@@ -572,32 +651,34 @@ func (u *productRepo) Update(ctx context.Context, req *models.ProductUpdate) (in
 		category_id = $1,
         brand_id = $2,
         image = $3,
-        favorite = $4,
-        name = $5,
-        price = $6,
-        with_discount = $7,
-        rating = $8,
-        description = $9,
-		status = $10,
-		discount_percent = $11,
-		discount_end_time = $12,
+        name = $4,
+        price = $5,
+        with_discount = 0,
+        description = $6,
+		status = $7,
+		discount_percent = $8,
+		discount_start_time = $9,
+		discount_end_time = $10,
+		status_before_discount = NULL,
+		name_i18n = COALESCE($11::JSONB, name_i18n),
+		description_i18n = COALESCE($12::JSONB, description_i18n),
         updated_at = $13
     WHERE id = $14
     `
 
-	result, err := u.db.Exec(ctx, query,
+	result, err := tx.Exec(ctx, query,
 		req.CategoryId,
 		req.BrandId,
 		req.Image,
-		req.Favorite,
 		req.Name,
-		req.Price,  // Original price
-		finalPrice, // Final price with discount applied
-		req.Rating,
+		req.Price,
 		req.Description,
 		req.Status,
 		req.DiscountPercent,
-		discountEndTime, // Corrected parameter for discount end time
+		discountStartTime,
+		discountEndTime,
+		translationsParam(req.NameI18n),
+		translationsParam(req.DescriptionI18n),
 		currentTime,
 		id,
 	)
@@ -606,28 +687,60 @@ func (u *productRepo) Update(ctx context.Context, req *models.ProductUpdate) (in
 		return 0, err
 	}
 
-	// After executing the update query
-	rowsAffected := result.RowsAffected() // No error handling needed her            // Return rowsAffected and a nil error
-	if err != nil {
-		u.log.Error("Error getting rows affected: " + err.Error())
-		return rowsAffected, nil
+	if err = renameSlug(ctx, tx, models.SlugEntityProduct, id, req.Name); err != nil {
+		u.log.Error("Error while updating product slug: " + err.Error())
+		return 0, err
 	}
 
-	return rowsAffected, nil
+	if _, err = applyDiscounts(ctx, tx, id); err != nil {
+		u.log.Error("Error while applying product discount: " + err.Error())
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		u.log.Error("Error while committing product update: " + err.Error())
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
 }
 
+// Delete soft deletes the product, its colors stay as they are for the order
+// items referencing them and come back with the product
 func (u *productRepo) Delete(ctx context.Context, req *models.ProductPrimaryKey) error {
-	// Avval color jadvalidan bog'liq ma'lumotlarni o'chirish
-	_, err := u.db.Exec(ctx, `DELETE FROM color WHERE product_id = $1`, req.Id)
+	_, err := u.db.Exec(ctx, `UPDATE product SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, req.Id)
+	if err != nil {
+		u.log.Error("Error while deleting product: " + err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Restore brings back a soft deleted product, its category must not be deleted
+func (u *productRepo) Restore(ctx context.Context, req *models.ProductPrimaryKey) error {
+	var categoryDeleted sql.NullBool
+
+	err := u.db.QueryRow(ctx, `
+		SELECT c.deleted_at IS NOT NULL
+		FROM product p
+		LEFT JOIN category c ON c.id = p.category_id
+		WHERE p.id = $1 AND p.deleted_at IS NOT NULL
+	`, req.Id).Scan(&categoryDeleted)
+	if err == pgx.ErrNoRows {
+		return storage.ErrNotDeleted
+	}
 	if err != nil {
-		u.log.Error("Error while deleting color records: " + err.Error())
+		u.log.Error("Error while checking product to restore: " + err.Error())
 		return err
 	}
+	if categoryDeleted.Bool {
+		return storage.ErrParentDeleted
+	}
 
-	// Keyin product jadvalidan ma'lumotni o'chirish
-	_, err = u.db.Exec(ctx, `DELETE FROM product WHERE id = $1`, req.Id)
+	_, err = u.db.Exec(ctx, `UPDATE product SET deleted_at = NULL, updated_at = NOW() WHERE id = $1`, req.Id)
 	if err != nil {
-		u.log.Error("Error while deleting product: " + err.Error())
+		u.log.Error("Error while restoring product: " + err.Error())
 		return err
 	}
 
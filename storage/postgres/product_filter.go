@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"e-commerce/models"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// productEffectivePrice is what the customer pays, the discounted price
+	// while a discount is running
+	productEffectivePrice = `(CASE WHEN p.with_discount > 0 THEN p.with_discount ELSE p.price END)`
+
+	// productVariantPrice is the effective price of variant v of product p,
+	// the running product discount applies to its own price as well
+	productVariantPrice = `(CASE WHEN v.price IS NULL THEN ` + productEffectivePrice + `
+		WHEN p.with_discount > 0 THEN ROUND(v.price * (100 - COALESCE(p.discount_percent, 0)) / 100, 2)
+		ELSE v.price END)`
+
+	// productMinPrice and productMaxPrice are the price range over the
+	// variants, or the product price when it has none
+	productMinPrice = `COALESCE((SELECT MIN(` + productVariantPrice + `) FROM product_variant v WHERE v.product_id = p.id), ` + productEffectivePrice + `)`
+	productMaxPrice = `COALESCE((SELECT MAX(` + productVariantPrice + `) FROM product_variant v WHERE v.product_id = p.id), ` + productEffectivePrice + `)`
+
+	// productItemCount is the stock of the product over its variants, or
+	// over its colors that are not deleted when it has no variants
+	productItemCount = `COALESCE(
+		(SELECT SUM(v.count) FROM product_variant v WHERE v.product_id = p.id),
+		(SELECT SUM(c.count) FROM color c WHERE c.product_id = p.id AND c.deleted_at IS NULL),
+		0)`
+)
+
+// productIsFavorite tells whether product p is in the wishlist of the customer
+// whose id is bound to the placeholder
+func productIsFavorite(customerId string) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM customer_favorite f WHERE f.product_id = p.id AND f.customer_id = %s)", customerId)
+}
+
+// productPriceBuckets are the lower bounds of the price facet buckets in sum
+var productPriceBuckets = []float64{0, 100000, 250000, 500000, 1000000, 2500000, 5000000, 10000000}
+
+// productFilter holds the SQL the product list filters translate to. The
+// query it is used in must alias product as p.
+type productFilter struct {
+	with  string
+	where string
+	rank  string
+	args  []interface{}
+}
+
+func newProductFilter(req *models.ProductGetListRequest) *productFilter {
+	f := &productFilter{}
+
+	if !req.IncludeDeleted {
+		f.where += " AND p.deleted_at IS NULL"
+	}
+
+	if req.CategoryId != "" {
+		// products of subcategories belong to the category as well
+		f.with = fmt.Sprintf(`
+			WITH RECURSIVE category_hierarchy AS (
+				SELECT id FROM category WHERE id = %s
+				UNION ALL
+				SELECT c.id FROM category c
+				INNER JOIN category_hierarchy ch ON c.parent_id = ch.id
+			)`, f.arg(req.CategoryId))
+		f.where += " AND p.category_id IN (SELECT id FROM category_hierarchy)"
+	}
+
+	if len(req.BrandIds) > 0 {
+		f.where += fmt.Sprintf(" AND p.brand_id = ANY(%s::UUID[])", f.arg(req.BrandIds))
+	}
+
+	if req.Favorite != nil && req.CustomerId != "" {
+		f.where += fmt.Sprintf(" AND %s = %s", productIsFavorite(f.arg(req.CustomerId)), f.arg(*req.Favorite))
+	}
+
+	if len(req.Statuses) > 0 {
+		f.where += fmt.Sprintf(" AND p.status::TEXT = ANY(%s::TEXT[])", f.arg(req.Statuses))
+	}
+
+	if len(req.Colors) > 0 {
+		colors := make([]string, len(req.Colors))
+		for i, color := range req.Colors {
+			colors[i] = strings.ToLower(color)
+		}
+		arg := f.arg(colors)
+		f.where += fmt.Sprintf(` AND (
+			EXISTS (SELECT 1 FROM color c WHERE c.product_id = p.id AND c.deleted_at IS NULL AND lower(c.color_name) = ANY(%s::TEXT[]))
+			OR EXISTS (SELECT 1 FROM product_variant v WHERE v.product_id = p.id AND lower(v.options->>'color') = ANY(%s::TEXT[]))
+		)`, arg, arg)
+	}
+
+	// a product matches when its price range overlaps the requested one
+	if req.MinPrice != nil {
+		f.where += fmt.Sprintf(" AND %s >= %s", productMaxPrice, f.arg(*req.MinPrice))
+	}
+
+	if req.MaxPrice != nil {
+		f.where += fmt.Sprintf(" AND %s <= %s", productMinPrice, f.arg(*req.MaxPrice))
+	}
+
+	if req.MinRating != nil {
+		f.where += " AND p.rating >= " + f.arg(*req.MinRating)
+	}
+
+	if req.InStock {
+		f.where += fmt.Sprintf(" AND %s > 0", productItemCount)
+	}
+
+	if search := strings.TrimSpace(req.Name); search != "" {
+		f.search(search)
+	}
+
+	return f
+}
+
+// search matches the words of the term as prefixes against the product's
+// search vector, trigram similarity of the name covers typos.
+func (f *productFilter) search(term string) {
+	name := f.arg(strings.ToLower(term))
+	similarity := fmt.Sprintf("similarity(lower(p.name), %s)", name)
+
+	tsquery := prefixTsquery(term)
+	if tsquery == "" {
+		f.where += fmt.Sprintf(" AND lower(p.name) %% %s", name)
+		f.rank = similarity
+		return
+	}
+
+	query := fmt.Sprintf("to_tsquery('simple', %s)", f.arg(tsquery))
+	f.where += fmt.Sprintf(" AND (p.search_vector @@ %s OR lower(p.name) %% %s)", query, name)
+	f.rank = fmt.Sprintf("(ts_rank(p.search_vector, %s) + %s)", query, similarity)
+}
+
+// orderBy sorts by the requested option, a search without one is sorted by
+// relevance. The newest products come first on ties.
+func (f *productFilter) orderBy(sort string) string {
+	switch sort {
+	case models.ProductSortPriceAsc:
+		return " ORDER BY " + productMinPrice + " ASC, p.created_at DESC"
+	case models.ProductSortPriceDesc:
+		return " ORDER BY " + productMaxPrice + " DESC, p.created_at DESC"
+	case models.ProductSortRating:
+		return " ORDER BY p.rating DESC, p.review_count DESC, p.created_at DESC"
+	case models.ProductSortPopular:
+		return " ORDER BY COALESCE(p.order_count, 0) DESC, p.created_at DESC"
+	case models.ProductSortNewest:
+		return " ORDER BY p.created_at DESC"
+	}
+
+	if f.rank != "" {
+		return " ORDER BY " + f.rank + " DESC, p.created_at DESC"
+	}
+
+	return " ORDER BY p.created_at DESC"
+}
+
+func (f *productFilter) arg(value interface{}) string {
+	f.args = append(f.args, value)
+	return fmt.Sprintf("$%d", len(f.args))
+}
+
+// prefixTsquery turns "iphone 15 pro" into "iphone:* & 15:* & pro:*",
+// anything but letters and digits is dropped so the input can not break
+// the tsquery syntax.
+func prefixTsquery(term string) string {
+	words := strings.FieldsFunc(strings.ToLower(term), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
@@ -0,0 +1,499 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/models"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/lib/pq"
+)
+
+// importRowError is a problem of a row the catalog team can fix in the file
+type importRowError struct {
+	column  string
+	message string
+}
+
+func (e *importRowError) Error() string {
+	return e.column + ": " + e.message
+}
+
+// productImport holds the state of an import shared by its rows
+type productImport struct {
+	categories map[string]string
+	brands     map[string]string
+	created    map[string]bool
+	updated    map[string]bool
+}
+
+// importedRow tells what saving a row changed
+type importedRow struct {
+	productId      string
+	productCreated bool
+	productUpdated bool
+	colorCreated   bool
+	colorUpdated   bool
+}
+
+// Import creates or updates the product of each row with its color and
+// stock. A row names an existing product by its slug or, without one, by its
+// name. The rows run in one transaction with a savepoint each, so every row
+// is checked even after one fails. Nothing is saved on a dry run or when a
+// row fails.
+func (u *productRepo) Import(ctx context.Context, req *models.ProductImportRequest) (*models.ProductImportReport, error) {
+	report := &models.ProductImportReport{
+		DryRun: req.DryRun,
+		Rows:   len(req.Rows),
+		Errors: []models.ProductImportError{},
+	}
+
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		u.log.Error("Error while starting product import transaction: " + err.Error())
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = setPriceChange(ctx, tx, req.ImportedBy, models.PriceChangeReasonImport); err != nil {
+		u.log.Error("Error while setting product price change: " + err.Error())
+		return nil, err
+	}
+
+	if err = setStockMovement(ctx, tx, models.InventoryMovementImport, "", req.ImportedBy); err != nil {
+		u.log.Error("Error while setting product stock movement: " + err.Error())
+		return nil, err
+	}
+
+	imp := &productImport{
+		categories: map[string]string{},
+		brands:     map[string]string{},
+		created:    map[string]bool{},
+		updated:    map[string]bool{},
+	}
+
+	for i := range req.Rows {
+		row := &req.Rows[i]
+
+		savepoint, err := tx.Begin(ctx)
+		if err != nil {
+			u.log.Error("Error while starting product import savepoint: " + err.Error())
+			return nil, err
+		}
+
+		result, err := imp.row(ctx, savepoint, row)
+		if err != nil {
+			if rollbackErr := savepoint.Rollback(ctx); rollbackErr != nil {
+				return nil, rollbackErr
+			}
+
+			importErr := models.ProductImportError{Line: row.Line, Message: "could not save the row: " + err.Error()}
+			var rowErr *importRowError
+			if errors.As(err, &rowErr) {
+				importErr.Column = rowErr.column
+				importErr.Message = rowErr.message
+			} else {
+				u.log.Error("Error while importing product row " + strconv.Itoa(row.Line) + ": " + err.Error())
+			}
+			report.Errors = append(report.Errors, importErr)
+			continue
+		}
+
+		if err = savepoint.Commit(ctx); err != nil {
+			u.log.Error("Error while releasing product import savepoint: " + err.Error())
+			return nil, err
+		}
+
+		switch {
+		case result.productCreated:
+			imp.created[result.productId] = true
+		case result.productUpdated && !imp.created[result.productId]:
+			imp.updated[result.productId] = true
+		}
+		if result.colorCreated {
+			report.ColorsCreated++
+		}
+		if result.colorUpdated {
+			report.ColorsUpdated++
+		}
+	}
+
+	report.ProductsCreated = len(imp.created)
+	report.ProductsUpdated = len(imp.updated)
+
+	if req.DryRun || len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		u.log.Error("Error while committing product import: " + err.Error())
+		return nil, err
+	}
+	report.Applied = true
+
+	return report, nil
+}
+
+func (imp *productImport) row(ctx context.Context, tx pgx.Tx, row *models.ProductCatalogRow) (*importedRow, error) {
+	categoryId, err := imp.reference(ctx, tx, models.SlugEntityCategory, row.Category, imp.categories)
+	if err != nil {
+		return nil, err
+	}
+
+	var brandId string
+	if row.Brand != "" {
+		brandId, err = imp.reference(ctx, tx, models.SlugEntityBrand, row.Brand, imp.brands)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := imp.product(ctx, tx, row, categoryId, brandId)
+	if err != nil {
+		return nil, err
+	}
+
+	if row.Color == "" {
+		if row.Count != nil {
+			return nil, &importRowError{models.ProductColumnCount, "stock is kept per color, give the color"}
+		}
+		if len(row.ColorUrl) > 0 {
+			return nil, &importRowError{models.ProductColumnColorUrl, "give the color the images belong to"}
+		}
+		return result, nil
+	}
+
+	if err = imp.color(ctx, tx, row, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// reference finds the category or brand the cell names by its slug or name
+func (imp *productImport) reference(ctx context.Context, tx pgx.Tx, entityType, value string, cache map[string]string) (string, error) {
+	if id, ok := cache[value]; ok {
+		return id, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, slug = $1 FROM "`+entityType+`"
+		WHERE (slug = $1 OR lower(name) = lower($1)) AND deleted_at IS NULL
+		ORDER BY slug = $1 DESC
+	`, value)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var (
+		matches []string
+		bySlug  bool
+	)
+	for rows.Next() {
+		var (
+			id     string
+			isSlug bool
+		)
+		if err = rows.Scan(&id, &isSlug); err != nil {
+			return "", err
+		}
+		if len(matches) == 0 {
+			bySlug = isSlug
+		}
+		matches = append(matches, id)
+	}
+	if err = rows.Err(); err != nil {
+		return "", err
+	}
+
+	switch {
+	case len(matches) == 0:
+		return "", &importRowError{entityType, "no " + entityType + " has the name or slug " + strconv.Quote(value)}
+	case len(matches) > 1 && !bySlug:
+		return "", &importRowError{entityType, strconv.Quote(value) + " is the name of several, give the slug"}
+	}
+
+	cache[value] = matches[0]
+	return matches[0], nil
+}
+
+func (imp *productImport) product(ctx context.Context, tx pgx.Tx, row *models.ProductCatalogRow, categoryId, brandId string) (*importedRow, error) {
+	var (
+		id  string
+		err error
+	)
+
+	if row.Slug != "" {
+		err = tx.QueryRow(ctx, `SELECT id FROM "product" WHERE slug = $1 AND deleted_at IS NULL`, row.Slug).Scan(&id)
+		if err == pgx.ErrNoRows {
+			return nil, &importRowError{models.ProductColumnSlug, "no product has the slug " + strconv.Quote(row.Slug)}
+		}
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var count int
+		err = tx.QueryRow(ctx, `
+			SELECT COUNT(*), COALESCE(MIN(id::TEXT), '') FROM "product" WHERE lower(name) = lower($1) AND deleted_at IS NULL
+		`, row.Name).Scan(&count, &id)
+		if err != nil {
+			return nil, err
+		}
+		if count > 1 {
+			return nil, &importRowError{models.ProductColumnName, "several products have the name, give the slug"}
+		}
+	}
+
+	if id == "" {
+		return imp.createProduct(ctx, tx, row, categoryId, brandId)
+	}
+
+	// a running discount keeps its status, the given one is restored when it
+	// ends, and its price follows the new price
+	result, err := tx.Exec(ctx, `
+		UPDATE "product"
+		SET
+			category_id = $1::UUID,
+			brand_id = NULLIF($2, '')::UUID,
+			image = $3,
+			name = $4,
+			price = $5,
+			with_discount = CASE WHEN with_discount > 0
+				THEN ROUND($5 * (100 - COALESCE(discount_percent, 0)) / 100, 2)
+				ELSE with_discount END,
+			description = $6,
+			status = CASE WHEN status = 'vremennaya_skidka' THEN status ELSE $7::product_status END,
+			status_before_discount = CASE WHEN status = 'vremennaya_skidka' THEN $7::product_status ELSE status_before_discount END,
+			updated_at = $8
+		WHERE id = $9 AND (
+			category_id IS DISTINCT FROM $1::UUID
+			OR brand_id IS DISTINCT FROM NULLIF($2, '')::UUID
+			OR COALESCE(image, '') <> $3
+			OR name <> $4
+			OR price <> $5
+			OR COALESCE(description, '') <> $6
+			OR COALESCE((CASE WHEN status = 'vremennaya_skidka' THEN status_before_discount ELSE status END)::TEXT, '') <> $7::TEXT
+		)
+	`,
+		categoryId,
+		brandId,
+		row.Image,
+		row.Name,
+		row.Price,
+		row.Description,
+		row.Status,
+		importTime(),
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = renameSlug(ctx, tx, models.SlugEntityProduct, id, row.Name); err != nil {
+		return nil, err
+	}
+
+	return &importedRow{productId: id, productUpdated: result.RowsAffected() > 0}, nil
+}
+
+func (imp *productImport) createProduct(ctx context.Context, tx pgx.Tx, row *models.ProductCatalogRow, categoryId, brandId string) (*importedRow, error) {
+	id := uuid.New().String()
+
+	slug, err := uniqueSlug(ctx, tx, models.SlugEntityProduct, row.Name, id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO "product"(
+			id,
+			category_id,
+			brand_id,
+			image,
+			name,
+			slug,
+			price,
+			with_discount,
+			rating,
+			description,
+			item_count,
+			status,
+			created_at
+		) VALUES ($1, $2, NULLIF($3, '')::UUID, $4, $5, $6, $7, 0, 0, $8, 0, $9, $10)
+	`,
+		id,
+		categoryId,
+		brandId,
+		row.Image,
+		row.Name,
+		slug,
+		row.Price,
+		row.Description,
+		row.Status,
+		importTime(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &importedRow{productId: id, productCreated: true}, nil
+}
+
+// color creates the color of the row or updates its images and stock, an
+// empty color_url or count cell keeps what the color has
+func (imp *productImport) color(ctx context.Context, tx pgx.Tx, row *models.ProductCatalogRow, result *importedRow) error {
+	if row.Count != nil {
+		var hasVariants bool
+		err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM "product_variant" WHERE product_id = $1)`, result.productId).Scan(&hasVariants)
+		if err != nil {
+			return err
+		}
+		if hasVariants {
+			return &importRowError{models.ProductColumnCount, "the product has variants, its stock is kept per variant"}
+		}
+	}
+
+	var urls interface{}
+	if len(row.ColorUrl) > 0 {
+		urls = pq.StringArray(row.ColorUrl)
+	}
+
+	var count interface{}
+	if row.Count != nil {
+		count = *row.Count
+	}
+
+	var id string
+	err := tx.QueryRow(ctx, `
+		SELECT id FROM "color"
+		WHERE product_id = $1 AND lower(color_name) = lower($2) AND deleted_at IS NULL
+		ORDER BY created_at
+		LIMIT 1
+	`, result.productId, row.Color).Scan(&id)
+	if err == pgx.ErrNoRows {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO "color" (
+				id,
+				product_id,
+				color_name,
+				color_url,
+				count,
+				created_at
+			)
+			VALUES ($1, $2, $3, COALESCE($4::TEXT[], '{}'), COALESCE($5::INT, 0), CURRENT_TIMESTAMP)
+		`, uuid.New().String(), result.productId, row.Color, urls, count)
+		if err != nil {
+			return err
+		}
+		result.colorCreated = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	updated, err := tx.Exec(ctx, `
+		UPDATE "color"
+		SET
+			color_url = COALESCE($1::TEXT[], color_url),
+			count = COALESCE($2::INT, count),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3 AND (
+			color_url IS DISTINCT FROM COALESCE($1::TEXT[], color_url)
+			OR count IS DISTINCT FROM COALESCE($2::INT, count)
+		)
+	`, urls, count, id)
+	if err != nil {
+		return err
+	}
+	result.colorUpdated = updated.RowsAffected() > 0
+
+	return nil
+}
+
+// Export passes the catalog to fn a row per product color in the order the
+// products were created. Categories and brands are given by their slugs and
+// the status is the one without a running discount, so the file can be
+// imported back as is.
+func (u *productRepo) Export(ctx context.Context, fn func(row *models.ProductCatalogRow) error) error {
+	rows, err := u.db.Query(ctx, `
+		SELECT
+			p.slug,
+			p.name,
+			COALESCE(cat.slug, ''),
+			COALESCE(b.slug, ''),
+			p.price,
+			COALESCE(p.description, ''),
+			COALESCE(p.image, ''),
+			COALESCE((CASE WHEN p.status = 'vremennaya_skidka' THEN p.status_before_discount ELSE p.status END)::TEXT, ''),
+			c.id IS NOT NULL,
+			COALESCE(c.color_name, ''),
+			c.color_url,
+			COALESCE(c.count, 0),
+			EXISTS (SELECT 1 FROM "product_variant" v WHERE v.product_id = p.id)
+		FROM "product" p
+		LEFT JOIN "category" cat ON cat.id = p.category_id
+		LEFT JOIN "brand" b ON b.id = p.brand_id
+		LEFT JOIN "color" c ON c.product_id = p.id AND c.deleted_at IS NULL
+		WHERE p.deleted_at IS NULL
+		ORDER BY p.created_at, p.id, c.created_at, c.id
+	`)
+	if err != nil {
+		u.log.Error("Error while exporting products: " + err.Error())
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			row         models.ProductCatalogRow
+			price       sql.NullFloat64
+			hasColor    bool
+			colorUrl    pq.StringArray
+			count       int
+			hasVariants bool
+		)
+
+		err = rows.Scan(
+			&row.Slug,
+			&row.Name,
+			&row.Category,
+			&row.Brand,
+			&price,
+			&row.Description,
+			&row.Image,
+			&row.Status,
+			&hasColor,
+			&row.Color,
+			&colorUrl,
+			&count,
+			&hasVariants,
+		)
+		if err != nil {
+			u.log.Error("Error while scanning exported product: " + err.Error())
+			return err
+		}
+
+		row.Price = price.Float64
+		row.ColorUrl = colorUrl
+		if hasColor && !hasVariants {
+			row.Count = &count
+		}
+
+		if err = fn(&row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func importTime() time.Time {
+	loc, _ := time.LoadLocation("Asia/Tashkent")
+	return time.Now().In(loc)
+}
@@ -0,0 +1,284 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/models"
+	"e-commerce/storage"
+)
+
+// productAffinityMaxRelated is how many co-purchased products are kept per
+// product, more than a recommendation ever shows
+const productAffinityMaxRelated = 50
+
+// refreshAffinityQuery scores every pair of products ordered together by the
+// cosine of their orders, an order counts once however many items of a
+// product it has
+const refreshAffinityQuery = `
+	WITH baskets AS (
+		SELECT DISTINCT order_id, product_id
+		FROM "order_items"
+		WHERE order_id IS NOT NULL AND product_id IS NOT NULL
+	), product_orders AS (
+		SELECT product_id, COUNT(*) AS orders
+		FROM baskets
+		GROUP BY product_id
+	), pairs AS (
+		SELECT a.product_id, b.product_id AS related_product_id, COUNT(*) AS orders_together
+		FROM baskets a
+		INNER JOIN baskets b ON b.order_id = a.order_id AND b.product_id <> a.product_id
+		GROUP BY a.product_id, b.product_id
+	), scored AS (
+		SELECT
+			pairs.product_id,
+			pairs.related_product_id,
+			pairs.orders_together,
+			pairs.orders_together / SQRT(po.orders * ro.orders) AS score
+		FROM pairs
+		INNER JOIN product_orders po ON po.product_id = pairs.product_id
+		INNER JOIN product_orders ro ON ro.product_id = pairs.related_product_id
+	), ranked AS (
+		SELECT
+			scored.*,
+			ROW_NUMBER() OVER (PARTITION BY product_id ORDER BY score DESC, orders_together DESC) AS position
+		FROM scored
+	)
+	INSERT INTO "product_affinity" (product_id, related_product_id, orders_together, score, computed_at)
+	SELECT product_id, related_product_id, orders_together, score, NOW()
+	FROM ranked
+	WHERE position <= $1
+`
+
+// RefreshAffinity recomputes the co-purchase affinities from the order
+// history and returns the number of pairs kept. A run already going on in
+// another instance is left alone and 0 is returned.
+func (u *productRepo) RefreshAffinity(ctx context.Context) (int64, error) {
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	err = tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock(hashtext('product_affinity'))`).Scan(&locked)
+	if err != nil {
+		return 0, err
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM "product_affinity"`); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.Exec(ctx, refreshAffinityQuery, productAffinityMaxRelated)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// GetRecommendations returns the products in stock to show next to the
+// product. Products ordered together with it come first for the
+// bought_together kind, then the products of its category and of its brand;
+// the related kind starts with the products of both its category and brand.
+// Within a source products are ranked by affinity, rating and popularity.
+func (u *productRepo) GetRecommendations(ctx context.Context, req *models.ProductRecommendationRequest) (*models.ProductRecommendationResponse, error) {
+	resp := &models.ProductRecommendationResponse{Recommendations: []*models.ProductRecommendation{}}
+
+	var exists bool
+	err := u.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM "product" WHERE id = $1 AND deleted_at IS NULL)`, req.ProductId).Scan(&exists)
+	if err != nil {
+		u.log.Error("Error while checking recommended product: " + err.Error())
+		return nil, err
+	}
+	if !exists {
+		return nil, storage.ErrProductNotFound
+	}
+
+	query := `
+		WITH target AS (
+			SELECT id, category_id, brand_id FROM "product" WHERE id = $1
+		), candidates AS (
+			SELECT a.related_product_id AS id, '` + models.RecommendationSourceBoughtTogether + `' AS source, 1 AS tier
+			FROM "product_affinity" a
+			WHERE a.product_id = $1 AND $2::TEXT = '` + models.RecommendationBoughtTogether + `'
+			UNION ALL
+			SELECT p.id, '` + models.RecommendationSourceSameCategory + `',
+				CASE WHEN $2::TEXT = '` + models.RecommendationRelated + `' AND p.brand_id = t.brand_id THEN 1 ELSE 2 END
+			FROM "product" p
+			INNER JOIN target t ON p.category_id = t.category_id
+			WHERE p.id <> t.id
+			UNION ALL
+			SELECT p.id, '` + models.RecommendationSourceSameBrand + `', 3
+			FROM "product" p
+			INNER JOIN target t ON p.brand_id = t.brand_id
+			WHERE p.id <> t.id
+		), ranked AS (
+			SELECT DISTINCT ON (id) id, source, tier
+			FROM candidates
+			ORDER BY id, tier
+		)
+		SELECT
+			r.source,
+			COALESCE(a.score, 0),
+			p.id,
+			p.category_id,
+			p.brand_id,
+			p.image,
+			` + productIsFavorite("NULLIF($4, '')::UUID") + ` AS is_favorite,
+			p.name,
+			p.slug,
+			p.price,
+			p.with_discount,
+			` + productMinPrice + ` AS min_price,
+			` + productMaxPrice + ` AS max_price,
+			p.rating,
+			p.review_count,
+			p.description,
+			` + productItemCount + ` AS item_count,
+			p.status,
+			p.discount_percent,
+			p.discount_start_time,
+			p.discount_end_time,
+			p.created_at,
+			p.name_i18n::TEXT,
+			p.description_i18n::TEXT
+		FROM ranked r
+		INNER JOIN "product" p ON p.id = r.id
+		LEFT JOIN "product_affinity" a ON a.product_id = $1 AND a.related_product_id = r.id
+		WHERE p.deleted_at IS NULL AND ` + productItemCount + ` > 0
+		ORDER BY r.tier, COALESCE(a.score, 0) DESC, p.rating DESC, COALESCE(p.order_count, 0) DESC, p.created_at DESC
+		LIMIT $3
+	`
+
+	rows, err := u.db.Query(ctx, query, req.ProductId, req.Kind, req.Limit, req.CustomerId)
+	if err != nil {
+		u.log.Error("Error while getting product recommendations: " + err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+
+	for rows.Next() {
+		var (
+			source              sql.NullString
+			score               sql.NullFloat64
+			id                  sql.NullString
+			category_id         sql.NullString
+			brand_id            sql.NullString
+			image               sql.NullString
+			is_favorite         sql.NullBool
+			name                sql.NullString
+			slug                sql.NullString
+			price               sql.NullFloat64
+			with_discount       sql.NullFloat64
+			min_price           sql.NullFloat64
+			max_price           sql.NullFloat64
+			rating              sql.NullFloat64
+			review_count        sql.NullInt64
+			description         sql.NullString
+			item_count          sql.NullInt64
+			status              sql.NullString
+			discount_percent    sql.NullFloat64
+			discount_start_time sql.NullString
+			discount_end_time   sql.NullString
+			created_at          sql.NullString
+			name_i18n           sql.NullString
+			desc_i18n           sql.NullString
+		)
+
+		err = rows.Scan(
+			&source,
+			&score,
+			&id,
+			&category_id,
+			&brand_id,
+			&image,
+			&is_favorite,
+			&name,
+			&slug,
+			&price,
+			&with_discount,
+			&min_price,
+			&max_price,
+			&rating,
+			&review_count,
+			&description,
+			&item_count,
+			&status,
+			&discount_percent,
+			&discount_start_time,
+			&discount_end_time,
+			&created_at,
+			&name_i18n,
+			&desc_i18n,
+		)
+		if err != nil {
+			u.log.Error("Error while scanning product recommendations: " + err.Error())
+			return nil, err
+		}
+
+		nameI18n, descriptionI18n := scanTranslations(name_i18n), scanTranslations(desc_i18n)
+
+		ids = append(ids, id.String)
+		resp.Recommendations = append(resp.Recommendations, &models.ProductRecommendation{
+			Source: source.String,
+			Score:  score.Float64,
+			Product: models.Product{
+				Id:                id.String,
+				CategoryId:        category_id.String,
+				BrandId:           brand_id.String,
+				Image:             image.String,
+				IsFavorite:        is_favorite.Bool,
+				Name:              nameI18n.Localize(name.String, req.Lang),
+				Slug:              slug.String,
+				Price:             price.Float64,
+				WithDiscount:      with_discount.Float64,
+				MinPrice:          min_price.Float64,
+				MaxPrice:          max_price.Float64,
+				Rating:            rating.Float64,
+				ReviewCount:       int(review_count.Int64),
+				Description:       descriptionI18n.Localize(description.String, req.Lang),
+				ItemCount:         int(item_count.Int64),
+				Status:            status.String,
+				DiscountPercent:   discount_percent.Float64,
+				DiscountStartTime: discount_start_time.String,
+				DiscountEndTime:   discount_end_time.String,
+				CreatedAt:         created_at.String,
+				NameI18n:          nameI18n,
+				DescriptionI18n:   descriptionI18n,
+				Color:             []models.Color{},
+			},
+		})
+	}
+	if err = rows.Err(); err != nil {
+		u.log.Error("Error while iterating product recommendations: " + err.Error())
+		return nil, err
+	}
+
+	resp.Count = len(resp.Recommendations)
+	if len(ids) == 0 {
+		return resp, nil
+	}
+
+	colors, err := u.getColors(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, recommendation := range resp.Recommendations {
+		if productColors, ok := colors[recommendation.Product.Id]; ok {
+			recommendation.Product.Color = productColors
+		}
+	}
+
+	return resp, nil
+}
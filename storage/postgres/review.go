@@ -0,0 +1,384 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/models"
+	"e-commerce/pkg/logger"
+	"e-commerce/storage"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/lib/pq"
+)
+
+type reviewRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewReviewRepo initializes a new instance of reviewRepo
+func NewReviewRepo(db *pgxpool.Pool, log logger.LoggerI) *reviewRepo {
+	return &reviewRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// orderStatusDelivered is the order status after which its products can be
+// reviewed
+const orderStatusDelivered = "yetkazib berildi"
+
+const reviewColumns = `
+	r.id,
+	r.product_id,
+	r.customer_id,
+	COALESCE(c.name, ''),
+	r.rating,
+	r.text,
+	r.photos,
+	r.status,
+	r.moderation_note,
+	r.moderated_by::TEXT,
+	r.moderated_at,
+	r.created_at,
+	r.updated_at
+`
+
+// Create saves the review as pending, a customer can review a product once
+// and only after an order of it was delivered
+func (r *reviewRepo) Create(ctx context.Context, req *models.ReviewCreate) (*models.Review, error) {
+	var exists, delivered, reviewed bool
+
+	err := r.db.QueryRow(ctx, `
+		SELECT
+			EXISTS (SELECT 1 FROM "product" WHERE id = $1 AND deleted_at IS NULL),
+			EXISTS (
+				SELECT 1 FROM "order_items" oi
+				INNER JOIN "orders" o ON o.id = oi.order_id
+				WHERE oi.product_id = $1 AND o.customer_id = $2 AND o.status = $3
+			),
+			EXISTS (SELECT 1 FROM "product_review" WHERE product_id = $1 AND customer_id = $2)
+	`, req.ProductId, req.CustomerId, orderStatusDelivered).Scan(&exists, &delivered, &reviewed)
+	if err != nil {
+		r.log.Error("error while checking review eligibility", logger.Error(err))
+		return nil, err
+	}
+
+	switch {
+	case !exists:
+		return nil, storage.ErrProductNotFound
+	case !delivered:
+		return nil, storage.ErrReviewNotAllowed
+	case reviewed:
+		return nil, storage.ErrReviewExists
+	}
+
+	id := uuid.New().String()
+
+	query := `
+		INSERT INTO "product_review" (
+			id,
+			product_id,
+			customer_id,
+			rating,
+			text,
+			photos,
+			status,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
+		ON CONFLICT (product_id, customer_id) DO NOTHING
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		id,
+		req.ProductId,
+		req.CustomerId,
+		req.Rating,
+		req.Text,
+		pq.StringArray(req.Photos),
+		models.ReviewStatusPending,
+	)
+	if err != nil {
+		r.log.Error("error while creating review", logger.Error(err))
+		return nil, err
+	}
+
+	// a concurrent request of the same customer won
+	if result.RowsAffected() == 0 {
+		return nil, storage.ErrReviewExists
+	}
+
+	return r.GetByID(ctx, &models.ReviewPrimaryKey{Id: id})
+}
+
+func (r *reviewRepo) GetByID(ctx context.Context, req *models.ReviewPrimaryKey) (*models.Review, error) {
+	query := `
+		SELECT` + reviewColumns + `
+		FROM "product_review" r
+		LEFT JOIN "customer" c ON c.id = r.customer_id
+		WHERE r.id = $1
+	`
+
+	review, err := scanReview(r.db.QueryRow(ctx, query, req.Id))
+	if err == pgx.ErrNoRows {
+		return nil, storage.ErrReviewNotFound
+	}
+	if err != nil {
+		r.log.Error("error while getting review", logger.Error(err))
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// GetList returns the reviews matching the filters. The rating summary of the
+// product is added when approved reviews of a product are listed.
+func (r *reviewRepo) GetList(ctx context.Context, req *models.ReviewGetListRequest) (*models.ReviewGetListResponse, error) {
+	var (
+		resp  = &models.ReviewGetListResponse{Reviews: []*models.Review{}}
+		where = " WHERE 1=1"
+		args  = []interface{}{}
+	)
+
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if req.ProductId != "" {
+		where += " AND r.product_id = " + arg(req.ProductId)
+	}
+	if req.CustomerId != "" {
+		where += " AND r.customer_id = " + arg(req.CustomerId)
+	}
+	if req.Status != "" {
+		where += " AND r.status = " + arg(req.Status)
+	}
+	if req.Rating > 0 {
+		where += " AND r.rating = " + arg(req.Rating)
+	}
+	if req.WithPhotos {
+		where += " AND cardinality(r.photos) > 0"
+	}
+
+	orderBy := " ORDER BY r.created_at DESC"
+	switch req.Sort {
+	case models.ReviewSortRatingDesc:
+		orderBy = " ORDER BY r.rating DESC, r.created_at DESC"
+	case models.ReviewSortRatingAsc:
+		orderBy = " ORDER BY r.rating ASC, r.created_at DESC"
+	}
+
+	query := `
+		SELECT
+			COUNT(*) OVER(),` + reviewColumns + `
+		FROM "product_review" r
+		LEFT JOIN "customer" c ON c.id = r.customer_id
+	` + where + orderBy + fmt.Sprintf(" LIMIT %s OFFSET %s", arg(req.Limit), arg(req.Offset))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.log.Error("error while getting review list", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var count int
+		review, err := scanReview(rows, &count)
+		if err != nil {
+			r.log.Error("error while scanning review list", logger.Error(err))
+			return nil, err
+		}
+		resp.Count = count
+		resp.Reviews = append(resp.Reviews, review)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if req.ProductId != "" && req.Status == models.ReviewStatusApproved {
+		resp.Summary, err = r.summary(ctx, req.ProductId)
+		if err != nil {
+			r.log.Error("error while getting review summary", logger.Error(err))
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// Moderate approves or rejects the review and recomputes the rating of its
+// product
+func (r *reviewRepo) Moderate(ctx context.Context, req *models.ReviewModerate) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var productId string
+	err = tx.QueryRow(ctx, `
+		UPDATE "product_review"
+		SET
+			status = $1,
+			moderation_note = $2,
+			moderated_by = NULLIF($3, '')::UUID,
+			moderated_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $4
+		RETURNING product_id
+	`, req.Status, req.ModerationNote, req.ModeratedBy, req.Id).Scan(&productId)
+	if err == pgx.ErrNoRows {
+		return storage.ErrReviewNotFound
+	}
+	if err != nil {
+		r.log.Error("error while moderating review", logger.Error(err))
+		return err
+	}
+
+	if err = refreshProductRating(ctx, tx, productId); err != nil {
+		r.log.Error("error while refreshing product rating", logger.Error(err))
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *reviewRepo) Delete(ctx context.Context, req *models.ReviewPrimaryKey) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var productId string
+	err = tx.QueryRow(ctx, `DELETE FROM "product_review" WHERE id = $1 RETURNING product_id`, req.Id).Scan(&productId)
+	if err == pgx.ErrNoRows {
+		return storage.ErrReviewNotFound
+	}
+	if err != nil {
+		r.log.Error("error while deleting review", logger.Error(err))
+		return err
+	}
+
+	if err = refreshProductRating(ctx, tx, productId); err != nil {
+		r.log.Error("error while refreshing product rating", logger.Error(err))
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *reviewRepo) summary(ctx context.Context, productId string) (*models.ReviewSummary, error) {
+	summary := &models.ReviewSummary{Distribution: []models.RatingCount{}}
+
+	err := r.db.QueryRow(ctx, `SELECT COALESCE(rating, 0), review_count FROM "product" WHERE id = $1`, productId).
+		Scan(&summary.Rating, &summary.ReviewCount)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT s.rating, COUNT(r.id)
+		FROM generate_series(5, 1, -1) AS s(rating)
+		LEFT JOIN "product_review" r ON r.rating = s.rating AND r.product_id = $1 AND r.status = $2
+		GROUP BY s.rating
+		ORDER BY s.rating DESC
+	`, productId, models.ReviewStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var count models.RatingCount
+		if err = rows.Scan(&count.Rating, &count.Count); err != nil {
+			return nil, err
+		}
+		summary.Distribution = append(summary.Distribution, count)
+	}
+
+	return summary, rows.Err()
+}
+
+// refreshProductRating sets the rating of the product to the average of its
+// approved reviews and counts them
+func refreshProductRating(ctx context.Context, tx pgx.Tx, productId string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE "product" p
+		SET
+			rating = COALESCE(s.rating, 0),
+			review_count = s.review_count
+		FROM (
+			SELECT ROUND(AVG(rating), 1) AS rating, COUNT(*) AS review_count
+			FROM "product_review"
+			WHERE product_id = $1 AND status = $2
+		) s
+		WHERE p.id = $1
+	`, productId, models.ReviewStatusApproved)
+	return err
+}
+
+func scanReview(row pgx.Row, prefix ...interface{}) (*models.Review, error) {
+	var (
+		id              sql.NullString
+		product_id      sql.NullString
+		customer_id     sql.NullString
+		customer_name   sql.NullString
+		rating          sql.NullInt64
+		text            sql.NullString
+		photos          pq.StringArray
+		status          sql.NullString
+		moderation_note sql.NullString
+		moderated_by    sql.NullString
+		moderated_at    sql.NullString
+		created_at      sql.NullString
+		updated_at      sql.NullString
+	)
+
+	dest := append(prefix,
+		&id,
+		&product_id,
+		&customer_id,
+		&customer_name,
+		&rating,
+		&text,
+		&photos,
+		&status,
+		&moderation_note,
+		&moderated_by,
+		&moderated_at,
+		&created_at,
+		&updated_at,
+	)
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	review := &models.Review{
+		Id:             id.String,
+		ProductId:      product_id.String,
+		CustomerId:     customer_id.String,
+		CustomerName:   customer_name.String,
+		Rating:         int(rating.Int64),
+		Text:           text.String,
+		Photos:         photos,
+		Status:         status.String,
+		ModerationNote: moderation_note.String,
+		ModeratedBy:    moderated_by.String,
+		ModeratedAt:    moderated_at.String,
+		CreatedAt:      created_at.String,
+		UpdatedAt:      updated_at.String,
+	}
+	if review.Photos == nil {
+		review.Photos = []string{}
+	}
+
+	return review, nil
+}
@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/models"
+	"e-commerce/pkg/logger"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/lib/pq"
+)
+
+type roleRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewRoleRepo initializes a new instance of roleRepo
+func NewRoleRepo(db *pgxpool.Pool, log logger.LoggerI) *roleRepo {
+	return &roleRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Create inserts a new admin role together with its permissions
+func (r *roleRepo) Create(ctx context.Context, req *models.RoleCreate) (*models.Role, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.log.Error("error while starting role create transaction", logger.Error(err))
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	id := uuid.New().String()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO "admin_role" (
+			id,
+			name,
+			description,
+			created_at
+		)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+	`, id, req.Name, req.Description)
+	if err != nil {
+		r.log.Error("error while creating role", logger.Error(err))
+		return nil, err
+	}
+
+	if err = r.setPermissions(ctx, tx, id, req.Permissions); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		r.log.Error("error while committing role create", logger.Error(err))
+		return nil, err
+	}
+
+	return r.GetByID(ctx, &models.RolePrimaryKey{Id: id})
+}
+
+func (r *roleRepo) GetByID(ctx context.Context, req *models.RolePrimaryKey) (*models.Role, error) {
+	var (
+		id          sql.NullString
+		name        sql.NullString
+		description sql.NullString
+		permissions pq.StringArray
+		created_at  sql.NullString
+		updated_at  sql.NullString
+	)
+
+	query := `
+		SELECT
+			r.id,
+			r.name,
+			r.description,
+			COALESCE(array_agg(p.permission ORDER BY p.permission) FILTER (WHERE p.permission IS NOT NULL), '{}'),
+			r.created_at,
+			r.updated_at
+		FROM "admin_role" AS r
+		LEFT JOIN "admin_role_permission" AS p ON p.role_id = r.id
+		WHERE r.id = $1
+		GROUP BY r.id
+	`
+
+	err := r.db.QueryRow(ctx, query, req.Id).Scan(
+		&id,
+		&name,
+		&description,
+		&permissions,
+		&created_at,
+		&updated_at,
+	)
+	if err != nil {
+		r.log.Error("error while getting role by id", logger.Error(err))
+		return nil, err
+	}
+
+	return &models.Role{
+		Id:          id.String,
+		Name:        name.String,
+		Description: description.String,
+		Permissions: permissions,
+		CreatedAt:   created_at.String,
+		UpdatedAt:   updated_at.String,
+	}, nil
+}
+
+func (r *roleRepo) GetList(ctx context.Context, req *models.RoleGetListRequest) (*models.RoleGetListResponse, error) {
+	var (
+		resp   = &models.RoleGetListResponse{}
+		query  string
+		offset = " OFFSET 0"
+		limit  = " LIMIT 10"
+	)
+
+	query = `
+		SELECT
+			COUNT(*) OVER(),
+			r.id,
+			r.name,
+			r.description,
+			COALESCE(array_agg(p.permission ORDER BY p.permission) FILTER (WHERE p.permission IS NOT NULL), '{}'),
+			r.created_at,
+			r.updated_at
+		FROM "admin_role" AS r
+		LEFT JOIN "admin_role_permission" AS p ON p.role_id = r.id
+		GROUP BY r.id
+		ORDER BY r.created_at
+	`
+
+	if req.Offset > 0 {
+		offset = fmt.Sprintf(" OFFSET %d", req.Offset)
+	}
+
+	if req.Limit > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", req.Limit)
+	}
+
+	query += offset + limit
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		r.log.Error("error is while getting role list", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id          sql.NullString
+			name        sql.NullString
+			description sql.NullString
+			permissions pq.StringArray
+			created_at  sql.NullString
+			updated_at  sql.NullString
+		)
+
+		err = rows.Scan(
+			&resp.Count,
+			&id,
+			&name,
+			&description,
+			&permissions,
+			&created_at,
+			&updated_at,
+		)
+		if err != nil {
+			r.log.Error("error is while getting role list (scanning data)", logger.Error(err))
+			return nil, err
+		}
+
+		resp.Roles = append(resp.Roles, &models.Role{
+			Id:          id.String,
+			Name:        name.String,
+			Description: description.String,
+			Permissions: permissions,
+			CreatedAt:   created_at.String,
+			UpdatedAt:   updated_at.String,
+		})
+	}
+
+	return resp, rows.Err()
+}
+
+// Update renames the role and replaces its permission set
+func (r *roleRepo) Update(ctx context.Context, req *models.RoleUpdate) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.log.Error("error while starting role update transaction", logger.Error(err))
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE
+			"admin_role"
+		SET
+			name = $1,
+			description = $2,
+			updated_at = NOW()
+		WHERE id = $3
+	`, req.Name, req.Description, req.Id)
+	if err != nil {
+		r.log.Error("error is while updating role", logger.Error(err))
+		return 0, err
+	}
+
+	if result.RowsAffected() == 0 {
+		return 0, nil
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM "admin_role_permission" WHERE role_id = $1`, req.Id)
+	if err != nil {
+		r.log.Error("error is while clearing role permissions", logger.Error(err))
+		return 0, err
+	}
+
+	if err = r.setPermissions(ctx, tx, req.Id, req.Permissions); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		r.log.Error("error while committing role update", logger.Error(err))
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *roleRepo) setPermissions(ctx context.Context, tx pgx.Tx, roleID string, permissions []string) error {
+	for _, permission := range permissions {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO "admin_role_permission" (role_id, permission)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, roleID, permission)
+		if err != nil {
+			r.log.Error("error while setting role permission", logger.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/models"
+	"e-commerce/pkg/logger"
+	"e-commerce/pkg/slug"
+	"e-commerce/storage"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type slugRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewSlugRepo initializes a new instance of slugRepo
+func NewSlugRepo(db *pgxpool.Pool, log logger.LoggerI) *slugRepo {
+	return &slugRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// GetRedirect returns the current slug of the entity that used to have the
+// requested one
+func (s *slugRepo) GetRedirect(ctx context.Context, req *models.SlugRedirectRequest) (string, error) {
+	if !isSlugEntity(req.EntityType) {
+		return "", fmt.Errorf("unknown slug entity type: %s", req.EntityType)
+	}
+
+	query := `
+		SELECT
+			e.slug
+		FROM "slug_history" h
+		INNER JOIN "` + req.EntityType + `" e ON e.id = h.entity_id
+		WHERE h.entity_type = $1 AND h.slug = $2
+	`
+
+	var current sql.NullString
+	err := s.db.QueryRow(ctx, query, req.EntityType, req.Slug).Scan(&current)
+	if err == pgx.ErrNoRows {
+		return "", storage.ErrSlugNotFound
+	}
+	if err != nil {
+		s.log.Error("error while getting slug redirect", logger.Error(err))
+		return "", err
+	}
+
+	return current.String, nil
+}
+
+func isSlugEntity(entityType string) bool {
+	switch entityType {
+	case models.SlugEntityProduct, models.SlugEntityCategory, models.SlugEntityBrand:
+		return true
+	}
+	return false
+}
+
+type slugQuerier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+// uniqueSlug makes a slug from the name that no other row of the entity's
+// table uses now or used before, adding -2, -3 and so on when it is taken
+func uniqueSlug(ctx context.Context, db slugQuerier, entityType, name, id string) (string, error) {
+	base := slug.Make(name)
+	if base == "" {
+		base = entityType
+	}
+
+	query := `
+		SELECT slug FROM "` + entityType + `"
+		WHERE id <> $3 AND (slug = $1 OR slug LIKE $2)
+		UNION
+		SELECT slug FROM "slug_history"
+		WHERE entity_type = $4 AND entity_id <> $3 AND (slug = $1 OR slug LIKE $2)
+	`
+
+	rows, err := db.Query(ctx, query, base, base+"-%", id, entityType)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	taken := map[string]bool{}
+	for rows.Next() {
+		var used string
+		if err = rows.Scan(&used); err != nil {
+			return "", err
+		}
+		taken[used] = true
+	}
+	if err = rows.Err(); err != nil {
+		return "", err
+	}
+
+	candidate := base
+	for i := 2; taken[candidate]; i++ {
+		candidate = base + "-" + strconv.Itoa(i)
+	}
+
+	return candidate, nil
+}
+
+// renameSlug gives the row a slug from its new name and keeps the previous
+// one in the slug history, so links to it redirect. The slug stays as is
+// when the new name gives the same one.
+func renameSlug(ctx context.Context, tx pgx.Tx, entityType, id, name string) error {
+	var current sql.NullString
+
+	err := tx.QueryRow(ctx, `SELECT slug FROM "`+entityType+`" WHERE id = $1 FOR UPDATE`, id).Scan(&current)
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if current.String != "" && slugOf(current.String, name, entityType) {
+		return nil
+	}
+
+	next, err := uniqueSlug(ctx, tx, entityType, name, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE "`+entityType+`" SET slug = $1 WHERE id = $2`, next, id)
+	if err != nil {
+		return err
+	}
+
+	// the entity may take back one of its own old slugs
+	_, err = tx.Exec(ctx, `DELETE FROM "slug_history" WHERE entity_type = $1 AND slug = $2`, entityType, next)
+	if err != nil {
+		return err
+	}
+
+	if current.String == "" {
+		return nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO "slug_history" (
+			entity_type,
+			slug,
+			entity_id,
+			created_at
+		)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+		ON CONFLICT (entity_type, slug) DO UPDATE SET
+			entity_id = EXCLUDED.entity_id,
+			created_at = EXCLUDED.created_at
+	`, entityType, current.String, id)
+
+	return err
+}
+
+// slugOf reports whether the slug was made from the name, with or without
+// a number added to make it unique
+func slugOf(current, name, entityType string) bool {
+	base := slug.Make(name)
+	if base == "" {
+		base = entityType
+	}
+
+	if current == base {
+		return true
+	}
+
+	suffix := strings.TrimPrefix(current, base+"-")
+	if suffix == current {
+		return false
+	}
+
+	_, err := strconv.Atoi(suffix)
+	return err == nil
+}
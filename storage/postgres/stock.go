@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"e-commerce/models"
+	"e-commerce/storage"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// adjustStock changes the count of the color or variant row by the delta in a
+// single statement. It waits for a checkout holding the row and checks the
+// count it left, so the stock never goes below zero. live narrows the rows
+// that can be adjusted, notFound is returned when the row is not among them.
+// item matches the row among order items and ledger entries, a return is
+// checked against what the order bought of it while the row is held, so the
+// returns of one order never add back more than it bought.
+func adjustStock(ctx context.Context, db *pgxpool.Pool, table, live, item string, req *models.StockAdjustment, notFound error) (*models.StockAdjustmentResponse, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = setStockMovement(ctx, tx, req.Reason, req.OrderId, req.ChangedBy); err != nil {
+		return nil, err
+	}
+
+	var count int
+
+	err = tx.QueryRow(ctx, `
+		UPDATE "`+table+`"
+		SET count = COALESCE(count, 0) + $1, updated_at = NOW()
+		WHERE id = $2 AND `+live+` AND COALESCE(count, 0) + $1 >= 0
+		RETURNING count
+	`, req.Delta, req.Id).Scan(&count)
+	if err == pgx.ErrNoRows {
+		var exists bool
+		err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM "`+table+`" WHERE id = $1 AND `+live+`)`, req.Id).Scan(&exists)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, notFound
+		}
+		return nil, storage.ErrStockNegative
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Reason == models.StockReasonReturn {
+		// the ledger already holds this return
+		var bought, returned int
+		err = tx.QueryRow(ctx, `
+			SELECT
+				COALESCE((SELECT SUM(quantity) FROM "order_items" WHERE order_id = $1 AND `+item+`), 0)::INT,
+				COALESCE((SELECT SUM(quantity) FROM "inventory_movement" WHERE order_id = $1 AND `+item+` AND kind = $3), 0)::INT
+		`, req.OrderId, req.Id, models.StockReasonReturn).Scan(&bought, &returned)
+		if err != nil {
+			return nil, err
+		}
+		if returned > bought {
+			return nil, storage.ErrReturnExceedsOrder
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &models.StockAdjustmentResponse{
+		Id:          req.Id,
+		Reason:      req.Reason,
+		Delta:       req.Delta,
+		CountBefore: count - req.Delta,
+		Count:       count,
+	}, nil
+}
@@ -0,0 +1,454 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/models"
+	"e-commerce/pkg/logger"
+	"e-commerce/pkg/slug"
+	"e-commerce/storage"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/lib/pq"
+)
+
+type variantRepo struct {
+	db  *pgxpool.Pool
+	log logger.LoggerI
+}
+
+// NewVariantRepo initializes a new instance of variantRepo
+func NewVariantRepo(db *pgxpool.Pool, log logger.LoggerI) *variantRepo {
+	return &variantRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+const variantColumns = `
+	v.id,
+	v.product_id,
+	v.sku,
+	v.options,
+	v.price,
+	` + productVariantPrice + ` AS effective_price,
+	v.count,
+	v.images,
+	v.color_id,
+	v.created_at,
+	v.updated_at
+`
+
+// Create adds a variant to the product, the sku is generated from the product
+// slug and the option values when it is not given
+func (v *variantRepo) Create(ctx context.Context, req *models.ProductVariantCreate) (*models.ProductVariant, error) {
+	var productSlug sql.NullString
+
+	err := v.db.QueryRow(ctx, `SELECT slug FROM "product" WHERE id = $1`, req.ProductId).Scan(&productSlug)
+	if err == pgx.ErrNoRows {
+		return nil, storage.ErrProductNotFound
+	}
+	if err != nil {
+		v.log.Error("error while getting variant product", logger.Error(err))
+		return nil, err
+	}
+
+	id := uuid.New().String()
+	options := normalizeOptions(req.Options)
+
+	tx, err := v.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockVariants(ctx, tx, req.ProductId, ""); err != nil {
+		v.log.Error("error while locking product variants", logger.Error(err))
+		return nil, err
+	}
+
+	sku := strings.TrimSpace(req.Sku)
+	if sku == "" {
+		sku, err = v.freeSku(ctx, tx, variantSku(productSlug.String, options))
+		if err != nil {
+			v.log.Error("error while generating variant sku", logger.Error(err))
+			return nil, err
+		}
+	}
+
+	if err = lockVariants(ctx, tx, "", sku); err != nil {
+		v.log.Error("error while locking variant sku", logger.Error(err))
+		return nil, err
+	}
+
+	if err = v.checkUnique(ctx, tx, id, req.ProductId, sku, options); err != nil {
+		return nil, err
+	}
+
+	optionsJson, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO "product_variant" (
+			id,
+			product_id,
+			sku,
+			options,
+			price,
+			count,
+			images,
+			color_id,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4::JSONB, $5, $6, $7, NULLIF($8, '')::UUID, CURRENT_TIMESTAMP)
+	`
+
+	if err = setPriceChange(ctx, tx, req.PriceChangedBy, req.PriceChangeReason); err != nil {
+		v.log.Error("error while setting variant price change", logger.Error(err))
+		return nil, err
+	}
+
+	if err = setStockMovement(ctx, tx, models.InventoryMovementInitial, "", req.PriceChangedBy); err != nil {
+		v.log.Error("error while setting variant stock movement", logger.Error(err))
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, query,
+		id,
+		req.ProductId,
+		sku,
+		string(optionsJson),
+		req.Price,
+		req.Count,
+		pq.StringArray(req.Images),
+		req.ColorId,
+	)
+	if err != nil {
+		v.log.Error("error while creating variant", logger.Error(err))
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return v.GetByID(ctx, &models.ProductVariantPrimaryKey{Id: id})
+}
+
+func (v *variantRepo) GetByID(ctx context.Context, req *models.ProductVariantPrimaryKey) (*models.ProductVariant, error) {
+	query := `
+		SELECT` + variantColumns + `
+		FROM "product_variant" v
+		INNER JOIN "product" p ON p.id = v.product_id
+		WHERE v.id = $1
+	`
+
+	variant, err := scanVariant(v.db.QueryRow(ctx, query, req.Id))
+	if err != nil {
+		if err != pgx.ErrNoRows {
+			v.log.Error("error while getting variant", logger.Error(err))
+		}
+		return nil, err
+	}
+
+	return variant, nil
+}
+
+func (v *variantRepo) GetList(ctx context.Context, req *models.ProductVariantGetListRequest) (*models.ProductVariantGetListResponse, error) {
+	query := `
+		SELECT` + variantColumns + `
+		FROM "product_variant" v
+		INNER JOIN "product" p ON p.id = v.product_id
+		WHERE v.product_id = $1
+		ORDER BY v.created_at
+	`
+
+	rows, err := v.db.Query(ctx, query, req.ProductId)
+	if err != nil {
+		v.log.Error("error while getting variant list", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	resp := &models.ProductVariantGetListResponse{Variants: []*models.ProductVariant{}}
+	for rows.Next() {
+		variant, err := scanVariant(rows)
+		if err != nil {
+			v.log.Error("error while scanning variant list", logger.Error(err))
+			return nil, err
+		}
+		resp.Variants = append(resp.Variants, variant)
+	}
+	resp.Count = len(resp.Variants)
+
+	return resp, rows.Err()
+}
+
+func (v *variantRepo) Update(ctx context.Context, req *models.ProductVariantUpdate) (int64, error) {
+	var productId string
+
+	err := v.db.QueryRow(ctx, `SELECT product_id FROM "product_variant" WHERE id = $1`, req.Id).Scan(&productId)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		v.log.Error("error while getting variant product", logger.Error(err))
+		return 0, err
+	}
+
+	options := normalizeOptions(req.Options)
+	sku := strings.TrimSpace(req.Sku)
+
+	tx, err := v.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockVariants(ctx, tx, productId, sku); err != nil {
+		v.log.Error("error while locking product variants", logger.Error(err))
+		return 0, err
+	}
+
+	if err = v.checkUnique(ctx, tx, req.Id, productId, sku, options); err != nil {
+		return 0, err
+	}
+
+	optionsJson, err := json.Marshal(options)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		UPDATE
+			"product_variant"
+		SET
+			sku = COALESCE(NULLIF($1, ''), sku),
+			options = $2::JSONB,
+			price = $3,
+			images = $4,
+			color_id = NULLIF($5, '')::UUID,
+			updated_at = NOW()
+		WHERE id = $6
+	`
+
+	if err = setPriceChange(ctx, tx, req.PriceChangedBy, req.PriceChangeReason); err != nil {
+		v.log.Error("error while setting variant price change", logger.Error(err))
+		return 0, err
+	}
+
+	result, err := tx.Exec(ctx, query,
+		sku,
+		string(optionsJson),
+		req.Price,
+		pq.StringArray(req.Images),
+		req.ColorId,
+		req.Id,
+	)
+	if err != nil {
+		v.log.Error("error while updating variant", logger.Error(err))
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// AdjustStock adds the delta to the stock of the variant
+func (v *variantRepo) AdjustStock(ctx context.Context, req *models.StockAdjustment) (*models.StockAdjustmentResponse, error) {
+	return adjustStock(ctx, v.db, "product_variant", "TRUE", "variant_id = $2", req, storage.ErrVariantNotFound)
+}
+
+// Delete removes a variant no order refers to
+func (v *variantRepo) Delete(ctx context.Context, req *models.ProductVariantPrimaryKey) error {
+	var ordered bool
+
+	err := v.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM "order_items" WHERE variant_id = $1)`, req.Id).Scan(&ordered)
+	if err != nil {
+		v.log.Error("error while checking variant orders", logger.Error(err))
+		return err
+	}
+
+	if ordered {
+		return storage.ErrVariantInUse
+	}
+
+	_, err = v.db.Exec(ctx, `DELETE FROM "product_variant" WHERE id = $1`, req.Id)
+	if err != nil {
+		v.log.Error("error while deleting variant", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// lockVariants holds the variant writes of the product and of the sku, the
+// ones that are given, until the transaction ends so the uniqueness checks
+// that follow stay true until the row is written
+func lockVariants(ctx context.Context, tx pgx.Tx, productId, sku string) error {
+	for _, key := range []string{productId, sku} {
+		if key == "" {
+			continue
+		}
+		_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('product_variant:' || $1))`, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkUnique rejects a second variant of the product with the same options
+// and a sku used by another variant
+func (v *variantRepo) checkUnique(ctx context.Context, db rowQuerier, id, productId, sku string, options map[string]string) error {
+	optionsJson, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	var optionsTaken, skuTaken bool
+	err = db.QueryRow(ctx, `
+		SELECT
+			EXISTS (SELECT 1 FROM "product_variant" WHERE product_id = $1 AND options = $2::JSONB AND id <> $3),
+			EXISTS (SELECT 1 FROM "product_variant" WHERE sku = $4 AND id <> $3)
+	`, productId, string(optionsJson), id, sku).Scan(&optionsTaken, &skuTaken)
+	if err != nil {
+		v.log.Error("error while checking variant uniqueness", logger.Error(err))
+		return err
+	}
+
+	if optionsTaken {
+		return storage.ErrVariantExists
+	}
+	if skuTaken {
+		return storage.ErrVariantSkuTaken
+	}
+
+	return nil
+}
+
+// freeSku adds -2, -3 and so on to the sku until no variant uses it
+func (v *variantRepo) freeSku(ctx context.Context, db rowQuerier, sku string) (string, error) {
+	candidate := sku
+	for i := 2; ; i++ {
+		var taken bool
+		err := db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM "product_variant" WHERE sku = $1)`, candidate).Scan(&taken)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = sku + "-" + strconv.Itoa(i)
+	}
+}
+
+// variantSku makes "IPHONE-15-BLACK-256GB" from the product slug and the
+// option values sorted by option name
+func variantSku(productSlug string, options map[string]string) string {
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := []string{productSlug}
+	for _, key := range keys {
+		parts = append(parts, options[key])
+	}
+
+	sku := strings.ToUpper(slug.Make(strings.Join(parts, " ")))
+	if len(sku) > 60 {
+		sku = strings.TrimRight(sku[:60], "-")
+	}
+	if sku == "" {
+		sku = "SKU"
+	}
+
+	return sku
+}
+
+func normalizeOptions(options map[string]string) map[string]string {
+	normalized := make(map[string]string, len(options))
+	for key, value := range options {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		normalized[key] = strings.TrimSpace(value)
+	}
+	return normalized
+}
+
+func scanVariant(row pgx.Row) (*models.ProductVariant, error) {
+	var (
+		id              sql.NullString
+		product_id      sql.NullString
+		sku             sql.NullString
+		options         []byte
+		price           sql.NullFloat64
+		effective_price sql.NullFloat64
+		count           sql.NullInt64
+		images          pq.StringArray
+		color_id        sql.NullString
+		created_at      sql.NullString
+		updated_at      sql.NullString
+	)
+
+	err := row.Scan(
+		&id,
+		&product_id,
+		&sku,
+		&options,
+		&price,
+		&effective_price,
+		&count,
+		&images,
+		&color_id,
+		&created_at,
+		&updated_at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	variant := &models.ProductVariant{
+		Id:             id.String,
+		ProductId:      product_id.String,
+		Sku:            sku.String,
+		Options:        map[string]string{},
+		EffectivePrice: effective_price.Float64,
+		Count:          int(count.Int64),
+		Images:         images,
+		ColorId:        color_id.String,
+		CreatedAt:      created_at.String,
+		UpdatedAt:      updated_at.String,
+	}
+
+	if price.Valid {
+		variant.Price = &price.Float64
+	}
+	if variant.Images == nil {
+		variant.Images = []string{}
+	}
+
+	if len(options) > 0 {
+		if err = json.Unmarshal(options, &variant.Options); err != nil {
+			return nil, err
+		}
+	}
+
+	return variant, nil
+}
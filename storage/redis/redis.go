@@ -44,6 +44,15 @@ func (s Store) SetX(ctx context.Context, key string, value interface{}, duration
 	return nil
 }
 
+func (s Store) SetNX(ctx context.Context, key string, value interface{}, duration time.Duration) (bool, error) {
+	boolCmd := s.db.SetNX(ctx, key, value, duration)
+	if boolCmd.Err() != nil {
+		return false, boolCmd.Err()
+	}
+
+	return boolCmd.Val(), nil
+}
+
 func (s Store) Get(ctx context.Context, key string) (interface{}, error) {
 	resp := s.db.Get(ctx, key)
 
@@ -60,3 +69,29 @@ func (s Store) Del(ctx context.Context, key string) error {
 	}
 	return nil
 }
+
+// Incr increments the counter and starts its expiration on the first increment.
+func (s Store) Incr(ctx context.Context, key string, duration time.Duration) (int64, error) {
+	intCmd := s.db.Incr(ctx, key)
+	if intCmd.Err() != nil {
+		return 0, intCmd.Err()
+	}
+
+	if intCmd.Val() == 1 {
+		if err := s.db.Expire(ctx, key, duration).Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	return intCmd.Val(), nil
+}
+
+// Eval runs the lua script on the keys in one step, no other command runs
+// between its reads and writes.
+func (s Store) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	resp := s.db.Eval(ctx, script, keys, args...)
+	if resp.Err() != nil {
+		return nil, resp.Err()
+	}
+	return resp.Val(), nil
+}
@@ -18,13 +18,25 @@ type StorageI interface {
 	Banner() BannerI
 	Color() ColorI
 	Location() LocationI
+	Role() RoleI
+	Audit() AuditI
+	Slug() SlugI
+	Variant() VariantI
+	Media() MediaI
+	PriceHistory() PriceHistoryI
+	Review() ReviewI
+	Favorite() FavoriteI
+	Inventory() InventoryI
 	// Register() AuthRepoI
 }
 
 type RedisI interface {
 	SetX(ctx context.Context, key string, value interface{}, duration time.Duration) error
+	SetNX(ctx context.Context, key string, value interface{}, duration time.Duration) (bool, error)
 	Get(ctx context.Context, key string) (interface{}, error)
 	Del(ctx context.Context, key string) error
+	Incr(ctx context.Context, key string, duration time.Duration) (int64, error)
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
 }
 
 type AdminI interface {
@@ -35,6 +47,65 @@ type AdminI interface {
 	Delete(ctx context.Context, req *models.AdminPrimaryKey) error
 	GetByLogin(ctx context.Context, login string) (models.Admin, error)
 	GetByPhoneNumber(ctx context.Context, req string) (*models.Admin, error)
+	UpdatePasswordHash(ctx context.Context, id string, passwordHash string) error
+	UpdateRole(ctx context.Context, req *models.AdminRoleUpdate) (int64, error)
+	GetPermissions(ctx context.Context, id string) ([]string, error)
+}
+
+type RoleI interface {
+	Create(ctx context.Context, req *models.RoleCreate) (*models.Role, error)
+	GetByID(ctx context.Context, req *models.RolePrimaryKey) (*models.Role, error)
+	GetList(ctx context.Context, req *models.RoleGetListRequest) (*models.RoleGetListResponse, error)
+	Update(ctx context.Context, req *models.RoleUpdate) (int64, error)
+}
+
+type AuditI interface {
+	Create(ctx context.Context, req *models.AuditLogCreate) error
+	GetList(ctx context.Context, req *models.AuditGetListRequest) (*models.AuditGetListResponse, error)
+}
+
+type VariantI interface {
+	Create(ctx context.Context, req *models.ProductVariantCreate) (*models.ProductVariant, error)
+	GetByID(ctx context.Context, req *models.ProductVariantPrimaryKey) (*models.ProductVariant, error)
+	GetList(ctx context.Context, req *models.ProductVariantGetListRequest) (*models.ProductVariantGetListResponse, error)
+	Update(ctx context.Context, req *models.ProductVariantUpdate) (int64, error)
+	AdjustStock(ctx context.Context, req *models.StockAdjustment) (*models.StockAdjustmentResponse, error)
+	Delete(ctx context.Context, req *models.ProductVariantPrimaryKey) error
+}
+
+type MediaI interface {
+	Create(ctx context.Context, req *models.ProductMediaCreateRequest) (*models.ProductMediaGetListResponse, error)
+	GetByID(ctx context.Context, req *models.ProductMediaPrimaryKey) (*models.ProductMedia, error)
+	GetList(ctx context.Context, req *models.ProductMediaGetListRequest) (*models.ProductMediaGetListResponse, error)
+	Update(ctx context.Context, req *models.ProductMediaUpdate) (int64, error)
+	Reorder(ctx context.Context, req *models.ProductMediaReorder) error
+	Delete(ctx context.Context, req *models.ProductMediaPrimaryKey) error
+}
+
+type PriceHistoryI interface {
+	GetList(ctx context.Context, req *models.PriceHistoryGetListRequest) (*models.PriceHistoryGetListResponse, error)
+}
+
+type InventoryI interface {
+	GetList(ctx context.Context, req *models.InventoryMovementGetListRequest) (*models.InventoryMovementGetListResponse, error)
+	Reconcile(ctx context.Context, req *models.InventoryReconcileRequest) (*models.InventoryReconcileResponse, error)
+}
+
+type ReviewI interface {
+	Create(ctx context.Context, req *models.ReviewCreate) (*models.Review, error)
+	GetByID(ctx context.Context, req *models.ReviewPrimaryKey) (*models.Review, error)
+	GetList(ctx context.Context, req *models.ReviewGetListRequest) (*models.ReviewGetListResponse, error)
+	Moderate(ctx context.Context, req *models.ReviewModerate) error
+	Delete(ctx context.Context, req *models.ReviewPrimaryKey) error
+}
+
+type FavoriteI interface {
+	Create(ctx context.Context, req *models.FavoritePrimaryKey) (*models.Favorite, error)
+	Delete(ctx context.Context, req *models.FavoritePrimaryKey) error
+}
+
+type SlugI interface {
+	GetRedirect(ctx context.Context, req *models.SlugRedirectRequest) (string, error)
 }
 
 type CustomerI interface {
@@ -43,9 +114,11 @@ type CustomerI interface {
 	GetList(ctx context.Context, req *models.CustomerGetListRequest) (*models.CustomerGetListResponse, error)
 	Update(ctx context.Context, req *models.CustomerUpdate) (int64, error)
 	Delete(ctx context.Context, req *models.CustomerPrimaryKey) error
+	Restore(ctx context.Context, req *models.CustomerPrimaryKey) error
 	// Login(ctx context.Context, login models.Customer) (string, error)
 	GetByLogin(ctx context.Context, login string) (models.Customer, error)
 	GetByPhoneNumber(ctx context.Context, req string) (models.Customer, error)
+	UpdatePasswordHash(ctx context.Context, id string, passwordHash string) error
 }
 
 type BrandI interface {
@@ -54,6 +127,7 @@ type BrandI interface {
 	GetList(ctx context.Context, req *models.BrandGetListRequest) (*models.BrandGetListResponse, error)
 	Update(ctx context.Context, req *models.BrandUpdate) (int64, error)
 	Delete(ctx context.Context, req *models.BrandPrimaryKey) error
+	Restore(ctx context.Context, req *models.BrandPrimaryKey) error
 }
 
 type CategoryI interface {
@@ -62,6 +136,9 @@ type CategoryI interface {
 	GetList(ctx context.Context, req *models.CategoryGetListRequest) (*models.CategoryGetListResponse, error)
 	Update(ctx context.Context, req *models.CategoryUpdate) (int64, error)
 	Delete(ctx context.Context, req *models.CategoryPrimaryKey) error
+	Restore(ctx context.Context, req *models.CategoryPrimaryKey) error
+	GetTree(ctx context.Context, req *models.CategoryTreeRequest) (*models.CategoryTreeResponse, error)
+	GetBreadcrumbs(ctx context.Context, req *models.CategoryPrimaryKey) (*models.CategoryBreadcrumbsResponse, error)
 }
 
 // type OrderI interface {
@@ -77,7 +154,7 @@ type OrderI interface {
 	GetOrder(orderId string) (*models.OrderCreateRequest, error)
 	GetAll(ctx context.Context, request *models.OrderGetListRequest) (*[]models.OrderCreateRequest, error)
 	UpdateOrder(order models.Order) error
-	DeleteOrder(orderId string) error
+	DeleteOrder(orderId, deletedBy string) error
 }
 
 type ProductI interface {
@@ -86,6 +163,12 @@ type ProductI interface {
 	GetList(ctx context.Context, req *models.ProductGetListRequest) (*models.ProductGetListResponse, error)
 	Update(ctx context.Context, req *models.ProductUpdate) (int64, error)
 	Delete(ctx context.Context, req *models.ProductPrimaryKey) error
+	Restore(ctx context.Context, req *models.ProductPrimaryKey) error
+	ApplyDiscounts(ctx context.Context) (int64, error)
+	Import(ctx context.Context, req *models.ProductImportRequest) (*models.ProductImportReport, error)
+	Export(ctx context.Context, fn func(row *models.ProductCatalogRow) error) error
+	RefreshAffinity(ctx context.Context) (int64, error)
+	GetRecommendations(ctx context.Context, req *models.ProductRecommendationRequest) (*models.ProductRecommendationResponse, error)
 }
 
 type BannerI interface {
@@ -94,12 +177,17 @@ type BannerI interface {
 	GetList(ctx context.Context, req *models.BannerGetListRequest) (*models.BannerGetListResponse, error)
 	Update(ctx context.Context, req *models.BannerUpdate) (int64, error)
 	Delete(ctx context.Context, req *models.BannerPrimaryKey) error
+	Restore(ctx context.Context, req *models.BannerPrimaryKey) error
 }
 
 type ColorI interface {
 	Create(ctx context.Context, req *models.ColorCreate) (*models.Color, error)
+	GetByID(ctx context.Context, req *models.ColorPrimaryKey) (*models.Color, error)
 	GetList(ctx context.Context, req *models.ColorGetListRequest) (*models.ColorGetListResponse, error)
+	Update(ctx context.Context, req *models.ColorUpdate) (int64, error)
+	AdjustStock(ctx context.Context, req *models.StockAdjustment) (*models.StockAdjustmentResponse, error)
 	Delete(ctx context.Context, req *models.ColorPrimaryKey) error
+	Restore(ctx context.Context, req *models.ColorPrimaryKey) error
 }
 
 type LocationI interface {
@@ -108,6 +196,7 @@ type LocationI interface {
 	GetList(ctx context.Context, req *models.LocationGetListRequest) (*models.LocationGetListResponse, error)
 	Update(ctx context.Context, req *models.LocationUpdate) (int64, error)
 	Delete(ctx context.Context, req *models.LacationPrimaryKey) error
+	Restore(ctx context.Context, req *models.LacationPrimaryKey) error
 }
 
 // type AuthRepoI interface {